@@ -107,3 +107,15 @@ build_deb_builder() {
 
     cd ..
 }
+
+
+# Builds the chain data migrator image
+build_chain_data_migrator() {
+    cd hyperdrive || fail "Directory ${PWD}/hyperdrive does not exist or you don't have permissions to access it."
+
+    echo -n "Building chain data migrator..."
+    docker buildx build --rm --platform=linux/amd64,linux/arm64 -t nodeset/hyperdrive-chain-data-migrator:$VERSION -f docker/chain-data-migrator.dockerfile --push . || fail "Error building chain data migrator."
+    echo "done!"
+
+    cd ..
+}
@@ -0,0 +1,284 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The name of the container used to copy chain data
+	chainDataMigratorContainerSuffix string = "chain_data_migrator"
+
+	// The folder inside of an export directory that holds the copied chain data
+	ChainDataExportDataDir string = "data"
+
+	// The file inside of an export directory that lists the SHA-256 checksum of every file in the data folder
+	ChainDataExportManifestFile string = "manifest.sha256"
+
+	// The file inside of an export directory that describes the export
+	ChainDataExportInfoFile string = "export.json"
+
+	// Operations supported by the migrator script
+	chainDataOperationExport string = "export"
+	chainDataOperationImport string = "import"
+	chainDataOperationSize   string = "size"
+)
+
+// The script run by the chain data migrator, which needs an image with rsync and zstd. Both directions copy file-by-file and skip
+// files that were already copied by an earlier run, so an interrupted export or import can be resumed by running it again.
+const chainDataMigratorScript string = `set -e
+DATA=/chaindata
+EXT=/mnt/external
+STORE="$EXT/` + ChainDataExportDataDir + `"
+MANIFEST="$EXT/` + ChainDataExportManifestFile + `"
+INFO="$EXT/` + ChainDataExportInfoFile + `"
+
+# Returns success if the two files have the same modification time
+same_mtime() {
+    [ -f "$2" ] && [ ! "$1" -nt "$2" ] && [ ! "$1" -ot "$2" ]
+}
+
+# Copies the files in $1 to $2, converting them with "zstd $3" and adding or removing the $4 suffix
+convert_files() {
+    src=$1; dst=$2; args=$3; mode=$4
+    mkdir -p "$dst"
+    cd "$src"
+    find . -type d | while IFS= read -r d; do mkdir -p "$dst/$d"; done
+    find . -type f | while IFS= read -r f; do
+        if [ "$mode" = "add" ]; then out="$dst/$f.zst"; else case "$f" in *.zst) out="$dst/${f%.zst}";; *) continue;; esac; fi
+        if same_mtime "$f" "$out"; then continue; fi
+        zstd -q -f $args "$f" -o "$out.partial"
+        touch -r "$f" "$out.partial"
+        mv "$out.partial" "$out"
+    done
+
+    # Remove anything left over from an older copy or an interrupted run
+    cd "$dst"
+    find . -type f | while IFS= read -r f; do
+        if [ "$mode" = "add" ]; then case "$f" in *.zst) orig="$src/${f%.zst}";; *) orig="";; esac; else orig="$src/$f.zst"; fi
+        if [ -z "$orig" ] || [ ! -f "$orig" ]; then rm -f "$f"; fi
+    done
+}
+
+# Mirrors $1 into $2, compressing or decompressing if requested
+copy_files() {
+    case "$2" in
+        compress) convert_files "$1" "$3" "-T0" "add";;
+        decompress) convert_files "$1" "$3" "-d" "remove";;
+        *) rsync -a --partial --delete --info=progress2 "$1/" "$3/";;
+    esac
+}
+
+case "$OPERATION" in
+    size)
+        # Print the size of the exported data, in bytes, and nothing else
+        if [ -d "$STORE" ]; then
+            find "$STORE" -type f -exec stat -c %s {} + | awk '{ total += $1 } END { print total + 0 }'
+        else
+            echo 0
+        fi
+        exit 0
+        ;;
+    export)
+        printf '%s' "$EXPORT_INFO" > "$INFO"
+        if [ "$COMPRESS" = "true" ]; then MODE=compress; else MODE=copy; fi
+        echo "Copying chain data..."
+        copy_files "$DATA" "$MODE" "$STORE"
+        echo "Creating checksum manifest..."
+        cd "$STORE"
+        find . -type f | sort | while IFS= read -r f; do sha256sum "$f"; done > "$MANIFEST.partial"
+        mv "$MANIFEST.partial" "$MANIFEST"
+        printf '%s' "$EXPORT_INFO_COMPLETE" > "$INFO"
+        ;;
+    import)
+        echo "Verifying checksum manifest..."
+        cd "$STORE"
+        if [ "$(find . -type f | wc -l)" -ne "$(wc -l < "$MANIFEST")" ]; then
+            echo "The data folder doesn't have the same number of files as the manifest."
+            exit 1
+        fi
+        if ! sha256sum -c "$MANIFEST" > /tmp/manifest-check 2>&1; then
+            grep -v ': OK$' /tmp/manifest-check
+            echo "Checksum verification failed."
+            exit 1
+        fi
+        if [ "$COMPRESS" = "true" ]; then MODE=decompress; else MODE=copy; fi
+        echo "Copying chain data..."
+        copy_files "$STORE" "$MODE" "$DATA"
+        ;;
+    *)
+        echo "Unknown operation: $OPERATION"
+        exit 1
+        ;;
+esac
+echo "Done."
+`
+
+// The kind of chain data being exported or imported
+type ChainDataType string
+
+const (
+	ChainDataType_Execution ChainDataType = "ec"
+	ChainDataType_Beacon    ChainDataType = "bn"
+)
+
+// Get the container that owns this kind of chain data
+func (t ChainDataType) ContainerID() config.ContainerID {
+	switch t {
+	case ChainDataType_Beacon:
+		return config.ContainerID_BeaconNode
+	default:
+		return config.ContainerID_ExecutionClient
+	}
+}
+
+// Get a friendly name for this kind of chain data
+func (t ChainDataType) ClientName() string {
+	switch t {
+	case ChainDataType_Beacon:
+		return "Beacon Node"
+	default:
+		return "Execution client"
+	}
+}
+
+// Details about a chain data export, stored alongside the exported data
+type ChainDataExportInfo struct {
+	// The kind of chain data in the export
+	Type ChainDataType `json:"type"`
+
+	// The client that produced the data
+	Client string `json:"client"`
+
+	// The network the data belongs to
+	Network config.Network `json:"network"`
+
+	// True if every file in the data folder was compressed with zstd
+	Compressed bool `json:"compressed"`
+
+	// The size of the client volume when it was exported, in bytes
+	DataSize uint64 `json:"dataSize"`
+
+	// True once the export has finished and the manifest has been written
+	Complete bool `json:"complete"`
+
+	// The time the export was started
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Read the info file of a chain data export. Returns nil if the directory doesn't have one.
+func ReadChainDataExportInfo(dir string) (*ChainDataExportInfo, error) {
+	path := filepath.Join(dir, ChainDataExportInfoFile)
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading export info file [%s]: %w", path, err)
+	}
+
+	info := new(ChainDataExportInfo)
+	err = json.Unmarshal(bytes, info)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing export info file [%s]: %w", path, err)
+	}
+	return info, nil
+}
+
+// Gets the size of the data an earlier export left in an export directory, in bytes.
+// This runs in the migrator container so it can read the files the export wrote with the container's permissions.
+func (c *HyperdriveClient) GetChainDataExportSize(container string, exportDir string, image string) (uint64, error) {
+	containerCmd, err := c.getContainerCommand()
+	if err != nil {
+		return 0, err
+	}
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/mnt/external -e OPERATION=%s %s sh -c %s",
+		containerCmd,
+		shellescape.Quote(container),
+		shellescape.Quote(exportDir),
+		chainDataOperationSize,
+		shellescape.Quote(image),
+		shellescape.Quote(chainDataMigratorScript),
+	)
+	output, err := readOutput(cmd)
+	if err != nil {
+		return 0, fmt.Errorf("error getting export directory size: %w", err)
+	}
+
+	trimmedOutput := strings.TrimSpace(string(output))
+	size, err := strconv.ParseUint(trimmedOutput, 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing export directory size output [%s]: %w", trimmedOutput, err)
+	}
+	return size, nil
+}
+
+// Copies a client volume into an export directory, writing a checksum manifest and the provided export info alongside it.
+// Files that were already exported by an earlier run are skipped, so interrupted exports can be resumed.
+func (c *HyperdriveClient) RunChainDataExport(container string, volume string, targetDir string, image string, info ChainDataExportInfo) error {
+	info.Complete = false
+	startInfo, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("error serializing export info: %w", err)
+	}
+	info.Complete = true
+	completeInfo, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("error serializing export info: %w", err)
+	}
+
+	env := map[string]string{
+		"OPERATION":            chainDataOperationExport,
+		"COMPRESS":             fmt.Sprint(info.Compressed),
+		"EXPORT_INFO":          string(startInfo),
+		"EXPORT_INFO_COMPLETE": string(completeInfo),
+	}
+	return c.runChainDataMigrator(container, volume, targetDir, image, env)
+}
+
+// Verifies the checksum manifest of an export directory and copies its data into a client volume, replacing the volume's contents.
+// Files that were already imported by an earlier run are skipped, so interrupted imports can be resumed.
+func (c *HyperdriveClient) RunChainDataImport(container string, volume string, sourceDir string, image string, compressed bool) error {
+	env := map[string]string{
+		"OPERATION": chainDataOperationImport,
+		"COMPRESS":  fmt.Sprint(compressed),
+	}
+	return c.runChainDataMigrator(container, volume, sourceDir, image, env)
+}
+
+// Runs the chain data migrator script with the client volume and external directory mounted
+func (c *HyperdriveClient) runChainDataMigrator(container string, volume string, externalDir string, image string, env map[string]string) error {
+	containerCmd, err := c.getContainerCommand()
+	if err != nil {
+		return err
+	}
+	envArgs := ""
+	for key, value := range env {
+		envArgs += fmt.Sprintf(" -e %s=%s", key, shellescape.Quote(value))
+	}
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/chaindata -v %s:/mnt/external%s %s sh -c %s",
+		containerCmd,
+		shellescape.Quote(container),
+		shellescape.Quote(volume),
+		shellescape.Quote(externalDir),
+		envArgs,
+		shellescape.Quote(image),
+		shellescape.Quote(chainDataMigratorScript),
+	)
+	return printOutput(cmd)
+}
+
+// Get the name of the container used to migrate chain data
+func GetChainDataMigratorContainerName(cfg *GlobalConfig) string {
+	return cfg.Hyperdrive.GetDockerArtifactName(chainDataMigratorContainerSuffix)
+}
@@ -0,0 +1,290 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The file in the user directory that holds the last verified checkpoint
+	VerifiedCheckpointFile string = "checkpoint.json"
+
+	// The timeout for each request to a checkpoint source
+	checkpointRequestTimeout time.Duration = 15 * time.Second
+
+	// The Beacon API route for block headers
+	beaconHeaderRoute string = "eth/v1/beacon/headers/%s"
+)
+
+// A Beacon Node that can be asked for the finalized checkpoint
+type CheckpointSource struct {
+	// A friendly name for the source
+	Name string
+
+	// The URL of the source's Beacon API
+	Url string
+}
+
+// A finalized block header reported by a checkpoint source
+type CheckpointHeader struct {
+	Slot      uint64
+	BlockRoot string
+	StateRoot string
+}
+
+// The result of querying a single checkpoint source
+type CheckpointSourceResult struct {
+	Source CheckpointSource
+	Header *CheckpointHeader
+	Error  error
+}
+
+// The result of cross-verifying the finalized checkpoint across several sources
+type CheckpointVerification struct {
+	// The slot that every source was compared at
+	Slot uint64
+
+	// The results for each source, in the order they were provided. The first one is the source being verified.
+	Results []CheckpointSourceResult
+
+	// True if every source that responded reported the same block root and state root
+	Agreed bool
+
+	// The number of sources that responded
+	Responded int
+}
+
+// A checkpoint that was confirmed by multiple sources, saved so it can be checked again later
+type VerifiedCheckpoint struct {
+	Network    config.Network `json:"network"`
+	Slot       uint64         `json:"slot"`
+	BlockRoot  string         `json:"blockRoot"`
+	StateRoot  string         `json:"stateRoot"`
+	Sources    []string       `json:"sources"`
+	VerifiedAt time.Time      `json:"verifiedAt"`
+}
+
+// Check if the verification is good enough to trust: the first source responded, at least one other source did too, and they all agreed
+func (v *CheckpointVerification) IsVerified() bool {
+	return v.PrimaryResponded() && v.Agreed && v.Responded >= 2
+}
+
+// Check if the first source, which is the one being verified, responded
+func (v *CheckpointVerification) PrimaryResponded() bool {
+	return len(v.Results) > 0 && v.Results[0].Error == nil
+}
+
+// Get the header that the responding sources agreed on, or nil if they didn't agree
+func (v *CheckpointVerification) GetAgreedHeader() *CheckpointHeader {
+	if !v.Agreed {
+		return nil
+	}
+	for _, result := range v.Results {
+		if result.Header != nil {
+			return result.Header
+		}
+	}
+	return nil
+}
+
+// Get the sources to compare the checkpoint across: the checkpoint sync provider, any additional providers, and the fallback BN if enabled.
+// Blank and duplicate URLs are ignored.
+func GetCheckpointSources(cfg *GlobalConfig) []CheckpointSource {
+	sources := []CheckpointSource{}
+	seen := map[string]bool{}
+	add := func(name string, url string) {
+		url = strings.TrimRight(strings.TrimSpace(url), "/")
+		if url == "" || seen[url] {
+			return
+		}
+		seen[url] = true
+		sources = append(sources, CheckpointSource{Name: name, Url: url})
+	}
+
+	add("Checkpoint Sync Provider", cfg.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value)
+	for i, url := range strings.Split(cfg.Cli.CheckpointSync.AdditionalProviders.Value, ",") {
+		add(fmt.Sprintf("Additional Provider %d", i+1), url)
+	}
+	if cfg.Hyperdrive.Fallback.UseFallbackClients.Value {
+		add("Fallback Beacon Node", cfg.Hyperdrive.Fallback.BnHttpUrl.Value)
+	}
+	return sources
+}
+
+// Ask each source for its finalized checkpoint and compare them. The first source is the one being verified, such as the checkpoint sync provider.
+// Sources may have finalized different epochs, so they're all compared at the oldest finalized slot that any of them reported.
+func VerifyCheckpoint(ctx context.Context, sources []CheckpointSource) *CheckpointVerification {
+	verification := &CheckpointVerification{
+		Results: make([]CheckpointSourceResult, len(sources)),
+	}
+
+	// Get the finalized header from each source
+	minSlot := uint64(0)
+	for i, source := range sources {
+		header, err := getBeaconHeader(ctx, source.Url, "finalized")
+		verification.Results[i] = CheckpointSourceResult{
+			Source: source,
+			Header: header,
+			Error:  err,
+		}
+		if err == nil && (minSlot == 0 || header.Slot < minSlot) {
+			minSlot = header.Slot
+		}
+	}
+	verification.Slot = minSlot
+
+	// Bring every source down to the common slot
+	for i, result := range verification.Results {
+		if result.Error != nil || result.Header.Slot == minSlot {
+			continue
+		}
+		header, err := getBeaconHeader(ctx, result.Source.Url, strconv.FormatUint(minSlot, 10))
+		if err != nil {
+			err = fmt.Errorf("error getting header at slot %d: %w", minSlot, err)
+		}
+		verification.Results[i].Header = header
+		verification.Results[i].Error = err
+	}
+
+	// Compare them
+	var reference *CheckpointHeader
+	verification.Agreed = true
+	for _, result := range verification.Results {
+		if result.Error != nil {
+			continue
+		}
+		verification.Responded++
+		if reference == nil {
+			reference = result.Header
+			continue
+		}
+		if result.Header.BlockRoot != reference.BlockRoot || result.Header.StateRoot != reference.StateRoot {
+			verification.Agreed = false
+		}
+	}
+	return verification
+}
+
+// Check that a source still has the provided checkpoint on its canonical chain
+func CheckSourceHasCheckpoint(ctx context.Context, source CheckpointSource, checkpoint *VerifiedCheckpoint) error {
+	header, err := getBeaconHeader(ctx, source.Url, strconv.FormatUint(checkpoint.Slot, 10))
+	if err != nil {
+		return fmt.Errorf("error getting header at slot %d: %w", checkpoint.Slot, err)
+	}
+	if header.BlockRoot != checkpoint.BlockRoot || header.StateRoot != checkpoint.StateRoot {
+		return fmt.Errorf("block at slot %d has root %s and state root %s, but the verified checkpoint has root %s and state root %s", checkpoint.Slot, header.BlockRoot, header.StateRoot, checkpoint.BlockRoot, checkpoint.StateRoot)
+	}
+	return nil
+}
+
+// Load the last verified checkpoint. Returns nil if there isn't one.
+func (c *HyperdriveClient) LoadVerifiedCheckpoint() (*VerifiedCheckpoint, error) {
+	path, err := c.getVerifiedCheckpointPath()
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading verified checkpoint file [%s]: %w", path, err)
+	}
+
+	checkpoint := new(VerifiedCheckpoint)
+	err = json.Unmarshal(bytes, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing verified checkpoint file [%s]: %w", path, err)
+	}
+	return checkpoint, nil
+}
+
+// Save a verified checkpoint
+func (c *HyperdriveClient) SaveVerifiedCheckpoint(checkpoint *VerifiedCheckpoint) error {
+	path, err := c.getVerifiedCheckpointPath()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(checkpoint, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing verified checkpoint: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing verified checkpoint file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the path of the verified checkpoint file
+func (c *HyperdriveClient) getVerifiedCheckpointPath() (string, error) {
+	path, err := homedir.Expand(filepath.Join(c.Context.UserDirPath, VerifiedCheckpointFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding verified checkpoint file path: %w", err)
+	}
+	return path, nil
+}
+
+// Get a block header from a Beacon Node
+func getBeaconHeader(ctx context.Context, url string, blockID string) (*CheckpointHeader, error) {
+	ctx, cancel := context.WithTimeout(ctx, checkpointRequestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/"+beaconHeaderRoute, url, blockID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with code %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var headerResponse struct {
+		Data struct {
+			Root   string `json:"root"`
+			Header struct {
+				Message struct {
+					Slot      string `json:"slot"`
+					StateRoot string `json:"state_root"`
+				} `json:"message"`
+			} `json:"header"`
+		} `json:"data"`
+	}
+	err = json.Unmarshal(body, &headerResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing response: %w", err)
+	}
+	slot, err := strconv.ParseUint(headerResponse.Data.Header.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing slot [%s]: %w", headerResponse.Data.Header.Message.Slot, err)
+	}
+	return &CheckpointHeader{
+		Slot:      slot,
+		BlockRoot: headerResponse.Data.Root,
+		StateRoot: headerResponse.Data.Header.Message.StateRoot,
+	}, nil
+}
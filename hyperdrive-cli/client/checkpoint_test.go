@@ -0,0 +1,31 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointVerificationIsVerified(t *testing.T) {
+	header := &CheckpointHeader{Slot: 64, BlockRoot: "0x01", StateRoot: "0x02"}
+	responded := CheckpointSourceResult{Header: header}
+	failed := CheckpointSourceResult{Error: fmt.Errorf("connection refused")}
+
+	tests := []struct {
+		name         string
+		verification CheckpointVerification
+		verified     bool
+	}{
+		{"provider and another source agree", CheckpointVerification{Results: []CheckpointSourceResult{responded, responded}, Agreed: true, Responded: 2}, true},
+		{"provider failed", CheckpointVerification{Results: []CheckpointSourceResult{failed, responded, responded}, Agreed: true, Responded: 2}, false},
+		{"only the provider responded", CheckpointVerification{Results: []CheckpointSourceResult{responded, failed}, Agreed: true, Responded: 1}, false},
+		{"sources disagree", CheckpointVerification{Results: []CheckpointSourceResult{responded, responded}, Agreed: false, Responded: 2}, false},
+		{"no sources", CheckpointVerification{Agreed: true}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.verified, test.verification.IsVerified())
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	dt "github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/filters"
+	dimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The volume that holds Prometheus's metrics history
+	prometheusDataVolume string = "prometheus-data"
+
+	// The volume that holds Grafana's dashboards and settings
+	grafanaStorageVolume string = "grafana-storage"
+)
+
+// A kind of Docker resource that can be cleaned up
+type DockerResourceType string
+
+const (
+	DockerResourceType_Image   DockerResourceType = "image"
+	DockerResourceType_Volume  DockerResourceType = "volume"
+	DockerResourceType_Network DockerResourceType = "network"
+)
+
+// A Docker resource that isn't used by the current settings anymore
+type StaleDockerResource struct {
+	Type DockerResourceType
+
+	// The ID to remove it with
+	ID string
+
+	// A readable name for it
+	Name string
+
+	// The disk space it takes up, or -1 if it's unknown
+	Size int64
+}
+
+// The parts of a docker compose file that refer to images, volumes, and networks
+type composeResourceFile struct {
+	Services map[string]composeImageService `yaml:"services"`
+	Volumes  map[string]*composeResource    `yaml:"volumes"`
+	Networks map[string]*composeResource    `yaml:"networks"`
+}
+
+// A top-level volume or network in a docker compose file
+type composeResource struct {
+	Name     string `yaml:"name"`
+	External any    `yaml:"external"`
+}
+
+// Find the images, volumes, and networks of the project that the current settings don't use anymore.
+// Images count as the project's if they're from a repository one of its containers uses, or could use with different client selections.
+// Anything a container still uses, even a stopped one, is left out since Docker won't remove it, and so are the client data and metrics volumes.
+func (c *HyperdriveClient) FindStaleDockerResources(composeFiles []string) ([]StaleDockerResource, error) {
+	cfg, _, files, err := c.deployComposeFiles(composeFiles)
+	if err != nil {
+		return nil, err
+	}
+	projectName := cfg.Hyperdrive.ProjectName.Value
+	used, err := getComposeResources(files, projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := c.GetDocker()
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	du, err := d.DiskUsage(ctx, dt.DiskUsageOptions{
+		Types: []dt.DiskUsageObject{dt.ImageObject, dt.VolumeObject, dt.ContainerObject},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting disk usage: %w", err)
+	}
+
+	// Images
+	repositories := cfg.getImageRepositories(used.images)
+	stale := []StaleDockerResource{}
+	for _, image := range du.Images {
+		if image.Containers > 0 {
+			continue
+		}
+		refs := append(append([]string{}, image.RepoTags...), image.RepoDigests...)
+		isProjectImage := false
+		isUsed := false
+		for _, ref := range refs {
+			named, err := reference.ParseNormalizedNamed(ref)
+			if err != nil {
+				continue
+			}
+			if repositories[reference.FamiliarName(named)] {
+				isProjectImage = true
+			}
+			if used.images[reference.FamiliarString(named)] {
+				isUsed = true
+			}
+		}
+		if !isProjectImage || isUsed {
+			continue
+		}
+		name := image.ID
+		if len(refs) > 0 {
+			name = refs[0]
+		}
+		stale = append(stale, StaleDockerResource{
+			Type: DockerResourceType_Image,
+			ID:   image.ID,
+			Name: name,
+			Size: image.Size,
+		})
+	}
+
+	// Volumes
+	stale = append(stale, getStaleVolumes(du.Volumes, projectName, used.volumes, cfg.getProtectedVolumes())...)
+
+	// Networks
+	networksInUse := map[string]bool{}
+	for _, container := range du.Containers {
+		if container.NetworkSettings == nil {
+			continue
+		}
+		for _, endpoint := range container.NetworkSettings.Networks {
+			networksInUse[endpoint.NetworkID] = true
+		}
+	}
+	networks, err := d.NetworkList(ctx, network.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("label", fmt.Sprintf("%s=%s", composeProjectLabel, projectName))),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting network list: %w", err)
+	}
+	for _, network := range networks {
+		if used.networks[network.Name] || networksInUse[network.ID] {
+			continue
+		}
+		stale = append(stale, StaleDockerResource{
+			Type: DockerResourceType_Network,
+			ID:   network.ID,
+			Name: network.Name,
+			Size: -1,
+		})
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		if stale[i].Type != stale[j].Type {
+			return stale[i].Type < stale[j].Type
+		}
+		return stale[i].Name < stale[j].Name
+	})
+	return stale, nil
+}
+
+// Get the project's volumes that the compose files don't use, leaving out protected ones and ones a container still uses
+func getStaleVolumes(volumes []*volume.Volume, projectName string, used map[string]bool, protected map[string]bool) []StaleDockerResource {
+	stale := []StaleDockerResource{}
+	for _, volume := range volumes {
+		if volume.Labels[composeProjectLabel] != projectName && !strings.HasPrefix(volume.Name, projectName+"_") {
+			continue
+		}
+		if used[volume.Name] || protected[volume.Name] {
+			continue
+		}
+		size := int64(-1)
+		if volume.UsageData != nil {
+			if volume.UsageData.RefCount > 0 {
+				continue
+			}
+			size = volume.UsageData.Size
+		}
+		stale = append(stale, StaleDockerResource{
+			Type: DockerResourceType_Volume,
+			ID:   volume.Name,
+			Name: volume.Name,
+			Size: size,
+		})
+	}
+	return stale
+}
+
+// Get the volumes that hold data worth keeping even when the current settings don't use them, such as the chain data of the local clients
+// while running in external mode and the metrics history while metrics are disabled
+func (c *GlobalConfig) getProtectedVolumes() map[string]bool {
+	projectName := c.Hyperdrive.ProjectName.Value
+	return map[string]bool{
+		projectName + "_" + c.Cli.ClientVolumes.EcDataVolume.Value: true,
+		projectName + "_" + c.Cli.ClientVolumes.BnDataVolume.Value: true,
+		projectName + "_" + prometheusDataVolume:                   true,
+		projectName + "_" + grafanaStorageVolume:                   true,
+	}
+}
+
+// Remove a stale Docker resource
+func (c *HyperdriveClient) RemoveStaleDockerResource(resource StaleDockerResource) error {
+	d, err := c.GetDocker()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	switch resource.Type {
+	case DockerResourceType_Image:
+		// Force is needed to remove images with more than one tag; ones that containers use were already left out
+		_, err = d.ImageRemove(ctx, resource.ID, dimage.RemoveOptions{Force: true, PruneChildren: true})
+	case DockerResourceType_Volume:
+		err = d.VolumeRemove(ctx, resource.ID, false)
+	case DockerResourceType_Network:
+		err = d.NetworkRemove(ctx, resource.ID)
+	default:
+		return fmt.Errorf("unknown resource type [%s]", resource.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("error removing %s [%s]: %w", resource.Type, resource.Name, err)
+	}
+	return nil
+}
+
+// The images, volumes, and networks a set of compose files use
+type composeResources struct {
+	// Familiar image references, such as nodeset/hyperdrive:v1.2.2
+	images map[string]bool
+
+	// Full volume and network names, including the project prefix
+	volumes  map[string]bool
+	networks map[string]bool
+}
+
+// Get the images, volumes, and networks used by a set of compose files
+func getComposeResources(composeFiles []string, projectName string) (composeResources, error) {
+	resources := composeResources{
+		images:   map[string]bool{},
+		volumes:  map[string]bool{},
+		networks: map[string]bool{},
+	}
+	for _, path := range composeFiles {
+		bytes, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return resources, fmt.Errorf("error reading compose file [%s]: %w", path, err)
+		}
+		file := composeResourceFile{}
+		err = yaml.Unmarshal(bytes, &file)
+		if err != nil {
+			return resources, fmt.Errorf("error parsing compose file [%s]: %w", path, err)
+		}
+
+		for _, service := range file.Services {
+			if service.Image == "" {
+				continue
+			}
+			named, err := reference.ParseNormalizedNamed(service.Image)
+			if err != nil {
+				return resources, fmt.Errorf("error parsing image name [%s]: %w", service.Image, err)
+			}
+			resources.images[reference.FamiliarString(reference.TagNameOnly(named))] = true
+		}
+		for key, volume := range file.Volumes {
+			resources.volumes[getComposeResourceName(key, volume, projectName)] = true
+		}
+		for key, network := range file.Networks {
+			resources.networks[getComposeResourceName(key, network, projectName)] = true
+		}
+	}
+	return resources, nil
+}
+
+// Get the name docker compose gives a top-level volume or network
+func getComposeResourceName(key string, resource *composeResource, projectName string) string {
+	if resource == nil {
+		return projectName + "_" + key
+	}
+	if resource.Name != "" {
+		return resource.Name
+	}
+	if external, isBool := resource.External.(bool); (isBool && external) || (!isBool && resource.External != nil) {
+		return key
+	}
+	return projectName + "_" + key
+}
+
+// Get the image repositories Hyperdrive uses: the ones in the provided images, and the ones every container tag setting points to, whether or not that container is enabled.
+// General-purpose helper images like alpine are left out since other programs are likely to use them too.
+func (c *GlobalConfig) getImageRepositories(images map[string]bool) map[string]bool {
+	repositories := map[string]bool{}
+	addRepository := func(image string) {
+		named, err := reference.ParseNormalizedNamed(image)
+		if err == nil {
+			repositories[reference.FamiliarName(named)] = true
+		}
+	}
+	for image := range images {
+		addRepository(image)
+	}
+	for _, path := range c.getParameterPaths() {
+		id := strings.ToLower(path.param.GetCommon().ID)
+		if strings.HasSuffix(id, "containertag") {
+			addRepository(path.param.String())
+		}
+	}
+	return repositories
+}
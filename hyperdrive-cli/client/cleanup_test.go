@@ -0,0 +1,72 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStaleVolumes(t *testing.T) {
+	cfg := &GlobalConfig{
+		Hyperdrive: &hdconfig.HyperdriveConfig{},
+		Cli:        NewCliConfig(),
+	}
+	cfg.Hyperdrive.ProjectName.Value = "hd"
+	cfg.Cli.ClientVolumes.EcDataVolume.Value = "ecdata"
+	cfg.Cli.ClientVolumes.BnDataVolume.Value = "bndata-lighthouse"
+	used := map[string]bool{
+		"hd_used": true,
+	}
+
+	tests := []struct {
+		name   string
+		volume *volume.Volume
+		stale  bool
+	}{
+		{"used by the compose files", &volume.Volume{Name: "hd_used"}, false},
+		{"unused", &volume.Volume{Name: "hd_old"}, true},
+		{"unused with a project label", &volume.Volume{Name: "old", Labels: map[string]string{composeProjectLabel: "hd"}}, true},
+		{"another project's", &volume.Volume{Name: "other_old"}, false},
+		{"execution client data in external mode", &volume.Volume{Name: "hd_ecdata"}, false},
+		{"beacon node data in external mode", &volume.Volume{Name: "hd_bndata-lighthouse"}, false},
+		{"client data left behind by a switch", &volume.Volume{Name: "hd_bndata"}, true},
+		{"prometheus data with metrics disabled", &volume.Volume{Name: "hd_prometheus-data"}, false},
+		{"grafana storage with metrics disabled", &volume.Volume{Name: "hd_grafana-storage"}, false},
+		{"referenced by a stopped container", &volume.Volume{Name: "hd_stopped", UsageData: &volume.UsageData{RefCount: 1}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stale := getStaleVolumes([]*volume.Volume{test.volume}, "hd", used, cfg.getProtectedVolumes())
+			if !test.stale {
+				require.Empty(t, stale)
+				return
+			}
+			require.Len(t, stale, 1)
+			require.Equal(t, DockerResourceType_Volume, stale[0].Type)
+			require.Equal(t, test.volume.Name, stale[0].ID)
+			require.Equal(t, int64(-1), stale[0].Size)
+		})
+	}
+}
+
+func TestGetComposeResourceName(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		resource *composeResource
+		expected string
+	}{
+		{"no settings", "ecdata", nil, "hd_ecdata"},
+		{"explicit name", "ecdata", &composeResource{Name: "chain"}, "chain"},
+		{"external", "shared", &composeResource{External: true}, "shared"},
+		{"not external", "shared", &composeResource{External: false}, "hd_shared"},
+		{"external with a mapping", "shared", &composeResource{External: map[string]any{"name": "shared"}}, "shared"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, getComposeResourceName(test.key, test.resource, "hd"))
+		})
+	}
+}
@@ -0,0 +1,668 @@
+package client
+
+import (
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The name of the section in the user settings file that holds settings only used by the CLI
+	CliConfigID string = "cli"
+
+	// Subconfig IDs
+	EcPruningID         string = "ecPruning"
+	CheckpointSyncID    string = "checkpointSync"
+	ClientVolumesID     string = "clientVolumes"
+	ContainerRuntimeID  string = "containerRuntime"
+	DockerSocketProxyID string = "dockerSocketProxy"
+	ImagesID            string = "images"
+	ResourcesID         string = "resources"
+
+	// EC pruning
+	EcPruningFreeSpaceThresholdID string = "freeSpaceThreshold"
+	EcPruningProvisionerTagID     string = "provisionerContainerTag"
+
+	// Checkpoint sync verification
+	CheckpointSyncAdditionalProvidersID string = "additionalProviders"
+	CheckpointSyncRequireAgreementID    string = "requireAgreement"
+
+	// Client volumes
+	ClientVolumesEcDataVolumeID string = "ecDataVolume"
+	ClientVolumesBnDataVolumeID string = "bnDataVolume"
+
+	// Container runtime
+	ContainerRuntimeRuntimeID    string = "runtime"
+	ContainerRuntimeSocketPathID string = "socketPath"
+
+	// Docker socket proxy
+	DockerSocketProxyEnableID       string = "enable"
+	DockerSocketProxyContainerTagID string = "containerTag"
+
+	// Container images
+	ImagesPinDigestsID           string = "pinDigests"
+	ImagesChainDataMigratorTagID string = "chainDataMigratorContainerTag"
+
+	// Container resources
+	ResourcesExecutionClientID            string = "executionClient"
+	ResourcesBeaconNodeID                 string = "beaconNode"
+	ResourcesMevBoostID                   string = "mevBoost"
+	ResourcesStakeWiseValidatorID         string = "stakewiseValidator"
+	ResourcesConstellationValidatorID     string = "constellationValidator"
+	ContainerResourcesCpuLimitID          string = "cpuLimit"
+	ContainerResourcesMemoryLimitID       string = "memoryLimit"
+	ContainerResourcesMemoryReservationID string = "memoryReservation"
+	ContainerResourcesOomScoreAdjID       string = "oomScoreAdj"
+
+	// Defaults
+	defaultPruneProvisionerTag  string = "rocketpool/eth1-prune-provisioner:v0.0.1"
+	defaultSocketProxyTag       string = "wollomatic/socket-proxy:1.6.0"
+	defaultChainDataMigratorTag string = "nodeset/hyperdrive-chain-data-migrator:v1.0.0"
+)
+
+// Settings that are used by the Hyperdrive CLI itself rather than by any of the daemons.
+// These are stored in the modules section of the user settings file so the daemons preserve them.
+type CliConfig struct {
+	// Execution client pruning
+	EcPruning *EcPruningConfig
+
+	// Checkpoint sync verification
+	CheckpointSync *CheckpointSyncConfig
+
+	// Names of the local client data volumes
+	ClientVolumes *ClientVolumesConfig
+
+	// The container runtime that runs the service
+	ContainerRuntime *ContainerRuntimeConfig
+
+	// The proxy that limits what the daemons can do with the container runtime
+	DockerSocketProxy *DockerSocketProxyConfig
+
+	// How the container images are resolved
+	Images *ImagesConfig
+
+	// The CPU and memory each container can use
+	Resources *ResourcesConfig
+}
+
+// Settings for pruning the local Execution client
+type EcPruningConfig struct {
+	// The free space on the EC volume (in GB) that triggers automatic pruning
+	FreeSpaceThreshold config.Parameter[uint64]
+
+	// The container tag of the prune provisioner used for offline pruning
+	ProvisionerTag config.Parameter[string]
+}
+
+// Settings for cross-verifying the checkpoint sync provider
+type CheckpointSyncConfig struct {
+	// Extra checkpoint sync providers to compare the main one against, separated by commas
+	AdditionalProviders config.Parameter[string]
+
+	// True to refuse to use a checkpoint that the providers disagree on, false to only warn about it
+	RequireAgreement config.Parameter[bool]
+}
+
+// The Docker volumes that hold the local clients' chain data.
+// These are changed by `hyperdrive service switch-client` so a new client can sync into a fresh volume while the old one is kept.
+type ClientVolumesConfig struct {
+	// The name of the Execution client's data volume
+	EcDataVolume config.Parameter[string]
+
+	// The name of the Beacon Node's data volume
+	BnDataVolume config.Parameter[string]
+}
+
+// The container runtime Hyperdrive runs its containers with, and how to reach it
+type ContainerRuntimeConfig struct {
+	// The runtime that runs the containers
+	Runtime config.Parameter[ContainerRuntime]
+
+	// The path of the runtime's API socket; blank to find it automatically
+	SocketPath config.Parameter[string]
+}
+
+// Settings for the proxy that sits between the daemons and the container runtime's socket
+type DockerSocketProxyConfig struct {
+	// True to give the daemons the proxy instead of the runtime's socket
+	Enable config.Parameter[bool]
+
+	// The container tag of the proxy
+	ContainerTag config.Parameter[string]
+}
+
+// Settings for the container images the service runs
+type ImagesConfig struct {
+	// True to start the containers with the digests in the image lock file instead of their tags
+	PinDigests config.Parameter[bool]
+
+	// The container tag of the image that exports and imports chain data
+	ChainDataMigratorTag config.Parameter[string]
+}
+
+// The CPU and memory limits of the containers that use the most resources
+type ResourcesConfig struct {
+	// The Execution client
+	ExecutionClient *ContainerResourcesConfig
+
+	// The Beacon Node
+	BeaconNode *ContainerResourcesConfig
+
+	// MEV-Boost
+	MevBoost *ContainerResourcesConfig
+
+	// The StakeWise Validator Client
+	StakeWiseValidator *ContainerResourcesConfig
+
+	// The Constellation Validator Client
+	ConstellationValidator *ContainerResourcesConfig
+}
+
+// The CPU and memory limits of a single container
+type ContainerResourcesConfig struct {
+	// The most CPU cores the container can use; 0 for no limit
+	CpuLimit config.Parameter[float64]
+
+	// The most memory the container can use, in MB; 0 for no limit
+	MemoryLimit config.Parameter[uint64]
+
+	// The memory the container is pushed back down to when the machine is low on memory, in MB; 0 for no reservation
+	MemoryReservation config.Parameter[uint64]
+
+	// The adjustment to how likely the kernel is to kill the container when the machine runs out of memory
+	OomScoreAdj config.Parameter[int64]
+
+	// The title of the container's section
+	title string
+}
+
+// Generates a new CLI configuration
+func NewCliConfig() *CliConfig {
+	return &CliConfig{
+		EcPruning:         NewEcPruningConfig(),
+		CheckpointSync:    NewCheckpointSyncConfig(),
+		ClientVolumes:     NewClientVolumesConfig(),
+		ContainerRuntime:  NewContainerRuntimeConfig(),
+		DockerSocketProxy: NewDockerSocketProxyConfig(),
+		Images:            NewImagesConfig(),
+		Resources:         NewResourcesConfig(),
+	}
+}
+
+// The title for the config
+func (cfg *CliConfig) GetTitle() string {
+	return "Hyperdrive CLI"
+}
+
+// Get the parameters for this config
+func (cfg *CliConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{}
+}
+
+// Get the sections underneath this one
+func (cfg *CliConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{
+		EcPruningID:         cfg.EcPruning,
+		CheckpointSyncID:    cfg.CheckpointSync,
+		ClientVolumesID:     cfg.ClientVolumes,
+		ContainerRuntimeID:  cfg.ContainerRuntime,
+		DockerSocketProxyID: cfg.DockerSocketProxy,
+		ImagesID:            cfg.Images,
+		ResourcesID:         cfg.Resources,
+	}
+}
+
+// Generates a new EC pruning configuration
+func NewEcPruningConfig() *EcPruningConfig {
+	return &EcPruningConfig{
+		FreeSpaceThreshold: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 EcPruningFreeSpaceThresholdID,
+				Name:               "Auto-Prune Free Space Threshold (GB)",
+				Description:        "When `hyperdrive service prune-ec --auto` is run (for example, by the daily timer that `hyperdrive service systemd install` sets up), Hyperdrive will only prune your Execution client if the free space on its volume has dropped below this many gigabytes.\n\nSet this to 0 to disable automatic pruning.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: 0,
+			},
+		},
+
+		ProvisionerTag: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 EcPruningProvisionerTagID,
+				Name:               "Prune Provisioner Container Tag",
+				Description:        "The tag of the container that flags the Execution client's volume for offline pruning. This is used by clients that must be shut down to prune, such as Geth and Besu.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: true,
+				Advanced:           true,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: defaultPruneProvisionerTag,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *EcPruningConfig) GetTitle() string {
+	return "Execution Client Pruning"
+}
+
+// Get the parameters for this config
+func (cfg *EcPruningConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.FreeSpaceThreshold,
+		&cfg.ProvisionerTag,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *EcPruningConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new checkpoint sync verification configuration
+func NewCheckpointSyncConfig() *CheckpointSyncConfig {
+	return &CheckpointSyncConfig{
+		AdditionalProviders: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 CheckpointSyncAdditionalProvidersID,
+				Name:               "Additional Checkpoint Providers",
+				Description:        "A comma-separated list of other checkpoint sync providers that you trust. Hyperdrive will ask each of them (and your fallback Beacon Node, if you have one) for the latest finalized checkpoint and make sure they all agree with your main provider before your Beacon Node uses it.\n\nThis protects you from a malicious or stale provider.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+
+		RequireAgreement: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 CheckpointSyncRequireAgreementID,
+				Name:               "Require Checkpoint Agreement",
+				Description:        "Enable this to make Hyperdrive refuse to resync your Beacon Node if your checkpoint providers disagree on the finalized checkpoint. If disabled, Hyperdrive will only warn you about the disagreement.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: true,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *CheckpointSyncConfig) GetTitle() string {
+	return "Checkpoint Sync Verification"
+}
+
+// Get the parameters for this config
+func (cfg *CheckpointSyncConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.AdditionalProviders,
+		&cfg.RequireAgreement,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *CheckpointSyncConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new client volume configuration
+func NewClientVolumesConfig() *ClientVolumesConfig {
+	return &ClientVolumesConfig{
+		EcDataVolume: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ClientVolumesEcDataVolumeID,
+				Name:               "Execution Client Data Volume",
+				Description:        "The name of the Docker volume that holds your Execution client's chain data, without the project name prefix.",
+				AffectsContainers:  []config.ContainerID{config.ContainerID_ExecutionClient},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: hdconfig.ExecutionClientDataVolume,
+			},
+		},
+
+		BnDataVolume: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ClientVolumesBnDataVolumeID,
+				Name:               "Beacon Node Data Volume",
+				Description:        "The name of the Docker volume that holds your Beacon Node's chain data, without the project name prefix.",
+				AffectsContainers:  []config.ContainerID{config.ContainerID_BeaconNode},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: hdconfig.BeaconNodeDataVolume,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *ClientVolumesConfig) GetTitle() string {
+	return "Client Volumes"
+}
+
+// Get the parameters for this config
+func (cfg *ClientVolumesConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.EcDataVolume,
+		&cfg.BnDataVolume,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *ClientVolumesConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new container runtime configuration
+func NewContainerRuntimeConfig() *ContainerRuntimeConfig {
+	return &ContainerRuntimeConfig{
+		Runtime: config.Parameter[ContainerRuntime]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerRuntimeRuntimeID,
+				Name:               "Container Runtime",
+				Description:        "The container runtime that runs Hyperdrive's containers on this machine.",
+				AffectsContainers:  []config.ContainerID{config.ContainerID_Daemon, swconfig.ContainerID_StakeWiseDaemon, csconfig.ContainerID_ConstellationDaemon},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Options: []*config.ParameterOption[ContainerRuntime]{
+				{
+					ParameterOptionCommon: &config.ParameterOptionCommon{
+						Name:        "Docker",
+						Description: "The standard Docker daemon, which runs as root. Your user needs to be in the `docker` group, and Hyperdrive will use sudo to delete files the containers created.",
+					},
+					Value: ContainerRuntime_Docker,
+				},
+				{
+					ParameterOptionCommon: &config.ParameterOptionCommon{
+						Name:        "Rootless Docker",
+						Description: "Docker running as your own user, as set up by `dockerd-rootless-setuptool.sh`. The containers can only create files your user (or its subordinate IDs) owns, so Hyperdrive doesn't need sudo to manage them.",
+					},
+					Value: ContainerRuntime_DockerRootless,
+				},
+				{
+					ParameterOptionCommon: &config.ParameterOptionCommon{
+						Name:        "Podman",
+						Description: "Podman running as your own user, with its API socket enabled (`systemctl --user enable --now podman.socket`). Hyperdrive uses `podman compose` and Podman's Docker-compatible API, and doesn't need sudo to manage the containers' files.",
+					},
+					Value: ContainerRuntime_Podman,
+				},
+			},
+			Default: map[config.Network]ContainerRuntime{
+				config.Network_All: ContainerRuntime_Docker,
+			},
+		},
+
+		SocketPath: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerRuntimeSocketPathID,
+				Name:               "Container Runtime Socket",
+				Description:        "The path of the container runtime's API socket, which Hyperdrive and its daemons use to manage the containers.\n\nLeave this blank to find it automatically: Hyperdrive checks `DOCKER_HOST`, then the runtime's standard location for your user.",
+				AffectsContainers:  []config.ContainerID{config.ContainerID_Daemon, swconfig.ContainerID_StakeWiseDaemon, csconfig.ContainerID_ConstellationDaemon},
+				CanBeBlank:         true,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: "",
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *ContainerRuntimeConfig) GetTitle() string {
+	return "Container Runtime"
+}
+
+// Get the parameters for this config
+func (cfg *ContainerRuntimeConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.Runtime,
+		&cfg.SocketPath,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *ContainerRuntimeConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new Docker socket proxy configuration
+func NewDockerSocketProxyConfig() *DockerSocketProxyConfig {
+	return &DockerSocketProxyConfig{
+		Enable: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 DockerSocketProxyEnableID,
+				Name:               "Use Docker Socket Proxy",
+				Description:        "By default, the Hyperdrive daemons are given the container runtime's socket so they can restart your Validator Clients. Access to that socket is effectively root access to this machine.\n\nEnable this to give them a proxy instead, which only lets them look up containers and restart or stop the ones in this Hyperdrive project.",
+				AffectsContainers:  []config.ContainerID{config.ContainerID_Daemon, swconfig.ContainerID_StakeWiseDaemon, csconfig.ContainerID_ConstellationDaemon, ContainerID_SocketProxy},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		ContainerTag: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 DockerSocketProxyContainerTagID,
+				Name:               "Docker Socket Proxy Container Tag",
+				Description:        "The tag of the Docker socket proxy container.",
+				AffectsContainers:  []config.ContainerID{ContainerID_SocketProxy},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: true,
+				Advanced:           true,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: defaultSocketProxyTag,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *DockerSocketProxyConfig) GetTitle() string {
+	return "Docker Socket Proxy"
+}
+
+// Get the parameters for this config
+func (cfg *DockerSocketProxyConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.Enable,
+		&cfg.ContainerTag,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *DockerSocketProxyConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new container image configuration
+func NewImagesConfig() *ImagesConfig {
+	return &ImagesConfig{
+		PinDigests: config.Parameter[bool]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ImagesPinDigestsID,
+				Name:               "Pin Image Digests",
+				Description:        "Enable this to start the containers with the exact image digests recorded by `hyperdrive service pull`, instead of whatever their tags point to at the time. This keeps a tag that was moved upstream from silently changing what you run.\n\nRun `hyperdrive service pull` after changing clients or updating Hyperdrive to record the new images, and `hyperdrive service pull --verify` to see if any tags have moved since.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]bool{
+				config.Network_All: false,
+			},
+		},
+
+		ChainDataMigratorTag: config.Parameter[string]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ImagesChainDataMigratorTagID,
+				Name:               "Chain Data Migrator Container Tag",
+				Description:        "The tag of the container that copies chain data in and out of the client volumes for the `export-ec-data`, `import-ec-data`, `export-bn-data` and `import-bn-data` commands. It comes with everything they need, so they work without an internet connection.",
+				AffectsContainers:  []config.ContainerID{},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: true,
+				Advanced:           true,
+			},
+			Default: map[config.Network]string{
+				config.Network_All: defaultChainDataMigratorTag,
+			},
+		},
+	}
+}
+
+// The title for the config
+func (cfg *ImagesConfig) GetTitle() string {
+	return "Container Images"
+}
+
+// Get the parameters for this config
+func (cfg *ImagesConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.PinDigests,
+		&cfg.ChainDataMigratorTag,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *ImagesConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
+
+// Generates a new container resources configuration
+func NewResourcesConfig() *ResourcesConfig {
+	return &ResourcesConfig{
+		ExecutionClient:        NewContainerResourcesConfig("Execution Client Resources", config.ContainerID_ExecutionClient),
+		BeaconNode:             NewContainerResourcesConfig("Beacon Node Resources", config.ContainerID_BeaconNode),
+		MevBoost:               NewContainerResourcesConfig("MEV-Boost Resources", config.ContainerID_MevBoost),
+		StakeWiseValidator:     NewContainerResourcesConfig("StakeWise Validator Client Resources", swconfig.ContainerID_StakewiseValidator),
+		ConstellationValidator: NewContainerResourcesConfig("Constellation Validator Client Resources", csconfig.ContainerID_ConstellationValidator),
+	}
+}
+
+// The title for the config
+func (cfg *ResourcesConfig) GetTitle() string {
+	return "Container Resources"
+}
+
+// Get the parameters for this config
+func (cfg *ResourcesConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{}
+}
+
+// Get the sections underneath this one
+func (cfg *ResourcesConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{
+		ResourcesExecutionClientID:        cfg.ExecutionClient,
+		ResourcesBeaconNodeID:             cfg.BeaconNode,
+		ResourcesMevBoostID:               cfg.MevBoost,
+		ResourcesStakeWiseValidatorID:     cfg.StakeWiseValidator,
+		ResourcesConstellationValidatorID: cfg.ConstellationValidator,
+	}
+}
+
+// Generates a new resource configuration for a single container
+func NewContainerResourcesConfig(title string, container config.ContainerID) *ContainerResourcesConfig {
+	return &ContainerResourcesConfig{
+		CpuLimit: config.Parameter[float64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerResourcesCpuLimitID,
+				Name:               "CPU Limit (Cores)",
+				Description:        "The most CPU cores this container can use at once, such as 2 or 1.5. This keeps it from starving the other containers when it's busy, such as while syncing.\n\nSet this to 0 to let it use as much CPU as it needs.",
+				AffectsContainers:  []config.ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]float64{
+				config.Network_All: 0,
+			},
+		},
+
+		MemoryLimit: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerResourcesMemoryLimitID,
+				Name:               "Memory Limit (MB)",
+				Description:        "The most memory this container can use, in MB. If it tries to use more, only this container is restarted, instead of the whole machine running out of memory and the kernel killing whatever it picks (such as your Validator Client).\n\nSet this to 0 for no limit.",
+				AffectsContainers:  []config.ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: 0,
+			},
+		},
+
+		MemoryReservation: config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerResourcesMemoryReservationID,
+				Name:               "Memory Reservation (MB)",
+				Description:        "A soft memory limit for this container, in MB. It can use more than this while memory is free, but when the machine runs low, containers are pushed back down to their reservations first.\n\nSet this to 0 for no reservation. If there's also a memory limit, this must be lower than it.",
+				AffectsContainers:  []config.ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]uint64{
+				config.Network_All: 0,
+			},
+		},
+
+		OomScoreAdj: config.Parameter[int64]{
+			ParameterCommon: &config.ParameterCommon{
+				ID:                 ContainerResourcesOomScoreAdjID,
+				Name:               "OOM Score Adjustment",
+				Description:        "Changes how likely the kernel is to kill this container if the machine runs out of memory, from -1000 (never) to 1000 (first). Raise it on the containers that can safely be restarted so the important ones are spared.\n\nSet this to 0 to leave it alone. Rootless Docker and Podman can't set it below 0.",
+				AffectsContainers:  []config.ContainerID{container},
+				CanBeBlank:         false,
+				OverwriteOnUpgrade: false,
+				Advanced:           true,
+			},
+			Default: map[config.Network]int64{
+				config.Network_All: 0,
+			},
+		},
+
+		title: title,
+	}
+}
+
+// The title for the config
+func (cfg *ContainerResourcesConfig) GetTitle() string {
+	return cfg.title
+}
+
+// Get the parameters for this config
+func (cfg *ContainerResourcesConfig) GetParameters() []config.IParameter {
+	return []config.IParameter{
+		&cfg.CpuLimit,
+		&cfg.MemoryLimit,
+		&cfg.MemoryReservation,
+		&cfg.OomScoreAdj,
+	}
+}
+
+// Get the sections underneath this one
+func (cfg *ContainerResourcesConfig) GetSubconfigs() map[string]config.IConfigSection {
+	return map[string]config.IConfigSection{}
+}
@@ -0,0 +1,280 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	dtc "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	dtv "github.com/docker/docker/api/types/volume"
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The file in the user directory that tracks an in-progress client switch
+	ClientSwitchStateFile string = "client-switch.json"
+
+	// The suffix for the container that keeps the old client running during a switch
+	clientSwitchOldContainerSuffix string = "old"
+
+	// The prefix of the labels Docker Compose uses to track the containers it manages
+	composeLabelPrefix string = "com.docker.compose."
+
+	// Approximate chain data sizes on test networks
+	ecTestnetSize uint64 = 300 * gib
+	bnTestnetSize uint64 = 100 * gib
+
+	// One gibibyte
+	gib uint64 = 1024 * 1024 * 1024
+)
+
+// The stages of a client switch
+type ClientSwitchStage string
+
+const (
+	// The switch has been planned but nothing has changed yet
+	ClientSwitchStage_Prepared ClientSwitchStage = "prepared"
+
+	// The old client has been moved aside and the new one is syncing
+	ClientSwitchStage_Syncing ClientSwitchStage = "syncing"
+
+	// The new client has synced and the old one is being removed
+	ClientSwitchStage_CleaningUp ClientSwitchStage = "cleaningUp"
+)
+
+var (
+	// Approximate mainnet chain data sizes for each Execution client
+	ecMainnetSizes map[config.ExecutionClient]uint64 = map[config.ExecutionClient]uint64{
+		config.ExecutionClient_Geth:       1300 * gib,
+		config.ExecutionClient_Nethermind: 1200 * gib,
+		config.ExecutionClient_Besu:       1300 * gib,
+		config.ExecutionClient_Reth:       1500 * gib,
+	}
+
+	// Approximate mainnet chain data sizes for each Beacon Node
+	bnMainnetSizes map[config.BeaconNode]uint64 = map[config.BeaconNode]uint64{
+		config.BeaconNode_Lighthouse: 200 * gib,
+		config.BeaconNode_Lodestar:   200 * gib,
+		config.BeaconNode_Nimbus:     200 * gib,
+		config.BeaconNode_Prysm:      250 * gib,
+		config.BeaconNode_Teku:       200 * gib,
+	}
+)
+
+// The state of a client switch, saved so it can be resumed if it's interrupted
+type ClientSwitchState struct {
+	// The kind of client being switched
+	Type ChainDataType `json:"type"`
+
+	// The client being switched away from
+	OldClient string `json:"oldClient"`
+
+	// The client being switched to
+	NewClient string `json:"newClient"`
+
+	// The network the switch was started on
+	Network config.Network `json:"network"`
+
+	// The volume holding the old client's data
+	OldVolume string `json:"oldVolume"`
+
+	// The volume name (without the project prefix) the old client used in the config
+	OldVolumeSetting string `json:"oldVolumeSetting"`
+
+	// The volume name (without the project prefix) the new client uses in the config
+	NewVolumeSetting string `json:"newVolumeSetting"`
+
+	// The container that keeps the old client running while the new one syncs, if there is one
+	OldContainer string `json:"oldContainer"`
+
+	// True if the switch enabled the fallback clients to point at the old container
+	EnabledFallback bool `json:"enabledFallback"`
+
+	// The fallback settings from before the switch, restored once it's done
+	PreviousFallback ClientSwitchFallbackSettings `json:"previousFallback"`
+
+	// The current stage of the switch
+	Stage ClientSwitchStage `json:"stage"`
+
+	// The time the switch was started
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// The fallback client settings that a switch may temporarily replace
+type ClientSwitchFallbackSettings struct {
+	UseFallbackClients bool   `json:"useFallbackClients"`
+	EcHttpUrl          string `json:"ecHttpUrl"`
+	BnHttpUrl          string `json:"bnHttpUrl"`
+	PrysmRpcUrl        string `json:"prysmRpcUrl"`
+}
+
+// Get the approximate disk space a client will need on the provided network
+func GetEstimatedClientDataSize(dataType ChainDataType, clientName string, ethNetworkName string) uint64 {
+	mainnet := ethNetworkName == "mainnet"
+	switch dataType {
+	case ChainDataType_Execution:
+		if !mainnet {
+			return ecTestnetSize
+		}
+		return ecMainnetSizes[config.ExecutionClient(clientName)]
+	case ChainDataType_Beacon:
+		if !mainnet {
+			return bnTestnetSize
+		}
+		return bnMainnetSizes[config.BeaconNode(clientName)]
+	}
+	return 0
+}
+
+// Get the name of the container that keeps the old client running during a switch
+func GetClientSwitchOldContainerName(cfg *GlobalConfig, dataType ChainDataType) string {
+	return cfg.Hyperdrive.GetDockerArtifactName(fmt.Sprintf("%s_%s", dataType.ContainerID(), clientSwitchOldContainerSuffix))
+}
+
+// Load the state of an in-progress client switch. Returns nil if there isn't one.
+func (c *HyperdriveClient) LoadClientSwitchState() (*ClientSwitchState, error) {
+	path, err := c.getClientSwitchStatePath()
+	if err != nil {
+		return nil, err
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading client switch state file [%s]: %w", path, err)
+	}
+
+	state := new(ClientSwitchState)
+	err = json.Unmarshal(bytes, state)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing client switch state file [%s]: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save the state of an in-progress client switch
+func (c *HyperdriveClient) SaveClientSwitchState(state *ClientSwitchState) error {
+	path, err := c.getClientSwitchStatePath()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(state, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing client switch state: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing client switch state file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Delete the state of a finished client switch
+func (c *HyperdriveClient) DeleteClientSwitchState() error {
+	path, err := c.getClientSwitchStatePath()
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error deleting client switch state file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the path of the client switch state file
+func (c *HyperdriveClient) getClientSwitchStatePath() (string, error) {
+	path, err := homedir.Expand(filepath.Join(c.Context.UserDirPath, ClientSwitchStateFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding client switch state file path: %w", err)
+	}
+	return path, nil
+}
+
+// Check if a Docker volume exists
+func (c *HyperdriveClient) VolumeExists(volumeName string) (bool, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return false, err
+	}
+	volumes, err := d.VolumeList(context.Background(), dtv.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("error listing volumes: %w", err)
+	}
+	for _, volume := range volumes.Volumes {
+		if volume.Name == volumeName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Check if a Docker container exists
+func (c *HyperdriveClient) ContainerExists(containerName string) (bool, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return false, err
+	}
+	containers, err := d.ContainerList(context.Background(), dtc.ListOptions{All: true})
+	if err != nil {
+		return false, fmt.Errorf("error listing containers: %w", err)
+	}
+	for _, container := range containers {
+		for _, name := range container.Names {
+			if strings.TrimPrefix(name, "/") == containerName {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Create a copy of a container under a new name that Docker Compose doesn't manage.
+// The copy keeps the original's image, settings, volumes, and networks, but doesn't publish any ports so it won't conflict with the container that replaces the original.
+func (c *HyperdriveClient) CloneContainerWithoutPorts(containerName string, cloneName string) error {
+	d, err := c.GetDocker()
+	if err != nil {
+		return err
+	}
+	ci, err := inspectContainer(c, containerName)
+	if err != nil {
+		return err
+	}
+
+	// Copy the settings, dropping everything that ties the container to Compose or to the original
+	containerConfig := *ci.Config
+	containerConfig.Hostname = ""
+	containerConfig.ExposedPorts = nil
+	containerConfig.Labels = map[string]string{}
+	for key, value := range ci.Config.Labels {
+		if !strings.HasPrefix(key, composeLabelPrefix) {
+			containerConfig.Labels[key] = value
+		}
+	}
+	hostConfig := *ci.HostConfig
+	hostConfig.PortBindings = nil
+	hostConfig.PublishAllPorts = false
+
+	// Attach it to the same networks, without the original's aliases
+	networkingConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{},
+	}
+	for name := range ci.NetworkSettings.Networks {
+		networkingConfig.EndpointsConfig[name] = &network.EndpointSettings{}
+	}
+
+	_, err = d.ContainerCreate(context.Background(), &containerConfig, &hostConfig, networkingConfig, nil, cloneName)
+	if err != nil {
+		return fmt.Errorf("error creating container [%s]: %w", cloneName, err)
+	}
+	return nil
+}
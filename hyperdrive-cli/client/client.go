@@ -3,7 +3,6 @@ package client
 import (
 	"fmt"
 	"log/slog"
-	"net/http/httptrace"
 	"path/filepath"
 
 	docker "github.com/docker/docker/client"
@@ -16,6 +15,7 @@ import (
 	swclient "github.com/nodeset-org/hyperdrive-stakewise/client"
 	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/context"
+	nmcclient "github.com/rocket-pool/node-manager-core/api/client"
 	"github.com/rocket-pool/node-manager-core/log"
 	"github.com/urfave/cli/v2"
 )
@@ -68,10 +68,10 @@ func NewHyperdriveClientFromHyperdriveCtx(hdCtx *context.HyperdriveContext) (*Hy
 	logger := log.NewTerminalLogger(hdCtx.DebugEnabled, terminalLogColor).With(slog.String(log.OriginKey, hdconfig.HyperdriveDaemonRoute))
 
 	// Create the tracer if required
-	var tracer *httptrace.ClientTrace
+	var tracer *httpTracer
 	if hdCtx.HttpTraceFile != nil {
 		var err error
-		tracer, err = createTracer(hdCtx.HttpTraceFile, logger)
+		tracer, err = createTracer(hdCtx.HttpTraceFile, logger, hdconfig.HyperdriveDaemonRoute)
 		if err != nil {
 			logger.Error("Error creating HTTP trace", log.Err(err))
 		}
@@ -113,7 +113,17 @@ func NewHyperdriveClientFromHyperdriveCtx(hdCtx *context.HyperdriveContext) (*Hy
 	authMgr := auth.NewAuthorizationManager(authPath, cliIssuer, auth.DefaultRequestLifespan)
 
 	// Create the API client
-	hdClient.Api = client.NewApiClient(url, logger, tracer, authMgr)
+	hdClient.Api = client.NewApiClient(url, logger, nil, authMgr)
+	if tracer != nil {
+		traceContext := tracer.wrapContext(nmcclient.NewNetworkRequesterContext(url, logger, nil, authMgr.AddAuthHeader))
+		hdClient.Api.NodeSet = client.NewNodeSetRequester(traceContext)
+		hdClient.Api.NodeSet_StakeWise = client.NewNodeSetStakeWiseRequester(traceContext)
+		hdClient.Api.NodeSet_Constellation = client.NewNodeSetConstellationRequester(traceContext)
+		hdClient.Api.Service = client.NewServiceRequester(traceContext)
+		hdClient.Api.Tx = client.NewTxRequester(traceContext)
+		hdClient.Api.Utils = client.NewUtilsRequester(traceContext)
+		hdClient.Api.Wallet = client.NewWalletRequester(traceContext)
+	}
 	return hdClient, nil
 }
 
@@ -130,10 +140,10 @@ func NewStakewiseClientFromHyperdriveCtx(hdCtx *context.HyperdriveContext, hdCli
 	logger := log.NewTerminalLogger(hdCtx.DebugEnabled, terminalLogColor).With(slog.String(log.OriginKey, swconfig.ModuleName))
 
 	// Create the tracer if required
-	var tracer *httptrace.ClientTrace
+	var tracer *httpTracer
 	if hdCtx.HttpTraceFile != nil {
 		var err error
-		tracer, err = createTracer(hdCtx.HttpTraceFile, logger)
+		tracer, err = createTracer(hdCtx.HttpTraceFile, logger, swconfig.ModuleName)
 		if err != nil {
 			logger.Error("Error creating HTTP trace", log.Err(err))
 		}
@@ -171,7 +181,14 @@ func NewStakewiseClientFromHyperdriveCtx(hdCtx *context.HyperdriveContext, hdCli
 	authMgr := auth.NewAuthorizationManager(authPath, cliIssuer, auth.DefaultRequestLifespan)
 
 	// Create the API client
-	swClient.Api = swclient.NewApiClient(url, logger, tracer, authMgr)
+	swClient.Api = swclient.NewApiClient(url, logger, nil, authMgr)
+	if tracer != nil {
+		traceContext := tracer.wrapContext(nmcclient.NewNetworkRequesterContext(url, logger, nil, authMgr.AddAuthHeader))
+		swClient.Api.Network = swclient.NewNetworkRequester(traceContext)
+		swClient.Api.Validator = swclient.NewValidatorRequester(traceContext)
+		swClient.Api.Wallet = swclient.NewWalletRequester(traceContext)
+		swClient.Api.Service = swclient.NewServiceRequester(traceContext)
+	}
 	return swClient, nil
 }
 
@@ -182,10 +199,10 @@ func NewConstellationClientFromCtx(c *cli.Context, hdClient *HyperdriveClient) (
 	logger := log.NewTerminalLogger(hdCtx.DebugEnabled, terminalLogColor).With(slog.String(log.OriginKey, csconfig.ModuleName))
 
 	// Create the tracer if required
-	var tracer *httptrace.ClientTrace
+	var tracer *httpTracer
 	if hdCtx.HttpTraceFile != nil {
 		var err error
-		tracer, err = createTracer(hdCtx.HttpTraceFile, logger)
+		tracer, err = createTracer(hdCtx.HttpTraceFile, logger, csconfig.ModuleName)
 		if err != nil {
 			logger.Error("Error creating HTTP trace", log.Err(err))
 		}
@@ -223,15 +240,26 @@ func NewConstellationClientFromCtx(c *cli.Context, hdClient *HyperdriveClient) (
 	authMgr := auth.NewAuthorizationManager(authPath, cliIssuer, auth.DefaultRequestLifespan)
 
 	// Create the API client
-	csClient.Api = csclient.NewApiClient(url, logger, tracer, authMgr)
+	csClient.Api = csclient.NewApiClient(url, logger, nil, authMgr)
+	if tracer != nil {
+		traceContext := tracer.wrapContext(nmcclient.NewNetworkRequesterContext(url, logger, nil, authMgr.AddAuthHeader))
+		csClient.Api.Minipool = csclient.NewMinipoolRequester(traceContext)
+		csClient.Api.Network = csclient.NewNetworkRequester(traceContext)
+		csClient.Api.Node = csclient.NewNodeRequester(traceContext)
+		csClient.Api.Service = csclient.NewServiceRequester(traceContext)
+		csClient.Api.Wallet = csclient.NewWalletRequester(traceContext)
+	}
 	return csClient, nil
 }
 
 // Get the Docker client
 func (c *HyperdriveClient) GetDocker() (*docker.Client, error) {
 	if c.docker == nil {
-		var err error
-		c.docker, err = docker.NewClientWithOpts(docker.WithAPIVersionNegotiation())
+		cfg, _, err := c.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("error loading user settings: %w", err)
+		}
+		c.docker, err = docker.NewClientWithOpts(docker.WithHost(cfg.GetContainerHost()), docker.WithAPIVersionNegotiation())
 		if err != nil {
 			return nil, fmt.Errorf("error creating Docker client: %w", err)
 		}
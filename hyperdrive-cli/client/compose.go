@@ -17,29 +17,53 @@ import (
 
 // Build a docker compose command
 func (c *HyperdriveClient) compose(composeFiles []string, args string) (string, error) {
+	cfg, expandedConfigPath, files, err := c.deployComposeFiles(composeFiles)
+	if err != nil {
+		return "", err
+	}
+
+	// Include all of the relevant docker compose definition files
+	composeFileFlags := []string{}
+	for _, file := range files {
+		composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %s", shellescape.Quote(file)))
+	}
+
+	// Return command
+	return fmt.Sprintf("COMPOSE_PROJECT_NAME=%s %s compose --project-directory %s %s %s", cfg.Hyperdrive.ProjectName.Value, cfg.getContainerCommand(), shellescape.Quote(expandedConfigPath), strings.Join(composeFileFlags, " "), args), nil
+}
+
+// Check the config and deploy the docker compose files for it.
+// Returns the config, the expanded Hyperdrive directory, and all of the compose files that make up the service in the order they should be applied.
+func (c *HyperdriveClient) deployComposeFiles(composeFiles []string) (*GlobalConfig, string, []string, error) {
 	// Get the expanded config path
 	expandedConfigPath, err := homedir.Expand(c.Context.UserDirPath)
 	if err != nil {
-		return "", err
+		return nil, "", nil, err
 	}
 
 	// Load config
 	cfg, isNew, err := c.LoadConfig()
 	if err != nil {
-		return "", err
+		return nil, "", nil, err
 	}
 	if isNew {
-		return "", fmt.Errorf("settings file not found. Please run `hyperdrive service config` to set up Hyperdrive before starting it")
+		return nil, "", nil, fmt.Errorf("settings file not found. Please run `hyperdrive service config` to set up Hyperdrive before starting it")
 	}
 
 	// Check config
 	if cfg.Hyperdrive.ClientMode.Value == config.ClientMode_Unknown {
-		return "", fmt.Errorf("you haven't selected local or external mode for your clients yet.\nPlease run 'hyperdrive service config' before running this command")
+		return nil, "", nil, fmt.Errorf("you haven't selected local or external mode for your clients yet.\nPlease run 'hyperdrive service config' before running this command")
 	} else if cfg.Hyperdrive.IsLocalMode() && cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value == config.ExecutionClient_Unknown {
-		return "", errors.New("no Execution Client selected. Please run 'hyperdrive service config' before running this command")
+		return nil, "", nil, errors.New("no Execution Client selected. Please run 'hyperdrive service config' before running this command")
 	}
 	if cfg.Hyperdrive.IsLocalMode() && cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value == config.BeaconNode_Unknown {
-		return "", errors.New("no Beacon Node selected. Please run 'hyperdrive service config' before running this command")
+		return nil, "", nil, errors.New("no Beacon Node selected. Please run 'hyperdrive service config' before running this command")
+	}
+
+	// Make sure the container runtime is up
+	err = cfg.checkContainerSocket()
+	if err != nil {
+		return nil, "", nil, err
 	}
 
 	// Make sure the external IP is loaded
@@ -48,20 +72,21 @@ func (c *HyperdriveClient) compose(composeFiles []string, args string) (string,
 	// Deploy the templates and run environment variable substitution on them
 	deployedContainers, err := c.deployTemplates(cfg, expandedConfigPath)
 	if err != nil {
-		return "", fmt.Errorf("error deploying Docker templates: %w", err)
+		return nil, "", nil, fmt.Errorf("error deploying Docker templates: %w", err)
 	}
+	files := append(deployedContainers, composeFiles...)
 
-	// Include all of the relevant docker compose definition files
-	composeFileFlags := []string{}
-	for _, container := range deployedContainers {
-		composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %s", shellescape.Quote(container)))
-	}
-	for _, container := range composeFiles {
-		composeFileFlags = append(composeFileFlags, fmt.Sprintf("-f %s", shellescape.Quote(container)))
+	// Pin the images to their locked digests
+	if cfg.Cli.Images.PinDigests.Value {
+		pinsFile, err := c.deployImagePins(files, filepath.Join(expandedConfigPath, runtimeDir))
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("error pinning image digests: %w", err)
+		}
+		if pinsFile != "" {
+			files = append(files, pinsFile)
+		}
 	}
-
-	// Return command
-	return fmt.Sprintf("COMPOSE_PROJECT_NAME=%s docker compose --project-directory %s %s %s", cfg.Hyperdrive.ProjectName.Value, shellescape.Quote(expandedConfigPath), strings.Join(composeFileFlags, " "), args), nil
+	return cfg, expandedConfigPath, files, nil
 }
 
 // Deploys all of the appropriate docker compose template files and provisions them based on the provided configuration
@@ -90,6 +115,12 @@ func (c *HyperdriveClient) deployTemplates(cfg *GlobalConfig, hyperdriveDir stri
 		return []string{}, fmt.Errorf("error creating runtime folder [%s]: %w", runtimeFolder, err)
 	}
 
+	// Merge in any custom network settings
+	cfg.networksDir, err = c.deployNetworkSettings(hyperdriveDir)
+	if err != nil {
+		return []string{}, fmt.Errorf("error deploying network settings: %w", err)
+	}
+
 	// Make the extra scrape jobs folder
 	extraScrapeJobsFolder := filepath.Join(hyperdriveDir, extraScrapeJobsDir)
 	err = os.MkdirAll(extraScrapeJobsFolder, 0755)
@@ -126,6 +157,11 @@ func (c *HyperdriveClient) deployTemplates(cfg *GlobalConfig, hyperdriveDir stri
 		)
 	}
 
+	// Check if the daemons should use the socket proxy
+	if cfg.Cli.DockerSocketProxy.Enable.Value {
+		toDeploy = append(toDeploy, ContainerID_SocketProxy)
+	}
+
 	// Check if we are running the MEV-Boost container locally
 	if cfg.Hyperdrive.MevBoost.Enable.Value && cfg.Hyperdrive.MevBoost.Mode.Value == config.ClientMode_Local {
 		toDeploy = append(toDeploy, config.ContainerID_MevBoost)
@@ -149,6 +185,13 @@ func (c *HyperdriveClient) deployTemplates(cfg *GlobalConfig, hyperdriveDir stri
 			}
 		}
 	}
+
+	// Deploy the user's own services
+	customServices, err := c.deployCustomServices(cfg, hyperdriveDir)
+	if err != nil {
+		return []string{}, fmt.Errorf("error deploying custom services: %w", err)
+	}
+	deployedContainers = append(deployedContainers, customServices...)
 	return deployedContainers, nil
 }
 
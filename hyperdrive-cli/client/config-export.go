@@ -0,0 +1,305 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive-daemon/shared"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/nodeset-org/hyperdrive-daemon/shared/config/ids"
+	"github.com/rocket-pool/node-manager-core/config"
+	"gopkg.in/yaml.v3"
+)
+
+// A portable copy of a node's settings that can be imported on another node.
+// Settings that are specific to the machine that exported them are left out, so the importing node keeps its own.
+type ConfigExport struct {
+	// The version of Hyperdrive the settings were exported from
+	Version string `yaml:"version"`
+
+	// The network the settings are for
+	Network config.Network `yaml:"network"`
+
+	// The settings, in the same layout as the user settings file
+	Settings map[string]any `yaml:"settings"`
+
+	// The settings that were left out because they're specific to the exporting machine, along with why
+	LocalSettings []ConfigExportLocalSetting `yaml:"localSettings,omitempty"`
+}
+
+// A setting that was left out of an export
+type ConfigExportLocalSetting struct {
+	// The path to the setting, such as hyperdrive.apiPort
+	Path string `yaml:"path"`
+
+	// Why it was left out
+	Reason string `yaml:"reason"`
+}
+
+// A parameter that only applies to the machine it's configured on
+type machineSpecificParameter struct {
+	param  config.IParameter
+	reason string
+}
+
+// A parameter along with its path in the settings file
+type parameterPath struct {
+	param config.IParameter
+	path  string
+}
+
+// Load a config export file
+func LoadConfigExport(path string) (*ConfigExport, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config export file [%s]: %w", path, err)
+	}
+	export := &ConfigExport{}
+	err = yaml.Unmarshal(bytes, export)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config export file [%s]: %w", path, err)
+	}
+	if export.Network == "" || export.Settings == nil {
+		return nil, fmt.Errorf("[%s] isn't a Hyperdrive config export file", path)
+	}
+	return export, nil
+}
+
+// Save the export to a file
+func (e *ConfigExport) Save(path string) error {
+	bytes, err := yaml.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("error serializing config export: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing config export file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Export the config's settings so they can be imported on another node, leaving out the ones specific to this machine
+func (c *GlobalConfig) Export() *ConfigExport {
+	localParams := map[config.IParameter]string{}
+	for _, machineParam := range c.getMachineSpecificParameters() {
+		localParams[machineParam.param] = machineParam.reason
+	}
+
+	export := &ConfigExport{
+		Version:       shared.HyperdriveVersion,
+		Network:       c.Hyperdrive.Network.Value,
+		Settings:      map[string]any{},
+		LocalSettings: []ConfigExportLocalSetting{},
+	}
+	for _, paramPath := range c.getParameterPaths() {
+		reason, isLocal := localParams[paramPath.param]
+		if isLocal {
+			export.LocalSettings = append(export.LocalSettings, ConfigExportLocalSetting{
+				Path:   paramPath.path,
+				Reason: reason,
+			})
+			continue
+		}
+		setSettingAtPath(export.Settings, paramPath.path, paramPath.param.String())
+	}
+	return export
+}
+
+// Merge the settings from an export into the config, switching networks first if the export is for a different one.
+// Settings that aren't in the export, including the ones that were specific to the exporting machine, keep their current values.
+// Returns warnings about any settings that couldn't be imported.
+func (c *GlobalConfig) Import(export *ConfigExport) ([]string, error) {
+	// Switch networks first, since that changes the defaults of other settings
+	if export.Network != c.Hyperdrive.Network.Value {
+		found := false
+		for _, settings := range c.Hyperdrive.GetNetworkSettings() {
+			if settings.Key == export.Network {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("the export is for the [%s] network, which this node doesn't have; if it's a custom network, add it with `hyperdrive service network add` first", export.Network)
+		}
+		c.ChangeNetwork(export.Network)
+	}
+	network := c.Hyperdrive.Network.Value
+
+	// Container tags are tied to the version that exported them, so only bring them over from the same version
+	sameVersion := strings.TrimPrefix(export.Version, "v") == strings.TrimPrefix(shared.HyperdriveVersion, "v")
+
+	warnings := []string{}
+	known := map[string]bool{}
+	for _, paramPath := range c.getParameterPaths() {
+		known[paramPath.path] = true
+		if paramPath.param == &c.Hyperdrive.Network {
+			continue
+		}
+		value, exists := getSettingAtPath(export.Settings, paramPath.path)
+		if !exists {
+			continue
+		}
+		if paramPath.param.GetCommon().OverwriteOnUpgrade && !sameVersion {
+			warnings = append(warnings, fmt.Sprintf("%s was exported from Hyperdrive v%s, so this node's value for this version was kept", paramPath.path, strings.TrimPrefix(export.Version, "v")))
+			continue
+		}
+		valueString, isString := value.(string)
+		if !isString {
+			valueString = fmt.Sprint(value)
+		}
+		err := paramPath.param.Deserialize(valueString, network)
+		if err != nil {
+			return nil, fmt.Errorf("error importing setting [%s]: %w", paramPath.path, err)
+		}
+	}
+
+	// Warn about anything this version doesn't know about
+	for _, path := range getSettingPaths(export.Settings, "") {
+		if !known[path] {
+			warnings = append(warnings, fmt.Sprintf("%s isn't a setting in this version of Hyperdrive, so it was skipped", path))
+		}
+	}
+	return warnings, nil
+}
+
+// Get the parameters that only apply to this machine and shouldn't be copied to another one
+func (c *GlobalConfig) getMachineSpecificParameters() []machineSpecificParameter {
+	const (
+		pathReason     string = "it's a path on this machine"
+		urlReason      string = "it points to a client this machine connects to"
+		secretReason   string = "it's a secret"
+		nameReason     string = "it identifies this machine"
+		loopbackReason string = "the port is only bound to this machine's localhost"
+		runtimeReason  string = "it depends on how this machine runs containers"
+		hardwareReason string = "it's sized for this machine's hardware"
+		volumeReason   string = "it names a Docker volume on this machine"
+		networkReason  string = "it names Docker networks on this machine"
+	)
+	hd := c.Hyperdrive
+	params := []machineSpecificParameter{
+		{param: &hd.UserDataPath, reason: pathReason},
+		{param: &hd.ExternalExecutionClient.HttpUrl, reason: urlReason},
+		{param: &hd.ExternalExecutionClient.WebsocketUrl, reason: urlReason},
+		{param: &hd.ExternalBeaconClient.HttpUrl, reason: urlReason},
+		{param: &hd.ExternalBeaconClient.PrysmRpcUrl, reason: urlReason},
+		{param: &hd.Fallback.EcHttpUrl, reason: urlReason},
+		{param: &hd.Fallback.BnHttpUrl, reason: urlReason},
+		{param: &hd.Fallback.PrysmRpcUrl, reason: urlReason},
+		{param: &hd.MevBoost.ExternalUrl, reason: urlReason},
+		{param: &hd.AdditionalDockerNetworks, reason: networkReason},
+		{param: &hd.Metrics.BitflyNodeMetrics.Secret, reason: secretReason},
+		{param: &hd.Metrics.BitflyNodeMetrics.MachineName, reason: nameReason},
+		{param: &hd.ApiPort, reason: loopbackReason},
+		{param: &c.StakeWise.ApiPort, reason: loopbackReason},
+		{param: &c.StakeWise.RelayPort, reason: loopbackReason},
+		{param: &c.Constellation.ApiPort, reason: loopbackReason},
+		{param: &c.Cli.ContainerRuntime.Runtime, reason: runtimeReason},
+		{param: &c.Cli.ContainerRuntime.SocketPath, reason: runtimeReason},
+		{param: &c.Cli.ClientVolumes.EcDataVolume, reason: volumeReason},
+		{param: &c.Cli.ClientVolumes.BnDataVolume, reason: volumeReason},
+	}
+
+	// Container resources
+	for _, resources := range c.getContainerResources() {
+		for _, param := range resources.GetParameters() {
+			params = append(params, machineSpecificParameter{param: param, reason: hardwareReason})
+		}
+	}
+
+	// Ports that are only opened on localhost
+	addLoopbackPorts := func(mode config.RpcPortMode, ports ...config.IParameter) {
+		if mode != config.RpcPortMode_OpenLocalhost {
+			return
+		}
+		for _, port := range ports {
+			params = append(params, machineSpecificParameter{param: port, reason: loopbackReason})
+		}
+	}
+	localEc := hd.LocalExecutionClient
+	localBn := hd.LocalBeaconClient
+	addLoopbackPorts(localEc.OpenApiPorts.Value, &localEc.HttpPort, &localEc.WebsocketPort)
+	addLoopbackPorts(localBn.OpenHttpPort.Value, &localBn.HttpPort)
+	addLoopbackPorts(localBn.Prysm.OpenRpcPort.Value, &localBn.Prysm.RpcPort)
+	addLoopbackPorts(hd.MevBoost.OpenRpcPort.Value, &hd.MevBoost.Port)
+	addLoopbackPorts(hd.Metrics.Prometheus.OpenPort.Value, &hd.Metrics.Prometheus.Port)
+	return params
+}
+
+// Get all of the parameters in the config along with their paths in the settings file, sorted by path
+func (c *GlobalConfig) getParameterPaths() []parameterPath {
+	paths := []parameterPath{}
+	paths = addParameterPaths(c.Hyperdrive, ids.RootConfigID, paths)
+	modulesPrefix := hdconfig.ModulesName + "."
+	for _, module := range c.GetAllModuleConfigs() {
+		paths = addParameterPaths(module, modulesPrefix+module.GetModuleName(), paths)
+	}
+	paths = addParameterPaths(c.Cli, modulesPrefix+CliConfigID, paths)
+	sort.SliceStable(paths, func(i, j int) bool {
+		return paths[i].path < paths[j].path
+	})
+	return paths
+}
+
+// Add the parameters of a section and its subsections to the list of paths
+func addParameterPaths(section config.IConfigSection, prefix string, paths []parameterPath) []parameterPath {
+	for _, param := range section.GetParameters() {
+		paths = append(paths, parameterPath{
+			param: param,
+			path:  prefix + "." + param.GetCommon().ID,
+		})
+	}
+	for name, subconfig := range section.GetSubconfigs() {
+		paths = addParameterPaths(subconfig, prefix+"."+name, paths)
+	}
+	return paths
+}
+
+// Set a value in a nested settings map, creating the maps along the path as needed
+func setSettingAtPath(settings map[string]any, path string, value string) {
+	keys := strings.Split(path, ".")
+	current := settings
+	for _, key := range keys[:len(keys)-1] {
+		next, exists := current[key].(map[string]any)
+		if !exists {
+			next = map[string]any{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+// Get a value from a nested settings map
+func getSettingAtPath(settings map[string]any, path string) (any, bool) {
+	keys := strings.Split(path, ".")
+	current := settings
+	for _, key := range keys[:len(keys)-1] {
+		next, exists := current[key].(map[string]any)
+		if !exists {
+			return nil, false
+		}
+		current = next
+	}
+	value, exists := current[keys[len(keys)-1]]
+	return value, exists
+}
+
+// Get the paths of all of the values in a nested settings map
+func getSettingPaths(settings map[string]any, prefix string) []string {
+	paths := []string{}
+	for key, value := range settings {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if submap, isMap := value.(map[string]any); isMap {
+			paths = append(paths, getSettingPaths(submap, path)...)
+		} else {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -0,0 +1,167 @@
+package client
+
+import (
+	"fmt"
+	"math"
+
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/nodeset-org/hyperdrive-daemon/shared/config/ids"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The JSON Schema dialect the config schema uses
+	jsonSchemaDraft string = "https://json-schema.org/draft/2020-12/schema"
+
+	// Matches decimal integers from 0 to 65535
+	uint16Pattern string = "(?:[0-9]{1,4}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])"
+
+	// Matches unsigned decimal integers
+	uintPattern string = "[0-9]+"
+
+	// Matches signed decimal integers
+	intPattern string = "-?[0-9]+"
+
+	// Matches decimal floating point numbers
+	floatPattern string = "-?(?:[0-9]+\\.?[0-9]*|\\.[0-9]+)(?:[eE][-+]?[0-9]+)?"
+)
+
+// Get a JSON Schema (Draft 2020-12) describing the user settings file for this config, including Hyperdrive, each module, and the CLI settings.
+// Every value in the settings file is stored as a string, so numbers and booleans are described with string patterns and enums.
+func (c *GlobalConfig) GetJsonSchema() map[string]any {
+	networks := []config.Network{}
+	for _, settings := range c.Hyperdrive.GetNetworkSettings() {
+		networks = append(networks, settings.Key)
+	}
+	defaultNetwork := c.Hyperdrive.Network.GetDefault(config.Network_All)
+
+	// Modules are stored alongside the CLI settings
+	moduleProperties := map[string]any{}
+	for _, module := range c.GetAllModuleConfigs() {
+		moduleSchema := getSectionSchema(module, networks, defaultNetwork)
+		moduleSchema["properties"].(map[string]any)[ids.VersionID] = map[string]any{
+			"type":        "string",
+			"description": "The version of the module that last saved these settings",
+		}
+		moduleProperties[module.GetModuleName()] = moduleSchema
+	}
+	moduleProperties[CliConfigID] = getSectionSchema(c.Cli, networks, defaultNetwork)
+
+	return map[string]any{
+		"$schema":     jsonSchemaDraft,
+		"title":       "Hyperdrive User Settings",
+		"description": "The user-settings.yml file used by Hyperdrive and its modules",
+		"type":        "object",
+		"properties": map[string]any{
+			ids.VersionID: map[string]any{
+				"type":        "string",
+				"description": "The version of Hyperdrive that last saved these settings",
+			},
+			ids.UserDirID: map[string]any{
+				"type":        "string",
+				"description": "The Hyperdrive user directory",
+			},
+			ids.RootConfigID: getSectionSchema(c.Hyperdrive, networks, defaultNetwork),
+			hdconfig.ModulesName: map[string]any{
+				"type":        "object",
+				"description": "The settings for each Hyperdrive module",
+				"properties":  moduleProperties,
+			},
+		},
+		"required":             []string{ids.RootConfigID},
+		"additionalProperties": false,
+	}
+}
+
+// Get the schema for a config section and its subsections
+func getSectionSchema(section config.IConfigSection, networks []config.Network, defaultNetwork config.Network) map[string]any {
+	properties := map[string]any{}
+	for _, param := range section.GetParameters() {
+		properties[param.GetCommon().ID] = getParameterSchema(param, networks, defaultNetwork)
+	}
+	for name, subconfig := range section.GetSubconfigs() {
+		properties[name] = getSectionSchema(subconfig, networks, defaultNetwork)
+	}
+	return map[string]any{
+		"type":                 "object",
+		"title":                section.GetTitle(),
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// Get the schema for a single parameter
+func getParameterSchema(param config.IParameter, networks []config.Network, defaultNetwork config.Network) map[string]any {
+	common := param.GetCommon()
+	schema := map[string]any{
+		"type":        "string",
+		"title":       common.Name,
+		"description": common.Description,
+	}
+
+	// Defaults, which can be different on each network
+	defaultValue := fmt.Sprint(param.GetDefaultAsAny(defaultNetwork))
+	schema["default"] = defaultValue
+	networkDefaults := map[string]string{}
+	hasNetworkDefaults := false
+	for _, network := range networks {
+		networkDefault := fmt.Sprint(param.GetDefaultAsAny(network))
+		networkDefaults[string(network)] = networkDefault
+		if networkDefault != defaultValue {
+			hasNetworkDefaults = true
+		}
+	}
+	if hasNetworkDefaults {
+		schema["x-defaultsByNetwork"] = networkDefaults
+	}
+
+	// Choices
+	options := param.GetOptions()
+	if len(options) > 0 {
+		values := []string{}
+		descriptions := []string{}
+		for _, option := range options {
+			values = append(values, option.String())
+			descriptions = append(descriptions, fmt.Sprintf("%s: %s", option.Common().Name, option.Common().Description))
+		}
+		schema["enum"] = values
+		schema["x-enumDescriptions"] = descriptions
+		return schema
+	}
+
+	// Blank numbers are replaced with the default unless blanks are allowed, in which case they're invalid
+	blankAllowed := !common.CanBeBlank
+	switch typedParam := param.(type) {
+	case *config.Parameter[bool]:
+		schema["enum"] = []string{"true", "false"}
+	case *config.Parameter[uint16]:
+		schema["pattern"] = getNumberPattern(uint16Pattern, blankAllowed)
+		schema["$comment"] = fmt.Sprintf("An integer from 0 to %d", math.MaxUint16)
+	case *config.Parameter[uint64]:
+		schema["pattern"] = getNumberPattern(uintPattern, blankAllowed)
+		schema["$comment"] = fmt.Sprintf("An integer from 0 to %d", uint64(math.MaxUint64))
+	case *config.Parameter[int], *config.Parameter[int64]:
+		schema["pattern"] = getNumberPattern(intPattern, blankAllowed)
+		schema["$comment"] = "An integer"
+	case *config.Parameter[float64]:
+		schema["pattern"] = getNumberPattern(floatPattern, blankAllowed)
+		schema["$comment"] = "A decimal number"
+	case *config.Parameter[string]:
+		if typedParam.MaxLength > 0 {
+			schema["maxLength"] = typedParam.MaxLength
+		}
+		if typedParam.Regex != "" {
+			// Blank strings skip the format check
+			schema["pattern"] = fmt.Sprintf("^$|%s", typedParam.Regex)
+		}
+	}
+	return schema
+}
+
+// Get a full-string pattern for a number, optionally allowing it to be blank
+func getNumberPattern(pattern string, blankAllowed bool) string {
+	if blankAllowed {
+		return fmt.Sprintf("^(?:%s)?$", pattern)
+	}
+	return fmt.Sprintf("^%s$", pattern)
+}
@@ -46,24 +46,33 @@ func (c *HyperdriveClient) LoadConfig() (*GlobalConfig, bool, error) {
 	}
 
 	// Config wasn't loaded, but there was no error - we should create one.
+	c.cfg, err = c.CreateDefaultConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	c.isNewCfg = true
+	return c.cfg, true, nil
+}
+
+// Create a new config with the default settings, without loading the user's config
+func (c *HyperdriveClient) CreateDefaultConfig() (*GlobalConfig, error) {
 	hdCfg, err := hdconfig.NewHyperdriveConfig(c.Context.UserDirPath, c.Context.HyperdriveNetworkSettings)
 	if err != nil {
-		return nil, false, fmt.Errorf("error creating Hyperdrive config: %w", err)
+		return nil, fmt.Errorf("error creating Hyperdrive config: %w", err)
 	}
 	swCfg, err := swconfig.NewStakeWiseConfig(hdCfg, c.Context.StakeWiseNetworkSettings)
 	if err != nil {
-		return nil, false, fmt.Errorf("error creating StakeWise config: %w", err)
+		return nil, fmt.Errorf("error creating StakeWise config: %w", err)
 	}
 	csCfg, err := csconfig.NewConstellationConfig(hdCfg, c.Context.ConstellationNetworkSettings)
 	if err != nil {
-		return nil, false, fmt.Errorf("error creating StakeWise config: %w", err)
+		return nil, fmt.Errorf("error creating Constellation config: %w", err)
 	}
-	c.cfg, err = NewGlobalConfig(hdCfg, c.Context.HyperdriveNetworkSettings, swCfg, c.Context.StakeWiseNetworkSettings, csCfg, c.Context.ConstellationNetworkSettings)
+	cfg, err := NewGlobalConfig(hdCfg, c.Context.HyperdriveNetworkSettings, swCfg, c.Context.StakeWiseNetworkSettings, csCfg, c.Context.ConstellationNetworkSettings)
 	if err != nil {
-		return nil, false, fmt.Errorf("error creating global config: %w", err)
+		return nil, fmt.Errorf("error creating global config: %w", err)
 	}
-	c.isNewCfg = true
-	return c.cfg, true, nil
+	return cfg, nil
 }
 
 // Load the backup config
@@ -0,0 +1,243 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client/template"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The name of the Docker network that all of Hyperdrive's containers are attached to
+	hyperdriveNetworkName string = "net"
+
+	// The service-level extension key a custom service uses to register itself as a Prometheus scrape target
+	customServiceMetricsKey string = "x-hyperdrive-metrics"
+
+	// The file in the extra scrape jobs folder that holds the scrape targets of the custom services.
+	// The prefix keeps it from colliding with the user's own scrape job files.
+	customServicesScrapeJobsFile string = "hyperdrive-custom-services.yml"
+
+	// The header written at the top of each file generated for the custom services
+	customServicesHeader string = "# Autogenerated from the custom-services folder - DO NOT MODIFY THIS FILE DIRECTLY\n"
+)
+
+// The metrics settings a custom service can provide under its x-hyperdrive-metrics key
+type customServiceMetrics struct {
+	// The port the service serves its metrics on
+	Port uint16 `yaml:"port"`
+
+	// The path the metrics are served on, if it isn't /metrics
+	Path string `yaml:"path,omitempty"`
+
+	// The host Prometheus should scrape, if it isn't the service name
+	Host string `yaml:"host,omitempty"`
+}
+
+// A Prometheus file-based service discovery entry
+type prometheusScrapeTarget struct {
+	Labels  map[string]string `yaml:"labels"`
+	Targets []string          `yaml:"targets"`
+}
+
+// Render each template in the custom services folder with the same data as the built-in templates, attach its services to the Hyperdrive network,
+// and register the ones that ask for it as Prometheus scrape targets.
+// Returns the compose files of the custom services, sorted by name.
+func (c *HyperdriveClient) deployCustomServices(cfg *GlobalConfig, hyperdriveDir string) ([]string, error) {
+	// Make the custom services folder so users know where to put them
+	sourceFolder := filepath.Join(hyperdriveDir, customServicesDir)
+	err := os.MkdirAll(sourceFolder, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("error creating custom services folder [%s]: %w", sourceFolder, err)
+	}
+	entries, err := os.ReadDir(sourceFolder)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom services folder [%s]: %w", sourceFolder, err)
+	}
+
+	runtimeFolder := filepath.Join(hyperdriveDir, runtimeDir, customServicesDir)
+	deployedServices := []string{}
+	targets := []prometheusScrapeTarget{}
+	for _, entry := range entries {
+		filename := entry.Name()
+		if !entry.Type().IsRegular() || !strings.HasSuffix(filename, template.TemplateSuffix) {
+			continue
+		}
+		err = os.MkdirAll(runtimeFolder, 0775)
+		if err != nil {
+			return nil, fmt.Errorf("error creating custom services runtime folder [%s]: %w", runtimeFolder, err)
+		}
+
+		// Render the template
+		t := template.Template{
+			Src: filepath.Join(sourceFolder, filename),
+			Dst: filepath.Join(runtimeFolder, strings.TrimSuffix(filename, template.TemplateSuffix)+template.ComposeFileSuffix),
+		}
+		err = t.Write(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("could not create custom service definition [%s]: %w", filename, err)
+		}
+
+		// Wire it into the project
+		serviceTargets, err := attachCustomServices(t.Dst, cfg.Hyperdrive.ProjectName.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error deploying custom service definition [%s]: %w", filename, err)
+		}
+		targets = append(targets, serviceTargets...)
+		deployedServices = append(deployedServices, t.Dst)
+	}
+
+	// Register the scrape targets, or clear out the old ones if there aren't any
+	scrapeJobsPath := filepath.Join(hyperdriveDir, extraScrapeJobsDir, customServicesScrapeJobsFile)
+	if len(targets) == 0 {
+		err = os.Remove(scrapeJobsPath)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("error removing custom service scrape targets [%s]: %w", scrapeJobsPath, err)
+		}
+		return deployedServices, nil
+	}
+	bytes, err := yaml.Marshal(targets)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing custom service scrape targets: %w", err)
+	}
+	err = os.WriteFile(scrapeJobsPath, append([]byte(customServicesHeader), bytes...), 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error writing custom service scrape targets [%s]: %w", scrapeJobsPath, err)
+	}
+	return deployedServices, nil
+}
+
+// Update a rendered custom service file so its services are part of the Hyperdrive project: attach each one to the Hyperdrive network
+// unless it sets its own network mode, and give it a container name with the project prefix unless it has one already.
+// Returns the Prometheus scrape targets of the services that have an x-hyperdrive-metrics key.
+func attachCustomServices(path string, projectName string) ([]prometheusScrapeTarget, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	file := map[string]any{}
+	err = yaml.Unmarshal(bytes, &file)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing YAML: %w", err)
+	}
+	services, isMap := file["services"].(map[string]any)
+	if !isMap || len(services) == 0 {
+		return nil, fmt.Errorf("it doesn't define any services")
+	}
+	names := []string{}
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	targets := []prometheusScrapeTarget{}
+	for _, name := range names {
+		service, isMap := services[name].(map[string]any)
+		if !isMap {
+			return nil, fmt.Errorf("service [%s] isn't a mapping", name)
+		}
+		if _, exists := service["container_name"]; !exists {
+			service["container_name"] = fmt.Sprintf("%s_%s", projectName, name)
+		}
+
+		// Attach it to the Hyperdrive network
+		_, hasNetworkMode := service["network_mode"]
+		if !hasNetworkMode {
+			switch networks := service["networks"].(type) {
+			case nil:
+				service["networks"] = []any{hyperdriveNetworkName}
+			case []any:
+				if !containsNetwork(networks, hyperdriveNetworkName) {
+					service["networks"] = append(networks, hyperdriveNetworkName)
+				}
+			case map[string]any:
+				if _, exists := networks[hyperdriveNetworkName]; !exists {
+					networks[hyperdriveNetworkName] = nil
+				}
+			default:
+				return nil, fmt.Errorf("the networks of service [%s] must be a list or a mapping", name)
+			}
+		}
+
+		// Get its scrape target
+		metricsSettings, exists := service[customServiceMetricsKey]
+		if !exists {
+			continue
+		}
+		target, err := getCustomServiceScrapeTarget(name, metricsSettings)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+
+	// The network is defined by the built-in templates, but the file needs to declare it for its services to use it
+	networks, isMap := file["networks"].(map[string]any)
+	if !isMap {
+		networks = map[string]any{}
+		file["networks"] = networks
+	}
+	if _, exists := networks[hyperdriveNetworkName]; !exists {
+		networks[hyperdriveNetworkName] = map[string]any{}
+	}
+
+	bytes, err = yaml.Marshal(file)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing custom services: %w", err)
+	}
+	err = os.WriteFile(path, append([]byte(customServicesHeader), bytes...), 0664)
+	if err != nil {
+		return nil, fmt.Errorf("error writing [%s]: %w", path, err)
+	}
+	return targets, nil
+}
+
+// Get the Prometheus scrape target for a custom service from its x-hyperdrive-metrics settings
+func getCustomServiceScrapeTarget(name string, settings any) (prometheusScrapeTarget, error) {
+	// Round-trip the settings through YAML to decode them into the struct
+	bytes, err := yaml.Marshal(settings)
+	if err != nil {
+		return prometheusScrapeTarget{}, fmt.Errorf("error reading %s of service [%s]: %w", customServiceMetricsKey, name, err)
+	}
+	metrics := customServiceMetrics{}
+	err = yaml.Unmarshal(bytes, &metrics)
+	if err != nil {
+		return prometheusScrapeTarget{}, fmt.Errorf("error reading %s of service [%s]: %w", customServiceMetricsKey, name, err)
+	}
+	if metrics.Port == 0 {
+		return prometheusScrapeTarget{}, fmt.Errorf("%s of service [%s] needs a port", customServiceMetricsKey, name)
+	}
+
+	host := metrics.Host
+	if host == "" {
+		host = name
+	}
+	target := prometheusScrapeTarget{
+		Labels: map[string]string{
+			"job": name,
+		},
+		Targets: []string{
+			fmt.Sprintf("%s:%d", host, metrics.Port),
+		},
+	}
+	if metrics.Path != "" {
+		target.Labels["__metrics_path__"] = metrics.Path
+	}
+	return target, nil
+}
+
+// Check if a service's list of networks includes the given network
+func containsNetwork(networks []any, network string) bool {
+	for _, entry := range networks {
+		if entry == network {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// Write the given compose file to a temp folder, attach its services, and return the result
+func attachTestServices(t *testing.T, contents string) (map[string]any, []prometheusScrapeTarget) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	targets, err := attachCustomServices(path, "hd")
+	require.NoError(t, err)
+
+	bytes, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(bytes), customServicesHeader))
+	file := map[string]any{}
+	require.NoError(t, yaml.Unmarshal(bytes, &file))
+	return file, targets
+}
+
+func TestAttachCustomServices(t *testing.T) {
+	file, targets := attachTestServices(t, `
+services:
+  plain:
+    image: busybox
+  listed:
+    image: busybox
+    networks:
+      - other
+  mapped:
+    image: busybox
+    networks:
+      other:
+        aliases:
+          - mapped-alias
+  attached:
+    image: busybox
+    networks:
+      - net
+  host:
+    image: busybox
+    network_mode: host
+  named:
+    image: busybox
+    container_name: my-exporter
+networks:
+  other:
+    external: true
+`)
+	require.Empty(t, targets)
+	services := file["services"].(map[string]any)
+	getService := func(name string) map[string]any {
+		return services[name].(map[string]any)
+	}
+
+	// Networks
+	require.Equal(t, []any{"net"}, getService("plain")["networks"])
+	require.Equal(t, []any{"other", "net"}, getService("listed")["networks"])
+	require.Equal(t, []any{"net"}, getService("attached")["networks"])
+	mapped := getService("mapped")["networks"].(map[string]any)
+	require.Contains(t, mapped, "net")
+	require.Contains(t, mapped, "other")
+	require.NotContains(t, getService("host"), "networks")
+	require.Equal(t, "host", getService("host")["network_mode"])
+
+	// Container names
+	require.Equal(t, "hd_plain", getService("plain")["container_name"])
+	require.Equal(t, "hd_host", getService("host")["container_name"])
+	require.Equal(t, "my-exporter", getService("named")["container_name"])
+
+	// Top-level networks
+	networks := file["networks"].(map[string]any)
+	require.Contains(t, networks, "net")
+	require.Equal(t, map[string]any{"external": true}, networks["other"])
+}
+
+func TestAttachCustomServices_Metrics(t *testing.T) {
+	_, targets := attachTestServices(t, `
+services:
+  exporter:
+    image: busybox
+    x-hyperdrive-metrics:
+      port: 9100
+  other:
+    image: busybox
+`)
+	require.Equal(t, []prometheusScrapeTarget{
+		{
+			Labels:  map[string]string{"job": "exporter"},
+			Targets: []string{"exporter:9100"},
+		},
+	}, targets)
+}
+
+func TestAttachCustomServices_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		err      string
+	}{
+		{"no services", "networks: {}\n", "doesn't define any services"},
+		{"service isn't a mapping", "services:\n  bad: busybox\n", "service [bad] isn't a mapping"},
+		{"networks aren't a list or mapping", "services:\n  bad:\n    networks: net\n", "must be a list or a mapping"},
+		{"metrics without a port", "services:\n  bad:\n    x-hyperdrive-metrics:\n      path: /stats\n", "needs a port"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "custom.yml")
+			require.NoError(t, os.WriteFile(path, []byte(test.contents), 0644))
+			_, err := attachCustomServices(path, "hd")
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestGetCustomServiceScrapeTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings any
+		expected prometheusScrapeTarget
+		err      string
+	}{
+		{
+			name:     "port only",
+			settings: map[string]any{"port": 9100},
+			expected: prometheusScrapeTarget{
+				Labels:  map[string]string{"job": "exporter"},
+				Targets: []string{"exporter:9100"},
+			},
+		},
+		{
+			name:     "path and host",
+			settings: map[string]any{"port": 8080, "path": "/stats", "host": "sidecar"},
+			expected: prometheusScrapeTarget{
+				Labels:  map[string]string{"job": "exporter", "__metrics_path__": "/stats"},
+				Targets: []string{"sidecar:8080"},
+			},
+		},
+		{
+			name:     "missing port",
+			settings: map[string]any{"path": "/stats"},
+			err:      "needs a port",
+		},
+		{
+			name:     "invalid port",
+			settings: map[string]any{"port": "metrics"},
+			err:      "error reading",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target, err := getCustomServiceScrapeTarget("exporter", test.settings)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, target)
+		})
+	}
+}
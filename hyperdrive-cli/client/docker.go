@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -8,6 +10,7 @@ import (
 
 	dt "github.com/docker/docker/api/types"
 	dtc "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/nodeset-org/hyperdrive-daemon/shared/config"
 )
 
@@ -20,6 +23,19 @@ func (c *HyperdriveClient) GetDockerImage(containerName string) (string, error)
 	return ci.Config.Image, nil
 }
 
+// Get the directory Docker keeps its data in, which holds the client volumes
+func (c *HyperdriveClient) GetDockerRootDir() (string, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return "", err
+	}
+	info, err := d.Info(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("error getting Docker info: %w", err)
+	}
+	return info.DockerRootDir, nil
+}
+
 // Get a list of running project containers (values are always true, the map is just for quick name lookup)
 func (c *HyperdriveClient) GetRunningContainers(projectName string) (map[string]bool, error) {
 	d, err := c.GetDocker()
@@ -36,7 +52,7 @@ func (c *HyperdriveClient) GetRunningContainers(projectName string) (map[string]
 	for _, container := range cl {
 		for _, name := range container.Names {
 			name = strings.TrimPrefix(name, "/") // Docker throws a leading / on names
-			if strings.HasPrefix(name, projectName) && container.State == "running" {
+			if strings.HasPrefix(name, projectName+"_") && container.State == "running" {
 				containers[name] = true
 				break
 			}
@@ -45,6 +61,33 @@ func (c *HyperdriveClient) GetRunningContainers(projectName string) (map[string]
 	return containers, nil
 }
 
+// Get the project containers that are stuck restarting, dead, or failing their health checks, along with their status
+func (c *HyperdriveClient) GetUnhealthyContainers(projectName string) (map[string]string, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return nil, err
+	}
+	cl, err := d.ContainerList(context.Background(), dtc.ListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("error getting container list: %w", err)
+	}
+
+	containers := map[string]string{}
+	for _, container := range cl {
+		if len(container.Names) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(container.Names[0], "/") // Docker throws a leading / on names
+		if !strings.HasPrefix(name, projectName+"_") {
+			continue
+		}
+		if container.State == "restarting" || container.State == "dead" || strings.Contains(container.Status, "(unhealthy)") {
+			containers[name] = container.Status
+		}
+	}
+	return containers, nil
+}
+
 // Get the Docker images with the project ID as a prefix that run the VC start script in their command line arguments
 func (c *HyperdriveClient) GetValidatorContainers(projectName string) ([]string, error) {
 	d, err := c.GetDocker()
@@ -62,7 +105,7 @@ func (c *HyperdriveClient) GetValidatorContainers(projectName string) ([]string,
 		isProjectContainer := false
 		for _, name := range container.Names {
 			name = strings.TrimPrefix(name, "/") // Docker throws a leading / on names
-			if strings.HasPrefix(name, projectName) {
+			if strings.HasPrefix(name, projectName+"_") {
 				isProjectContainer = true
 			}
 		}
@@ -100,6 +143,54 @@ func (c *HyperdriveClient) GetDockerContainerShutdownTime(containerName string)
 	return finishTime, nil
 }
 
+// Get the time that the given container was last started
+func (c *HyperdriveClient) GetDockerContainerStartTime(containerName string) (time.Time, error) {
+	ci, err := inspectContainer(c, containerName)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// Parse the time
+	startTime, err := time.Parse(time.RFC3339, strings.TrimSpace(ci.State.StartedAt))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing container [%s] start time [%s]: %w", containerName, ci.State.StartedAt, err)
+	}
+	return startTime, nil
+}
+
+// Get the lines a container has logged since the provided time (stdout and stderr combined)
+func (c *HyperdriveClient) GetContainerLogs(containerName string, since time.Time) ([]string, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := d.ContainerLogs(context.Background(), containerName, dtc.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Since:      since.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting logs for container [%s]: %w", containerName, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	// Docker multiplexes stdout and stderr into one stream for containers without a TTY
+	var output bytes.Buffer
+	_, err = stdcopy.StdCopy(&output, &output, reader)
+	if err != nil {
+		return nil, fmt.Errorf("error reading logs for container [%s]: %w", containerName, err)
+	}
+
+	lines := []string{}
+	scanner := bufio.NewScanner(&output)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, nil
+}
+
 // Shut down a container
 func (c *HyperdriveClient) StopContainer(containerName string) error {
 	d, err := c.GetDocker()
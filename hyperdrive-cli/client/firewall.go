@@ -0,0 +1,224 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// A firewall that Hyperdrive can generate rules for
+type FirewallBackend string
+
+const (
+	FirewallBackend_Ufw      FirewallBackend = "ufw"
+	FirewallBackend_Nftables FirewallBackend = "nftables"
+	FirewallBackend_Iptables FirewallBackend = "iptables"
+)
+
+const (
+	// The prefix on the comment of each firewall rule Hyperdrive adds, used to find them again
+	firewallCommentPrefix string = "Hyperdrive"
+
+	// The name of the chain Hyperdrive's rules go in for iptables and nftables
+	iptablesChainName string = "HYPERDRIVE"
+	nftablesChainName string = "hyperdrive"
+)
+
+// All of the supported firewall backends
+var FirewallBackends = []FirewallBackend{
+	FirewallBackend_Ufw,
+	FirewallBackend_Nftables,
+	FirewallBackend_Iptables,
+}
+
+// An inbound port in the firewall
+type FirewallRule struct {
+	Port     uint16
+	Protocol string
+
+	// What the port is for
+	Description string
+}
+
+// Get the inbound firewall rules for the current settings.
+// Returns the ports that should be open to other machines, and the ones Hyperdrive uses that should stay closed along with why.
+func (c *GlobalConfig) GetFirewallRules() ([]FirewallRule, []FirewallRule) {
+	exporterPort := &c.Hyperdrive.Metrics.ExporterMetricsPort
+	grafanaPort := &c.Hyperdrive.Metrics.Grafana.Port
+	open := []FirewallRule{}
+	closed := []FirewallRule{}
+	seen := map[hostPortKey]bool{}
+	for _, port := range c.GetHostPorts() {
+		key := hostPortKey{port: port.Param.Value, protocol: port.Protocol}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		rule := FirewallRule{
+			Port:        port.Param.Value,
+			Protocol:    port.Protocol,
+			Description: fmt.Sprintf("%s (%s)", port.Param.Name, port.Path),
+		}
+		switch {
+		case port.Localhost:
+			rule.Description += " is only bound to localhost"
+			closed = append(closed, rule)
+		case port.Param == exporterPort:
+			// The exporter runs on the host network, but only Prometheus needs to reach it
+			rule.Description += " is only used by Prometheus"
+			closed = append(closed, rule)
+		case port.Param == grafanaPort:
+			// Grafana doesn't have a setting to open its port, so keep its login page off the internet; reach it over your LAN or an SSH tunnel
+			rule.Description += " is Grafana's login page, which shouldn't be public; open it for your local network yourself if you need to"
+			closed = append(closed, rule)
+		default:
+			open = append(open, rule)
+		}
+	}
+
+	sortRules := func(rules []FirewallRule) {
+		sort.SliceStable(rules, func(i, j int) bool {
+			if rules[i].Port != rules[j].Port {
+				return rules[i].Port < rules[j].Port
+			}
+			return rules[i].Protocol < rules[j].Protocol
+		})
+	}
+	sortRules(open)
+	sortRules(closed)
+	return open, closed
+}
+
+// Get a shell script that opens the ports in the current settings with the provided firewall.
+// Running it again replaces the rules from earlier runs, so ports that are no longer used are closed.
+func (c *GlobalConfig) GetFirewallScript(backend FirewallBackend) (string, error) {
+	open, closed := c.GetFirewallRules()
+
+	script := &strings.Builder{}
+	script.WriteString("#!/bin/sh\n")
+	script.WriteString(fmt.Sprintf("# Inbound firewall rules for Hyperdrive (%s), generated from its current settings.\n", backend))
+	script.WriteString("# Running this again replaces the rules from earlier runs.\n")
+	script.WriteString("# Note that Docker publishes container ports with its own rules, which bypass the host's INPUT chain.\n")
+	script.WriteString("# Ports that shouldn't be public are bound to localhost by Hyperdrive so they aren't exposed either way.\n")
+	if len(closed) > 0 {
+		script.WriteString("#\n# These ports are used by Hyperdrive but should NOT be opened:\n")
+		for _, rule := range closed {
+			script.WriteString(fmt.Sprintf("#   %d/%s: %s\n", rule.Port, rule.Protocol, rule.Description))
+		}
+	}
+	script.WriteString("\nset -e\n\n")
+
+	switch backend {
+	case FirewallBackend_Ufw:
+		writeUfwRules(script, open)
+	case FirewallBackend_Nftables:
+		writeNftablesRules(script, open)
+	case FirewallBackend_Iptables:
+		writeIptablesRules(script, open)
+	default:
+		return "", fmt.Errorf("unknown firewall backend [%s]", backend)
+	}
+	return script.String(), nil
+}
+
+// Apply the firewall rules for the current settings with root privileges
+func (c *HyperdriveClient) ApplyFirewallRules(cfg *GlobalConfig, backend FirewallBackend) error {
+	script, err := cfg.GetFirewallScript(backend)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the firewall is installed
+	tool := string(backend)
+	if backend == FirewallBackend_Nftables {
+		tool = "nft"
+	}
+	exists, err := checkIfCommandExists(tool)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s isn't installed on this machine", tool)
+	}
+
+	// Run the script as root
+	rootCmd, err := getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+	cmd := newCommand(fmt.Sprintf("%s sh -s", rootCmd))
+	cmd.SetStdin(strings.NewReader(script))
+	cmd.SetStdout(os.Stdout)
+	cmd.SetStderr(os.Stderr)
+	err = cmd.Run()
+	if err != nil {
+		return fmt.Errorf("error applying %s rules: %w", backend, err)
+	}
+	return nil
+}
+
+// Write the rules for ufw, which skips rules that already exist
+func writeUfwRules(script *strings.Builder, rules []FirewallRule) {
+	script.WriteString("# Remove the rules from earlier runs\n")
+	script.WriteString(fmt.Sprintf("ufw show added | grep \"comment '%s\" | sed -e \"s/^ufw //\" -e \"s/ comment '.*'$//\" | while read -r rule; do\n", firewallCommentPrefix))
+	script.WriteString("\tufw delete $rule\n")
+	script.WriteString("done\n\n")
+
+	script.WriteString("# Open the ports\n")
+	for _, rule := range rules {
+		script.WriteString(fmt.Sprintf("ufw allow %d/%s comment '%s: %s'\n", rule.Port, rule.Protocol, firewallCommentPrefix, getFirewallComment(rule)))
+	}
+}
+
+// Write the rules for nftables, which go in their own chain that the input chain jumps to
+func writeNftablesRules(script *strings.Builder, rules []FirewallRule) {
+	script.WriteString("# Rebuild Hyperdrive's chain\n")
+	script.WriteString("nft add table inet filter\n")
+	script.WriteString(fmt.Sprintf("nft add chain inet filter %s\n", nftablesChainName))
+	script.WriteString(fmt.Sprintf("nft flush chain inet filter %s\n", nftablesChainName))
+	for _, rule := range rules {
+		script.WriteString(fmt.Sprintf("nft add rule inet filter %s %s dport %d accept comment '\"%s: %s\"'\n", nftablesChainName, rule.Protocol, rule.Port, firewallCommentPrefix, getFirewallComment(rule)))
+	}
+
+	script.WriteString("\n# Jump to it from the input chain\n")
+	script.WriteString("if nft list chain inet filter input > /dev/null 2>&1; then\n")
+	script.WriteString(fmt.Sprintf("\tnft list chain inet filter input | grep -q \"jump %s\" || nft insert rule inet filter input jump %s\n", nftablesChainName, nftablesChainName))
+	script.WriteString("else\n")
+	script.WriteString(fmt.Sprintf("\techo \"There's no input chain in the inet filter table; add a rule that jumps to the %s chain from your own input chain.\"\n", nftablesChainName))
+	script.WriteString("fi\n")
+}
+
+// Write the rules for iptables and ip6tables, which go in their own chain that the INPUT chain jumps to
+func writeIptablesRules(script *strings.Builder, rules []FirewallRule) {
+	script.WriteString("for ipt in iptables ip6tables; do\n")
+	script.WriteString("\t# Rebuild Hyperdrive's chain\n")
+	script.WriteString(fmt.Sprintf("\t$ipt -N %s 2> /dev/null || true\n", iptablesChainName))
+	script.WriteString(fmt.Sprintf("\t$ipt -F %s\n", iptablesChainName))
+	for _, rule := range rules {
+		script.WriteString(fmt.Sprintf("\t$ipt -A %s -p %s --dport %d -m comment --comment \"%s: %s\" -j ACCEPT\n", iptablesChainName, rule.Protocol, rule.Port, firewallCommentPrefix, getFirewallComment(rule)))
+	}
+	script.WriteString("\n\t# Jump to it from the INPUT chain\n")
+	script.WriteString(fmt.Sprintf("\t$ipt -C INPUT -j %s 2> /dev/null || $ipt -I INPUT -j %s\n", iptablesChainName, iptablesChainName))
+	script.WriteString("done\n")
+}
+
+// Get the comment for a rule, without any characters that would break the quoting in the scripts
+func getFirewallComment(rule FirewallRule) string {
+	return strings.NewReplacer("'", "", "\"", "", "$", "", "`", "", "\\", "").Replace(rule.Description)
+}
+
+// Get a firewall backend from its name
+func ParseFirewallBackend(name string) (FirewallBackend, error) {
+	for _, backend := range FirewallBackends {
+		if string(backend) == name {
+			return backend, nil
+		}
+	}
+	names := []string{}
+	for _, backend := range FirewallBackends {
+		names = append(names, string(backend))
+	}
+	return "", fmt.Errorf("unknown firewall backend [%s]; the options are: %s", name, strings.Join(names, ", "))
+}
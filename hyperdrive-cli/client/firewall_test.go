@@ -0,0 +1,41 @@
+package client
+
+import (
+	"testing"
+
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFirewallRulesMetrics(t *testing.T) {
+	hdCfg, err := hdconfig.NewHyperdriveConfig(t.TempDir(), nil)
+	require.NoError(t, err)
+	swCfg, err := swconfig.NewStakeWiseConfig(hdCfg, nil)
+	require.NoError(t, err)
+	csCfg, err := csconfig.NewConstellationConfig(hdCfg, nil)
+	require.NoError(t, err)
+	cfg := &GlobalConfig{
+		Hyperdrive:    hdCfg,
+		StakeWise:     swCfg,
+		Constellation: csCfg,
+		Cli:           NewCliConfig(),
+	}
+	hdCfg.Metrics.EnableMetrics.Value = true
+
+	ports := func(rules []FirewallRule) []uint16 {
+		result := []uint16{}
+		for _, rule := range rules {
+			result = append(result, rule.Port)
+		}
+		return result
+	}
+
+	// Grafana and the exporter are used locally, so neither should be opened
+	open, closed := cfg.GetFirewallRules()
+	require.NotContains(t, ports(open), hdCfg.Metrics.Grafana.Port.Value)
+	require.Contains(t, ports(closed), hdCfg.Metrics.Grafana.Port.Value)
+	require.NotContains(t, ports(open), hdCfg.Metrics.ExporterMetricsPort.Value)
+	require.Contains(t, ports(open), hdCfg.LocalExecutionClient.P2pPort.Value)
+}
@@ -30,6 +30,12 @@ type GlobalConfig struct {
 	// Constellation
 	Constellation          *csconfig.ConstellationConfig
 	ConstellationResources *csconfig.ConstellationResources
+
+	// CLI-only settings
+	Cli *CliConfig
+
+	// The folder the daemons load network settings from
+	networksDir string
 }
 
 // Make a new global config
@@ -39,7 +45,9 @@ func NewGlobalConfig(hdCfg *hdconfig.HyperdriveConfig, hdSettings []*hdconfig.Hy
 		Hyperdrive:    hdCfg,
 		StakeWise:     swCfg,
 		Constellation: csCfg,
+		Cli:           NewCliConfig(),
 	}
+	config.ApplyDefaults(cfg.Cli, hdCfg.Network.Value)
 
 	// Get the HD resources
 	network := hdCfg.Network.Value
@@ -96,7 +104,10 @@ func (c *GlobalConfig) GetAllModuleConfigs() []hdconfig.IModuleConfig {
 
 // Serialize the config and all modules
 func (c *GlobalConfig) Serialize() map[string]any {
-	return c.Hyperdrive.Serialize(c.GetAllModuleConfigs(), false)
+	settings := c.Hyperdrive.Serialize(c.GetAllModuleConfigs(), false)
+	modules := settings[hdconfig.ModulesName].(map[string]any)
+	modules[CliConfigID] = config.Serialize(c.Cli)
+	return settings
 }
 
 // Deserialize the config's modules (assumes the Hyperdrive config itself has already been deserialized)
@@ -128,6 +139,19 @@ func (c *GlobalConfig) DeserializeModules() error {
 			return fmt.Errorf("error deserializing constellation configuration: %w", err)
 		}
 	}
+
+	// Load the CLI settings
+	section, exists = c.Hyperdrive.Modules[CliConfigID]
+	if exists {
+		configMap, ok := section.(map[string]any)
+		if !ok {
+			return fmt.Errorf("config module section [%s] is not a map, it's a %s", CliConfigID, reflect.TypeOf(section))
+		}
+		err := config.Deserialize(c.Cli, configMap, c.Hyperdrive.Network.Value)
+		if err != nil {
+			return fmt.Errorf("error deserializing CLI configuration: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -136,14 +160,32 @@ func (c *GlobalConfig) CreateCopy() *GlobalConfig {
 	hdCopy := c.Hyperdrive.Clone()
 	swCopy := c.StakeWise.Clone().(*swconfig.StakeWiseConfig)
 	csCopy := c.Constellation.Clone().(*csconfig.ConstellationConfig)
+	cliCopy := NewCliConfig()
+	config.Clone(c.Cli, cliCopy, c.Hyperdrive.Network.Value)
 
 	return &GlobalConfig{
-		Hyperdrive:    hdCopy,
-		StakeWise:     swCopy,
-		Constellation: csCopy,
+		Hyperdrive:             hdCopy,
+		HyperdriveResources:    c.HyperdriveResources,
+		StakeWise:              swCopy,
+		StakeWiseResources:     c.StakeWiseResources,
+		Constellation:          csCopy,
+		ConstellationResources: c.ConstellationResources,
+		Cli:                    cliCopy,
 	}
 }
 
+// Get the network resources for the currently selected network, which may differ from the network the config was loaded with.
+// Returns nil if the network isn't known.
+func (c *GlobalConfig) GetNetworkResources() *config.NetworkResources {
+	network := c.Hyperdrive.Network.Value
+	for _, setting := range c.Hyperdrive.GetNetworkSettings() {
+		if setting.Key == network {
+			return setting.NetworkResources
+		}
+	}
+	return nil
+}
+
 // Changes the current network, propagating new parameter settings if they are affected
 func (c *GlobalConfig) ChangeNetwork(newNetwork config.Network) {
 	// Get the current network
@@ -157,6 +199,7 @@ func (c *GlobalConfig) ChangeNetwork(newNetwork config.Network) {
 	for _, module := range c.GetAllModuleConfigs() {
 		module.ChangeNetwork(oldNetwork, newNetwork)
 	}
+	config.ChangeNetwork(c.Cli, oldNetwork, newNetwork)
 }
 
 // Updates the default parameters based on the current network value
@@ -166,6 +209,7 @@ func (c *GlobalConfig) UpdateDefaults() {
 	for _, module := range c.GetAllModuleConfigs() {
 		module.UpdateDefaults(network)
 	}
+	config.UpdateDefaults(c.Cli, network)
 }
 
 // Checks to see if the current configuration is valid; if not, returns a list of errors
@@ -305,6 +349,9 @@ func (c *GlobalConfig) Validate() []string {
 		_, errors = addAndCheckForDuplicate(portMap, c.Hyperdrive.MevBoost.Port, errors)
 	}
 
+	// Ensure the containers' resource settings are usable
+	errors = append(errors, c.validateContainerResources()...)
+
 	return errors
 }
 
@@ -321,6 +368,7 @@ func (c *GlobalConfig) GetChanges(oldConfig *GlobalConfig) ([]*config.ChangedSec
 	if c.Constellation.Enabled.Value || oldConfig.Constellation.Enabled.Value {
 		sectionList = getChanges(oldConfig.Constellation, c.Constellation, sectionList, changedContainers)
 	}
+	sectionList = getChanges(oldConfig.Cli, c.Cli, sectionList, changedContainers)
 
 	// Add all VCs to the list of changed containers if any change requires a VC change
 	if changedContainers[config.ContainerID_ValidatorClient] {
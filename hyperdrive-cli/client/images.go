@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/distribution/reference"
+	dimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/term"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The file in the user directory that records the digest each image tag resolved to
+	ImageLockFile string = "image-lock.yml"
+
+	// The compose file in the runtime folder that pins each service to its locked digest
+	imagePinsFile string = "image-pins.yml"
+)
+
+// The digests that each image tag resolved to when it was last pulled
+type ImageLock struct {
+	// Map of image tag to the digest reference it resolved to, such as nodeset/hyperdrive@sha256:...
+	Images map[string]string `yaml:"images"`
+}
+
+// An image whose tag points somewhere other than the digest in the lock file
+type ImageDrift struct {
+	// The image tag
+	Image string
+
+	// The digest reference in the lock file, or blank if the image isn't locked
+	LockedDigest string
+
+	// The digest reference the tag points to now
+	CurrentDigest string
+}
+
+// The services in a docker compose file, only including the fields needed to find their images
+type composeImageFile struct {
+	Services map[string]composeImageService `yaml:"services"`
+}
+
+// A service in a docker compose file, only including its image
+type composeImageService struct {
+	Image string `yaml:"image,omitempty"`
+}
+
+// Load the image lock file, or an empty lock if there isn't one yet
+func (c *HyperdriveClient) LoadImageLock() (*ImageLock, error) {
+	path, err := c.getImageLockPath()
+	if err != nil {
+		return nil, err
+	}
+	lock := &ImageLock{
+		Images: map[string]string{},
+	}
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading image lock file [%s]: %w", path, err)
+	}
+	err = yaml.Unmarshal(bytes, lock)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing image lock file [%s]: %w", path, err)
+	}
+	if lock.Images == nil {
+		lock.Images = map[string]string{}
+	}
+	return lock, nil
+}
+
+// Save the image lock file
+func (c *HyperdriveClient) SaveImageLock(lock *ImageLock) error {
+	path, err := c.getImageLockPath()
+	if err != nil {
+		return err
+	}
+	bytes, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("error serializing image lock: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing image lock file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the images the current settings would deploy, sorted by name
+func (c *HyperdriveClient) GetServiceImages(composeFiles []string) ([]string, error) {
+	_, _, files, err := c.deployComposeFiles(composeFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	// Leave out the pins so the tags are returned
+	unpinnedFiles := []string{}
+	for _, file := range files {
+		if filepath.Base(file) != imagePinsFile {
+			unpinnedFiles = append(unpinnedFiles, file)
+		}
+	}
+	serviceImages, err := getComposeServiceImages(unpinnedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	images := []string{}
+	for _, image := range serviceImages {
+		if seen[image] {
+			continue
+		}
+		seen[image] = true
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// Pull an image, printing the progress of each layer, and return the digest reference its tag resolved to
+func (c *HyperdriveClient) PullImage(image string) (string, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	reader, err := d.ImagePull(ctx, image, dimage.PullOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error pulling [%s]: %w", image, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	fd := os.Stdout.Fd()
+	err = jsonmessage.DisplayJSONMessagesStream(reader, os.Stdout, fd, term.IsTerminal(int(fd)), nil)
+	if err != nil {
+		return "", fmt.Errorf("error pulling [%s]: %w", image, err)
+	}
+
+	// Find the digest it was pulled with
+	info, _, err := d.ImageInspectWithRaw(ctx, image)
+	if err != nil {
+		return "", fmt.Errorf("error inspecting [%s]: %w", image, err)
+	}
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image name [%s]: %w", image, err)
+	}
+	for _, repoDigest := range info.RepoDigests {
+		digestNamed, err := reference.ParseNormalizedNamed(repoDigest)
+		if err != nil {
+			continue
+		}
+		if digestNamed.Name() == named.Name() {
+			return reference.FamiliarString(digestNamed), nil
+		}
+	}
+	return "", fmt.Errorf("[%s] was pulled but it doesn't have a digest for its repository", image)
+}
+
+// Check the images against the lock file, asking their registries what each tag points to now.
+// Returns the images that aren't locked or whose tags have moved.
+func (c *HyperdriveClient) VerifyImageLock(images []string) ([]ImageDrift, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return nil, err
+	}
+	lock, err := c.LoadImageLock()
+	if err != nil {
+		return nil, err
+	}
+
+	drift := []ImageDrift{}
+	for _, image := range images {
+		named, err := reference.ParseNormalizedNamed(image)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing image name [%s]: %w", image, err)
+		}
+		info, err := d.DistributionInspect(context.Background(), image, "")
+		if err != nil {
+			return nil, fmt.Errorf("error getting the digest of [%s] from its registry: %w", image, err)
+		}
+		current, err := reference.WithDigest(reference.TrimNamed(named), info.Descriptor.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("error making digest reference for [%s]: %w", image, err)
+		}
+
+		currentDigest := reference.FamiliarString(current)
+		lockedDigest := lock.Images[image]
+		if lockedDigest != currentDigest {
+			drift = append(drift, ImageDrift{
+				Image:         image,
+				LockedDigest:  lockedDigest,
+				CurrentDigest: currentDigest,
+			})
+		}
+	}
+	return drift, nil
+}
+
+// Write a compose file that pins each service's image to its digest in the lock file.
+// Returns the path of the file, or blank if none of the images are locked.
+func (c *HyperdriveClient) deployImagePins(composeFiles []string, runtimeFolder string) (string, error) {
+	lock, err := c.LoadImageLock()
+	if err != nil {
+		return "", err
+	}
+	serviceImages, err := getComposeServiceImages(composeFiles)
+	if err != nil {
+		return "", err
+	}
+
+	pins := composeImageFile{
+		Services: map[string]composeImageService{},
+	}
+	for service, image := range serviceImages {
+		digest, exists := lock.Images[image]
+		if exists {
+			pins.Services[service] = composeImageService{Image: digest}
+		}
+	}
+	if len(pins.Services) == 0 {
+		return "", nil
+	}
+
+	bytes, err := yaml.Marshal(pins)
+	if err != nil {
+		return "", fmt.Errorf("error serializing image pins: %w", err)
+	}
+	path := filepath.Join(runtimeFolder, imagePinsFile)
+	err = os.WriteFile(path, bytes, 0664)
+	if err != nil {
+		return "", fmt.Errorf("error writing image pins [%s]: %w", path, err)
+	}
+	return path, nil
+}
+
+// Get the path of the image lock file
+func (c *HyperdriveClient) getImageLockPath() (string, error) {
+	path, err := homedir.Expand(filepath.Join(c.Context.UserDirPath, ImageLockFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding image lock file path: %w", err)
+	}
+	return path, nil
+}
+
+// Get the image of each service in a set of compose files, keyed by service name.
+// Later files override the images in earlier ones, the same way docker compose merges them.
+func getComposeServiceImages(composeFiles []string) (map[string]string, error) {
+	images := map[string]string{}
+	for _, path := range composeFiles {
+		bytes, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading compose file [%s]: %w", path, err)
+		}
+		file := composeImageFile{}
+		err = yaml.Unmarshal(bytes, &file)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing compose file [%s]: %w", path, err)
+		}
+		for service, settings := range file.Services {
+			if settings.Image != "" {
+				images[service] = settings.Image
+			}
+		}
+	}
+	return images, nil
+}
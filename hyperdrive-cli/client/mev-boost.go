@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/beacon"
+)
+
+const (
+	// The timeout for each request made to a MEV-Boost relay
+	relayRequestTimeout time.Duration = 10 * time.Second
+
+	// The number of registration lookups to run against a relay at the same time
+	relayRegistrationWorkers int = 8
+
+	// The builder API route for checking a relay's status
+	relayStatusRoute string = "eth/v1/builder/status"
+
+	// The relay data API route for looking up a validator's registration
+	relayRegistrationRoute string = "relay/v1/data/validator_registration"
+)
+
+var (
+	// The names of Ethereum networks that relays put in their hostnames to distinguish their deployments.
+	// Mainnet relays typically don't include a network name.
+	knownRelayNetworkNames []string = []string{
+		"holesky",
+		"hoodi",
+		"sepolia",
+		"goerli",
+	}
+)
+
+// A MEV-Boost relay that Hyperdrive is configured to use
+type MevRelayEndpoint struct {
+	// The name of the relay
+	Name string
+
+	// The relay's URL with its public key removed
+	Url string
+}
+
+// The results of checking a relay's status endpoint
+type MevRelayStatus struct {
+	// True if the relay responded successfully
+	Reachable bool
+
+	// How long the status request took
+	Latency time.Duration
+
+	// A hint that the relay may be deployed for a different network, or blank if there isn't one.
+	// It's only a guess from the hostname, since relays don't report their network, so it can be wrong either way.
+	NetworkHint string
+
+	// The error returned by the status request, if it failed
+	Error error
+}
+
+// A validator's registration with a relay
+type MevRelayRegistration struct {
+	// True if the relay has a registration for the validator
+	Registered bool
+
+	// The fee recipient the validator registered with
+	FeeRecipient string
+
+	// The time the registration was signed
+	Timestamp time.Time
+
+	// The error returned by the lookup, if it failed
+	Error error
+}
+
+// Get the relays that MEV-Boost is configured to use on the current network, including any custom relays
+func GetMevRelayEndpoints(cfg *GlobalConfig) []MevRelayEndpoint {
+	endpoints := []MevRelayEndpoint{}
+	networkName := cfg.Hyperdrive.GetEthNetworkName()
+	for _, relay := range cfg.Hyperdrive.MevBoost.GetEnabledMevRelays() {
+		endpoints = append(endpoints, MevRelayEndpoint{
+			Name: relay.Name,
+			Url:  stripRelayPubkey(relay.Urls[networkName]),
+		})
+	}
+
+	customRelays := strings.Split(cfg.Hyperdrive.MevBoost.CustomRelays.Value, ",")
+	for i, relayUrl := range customRelays {
+		relayUrl = strings.TrimSpace(relayUrl)
+		if relayUrl == "" {
+			continue
+		}
+		endpoints = append(endpoints, MevRelayEndpoint{
+			Name: fmt.Sprintf("Custom Relay %d", i+1),
+			Url:  stripRelayPubkey(relayUrl),
+		})
+	}
+	return endpoints
+}
+
+// Check a relay's status endpoint, measuring how long it takes to respond, and guess whether it's on the provided Ethereum network from its hostname
+func CheckMevRelayStatus(ctx context.Context, relay MevRelayEndpoint, ethNetworkName string) *MevRelayStatus {
+	status := &MevRelayStatus{}
+
+	// Guess the network from the hostname
+	status.NetworkHint = getRelayNetworkHint(relay.Url, ethNetworkName)
+
+	// Check the status
+	start := time.Now()
+	_, statusCode, err := sendRelayRequest(ctx, fmt.Sprintf("%s/%s", relay.Url, relayStatusRoute))
+	status.Latency = time.Since(start)
+	if err != nil {
+		status.Error = err
+		return status
+	}
+	if statusCode != http.StatusOK {
+		status.Error = fmt.Errorf("status request failed with code %d", statusCode)
+		return status
+	}
+	status.Reachable = true
+	return status
+}
+
+// Look up the registrations for the provided validators on a relay.
+// The results are in the same order as the pubkeys.
+func GetMevRelayRegistrations(ctx context.Context, relay MevRelayEndpoint, pubkeys []beacon.ValidatorPubkey) []MevRelayRegistration {
+	registrations := make([]MevRelayRegistration, len(pubkeys))
+
+	// Run the lookups with a limited number of workers
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < relayRegistrationWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indices {
+				registrations[index] = getMevRelayRegistration(ctx, relay, pubkeys[index])
+			}
+		}()
+	}
+	for i := range pubkeys {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	return registrations
+}
+
+// Look up a single validator's registration on a relay
+func getMevRelayRegistration(ctx context.Context, relay MevRelayEndpoint, pubkey beacon.ValidatorPubkey) MevRelayRegistration {
+	body, statusCode, err := sendRelayRequest(ctx, fmt.Sprintf("%s/%s?pubkey=%s", relay.Url, relayRegistrationRoute, pubkey.HexWithPrefix()))
+	if err != nil {
+		return MevRelayRegistration{Error: err}
+	}
+
+	// Relays return 400 or 404 when there isn't a registration for the validator
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusNotFound {
+		return MevRelayRegistration{}
+	}
+	if statusCode != http.StatusOK {
+		return MevRelayRegistration{Error: fmt.Errorf("request failed with code %d: %s", statusCode, strings.TrimSpace(string(body)))}
+	}
+
+	var response struct {
+		Message struct {
+			FeeRecipient string `json:"fee_recipient"`
+			Timestamp    uint64 `json:"timestamp,string"`
+		} `json:"message"`
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return MevRelayRegistration{Error: fmt.Errorf("error parsing registration: %w", err)}
+	}
+	return MevRelayRegistration{
+		Registered:   true,
+		FeeRecipient: response.Message.FeeRecipient,
+		Timestamp:    time.Unix(int64(response.Message.Timestamp), 0),
+	}
+}
+
+// Get a hint that a relay may be deployed for a different network than the provided one, based on the network names in its hostname.
+// Returns blank if there's nothing suspicious. This is a heuristic: relays aren't required to name their network, so it can miss
+// a relay on the wrong network and it can flag one that's on the right network.
+func getRelayNetworkHint(relayUrl string, ethNetworkName string) string {
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return fmt.Sprintf("has an invalid URL: %s", err.Error())
+	}
+	hostname := strings.ToLower(parsedUrl.Hostname())
+
+	for _, networkName := range knownRelayNetworkNames {
+		if networkName != ethNetworkName && strings.Contains(hostname, networkName) {
+			return fmt.Sprintf("mentions %s in its hostname, so it may be a %s relay", networkName, networkName)
+		}
+	}
+	if ethNetworkName != "mainnet" && !strings.Contains(hostname, ethNetworkName) {
+		return fmt.Sprintf("doesn't mention %s in its hostname, so it may be a Mainnet relay", ethNetworkName)
+	}
+	return ""
+}
+
+// Remove the public key from a relay URL, leaving just the API endpoint
+func stripRelayPubkey(relayUrl string) string {
+	parsedUrl, err := url.Parse(strings.TrimSpace(relayUrl))
+	if err != nil {
+		return strings.TrimRight(relayUrl, "/")
+	}
+	parsedUrl.User = nil
+	return strings.TrimRight(parsedUrl.String(), "/")
+}
+
+// Send a GET request to a relay, returning the response body and status code
+func sendRelayRequest(ctx context.Context, url string) ([]byte, int, error) {
+	ctx, cancel := context.WithTimeout(ctx, relayRequestTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error creating request: %w", err)
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error sending request: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading response: %w", err)
+	}
+	return body, response.StatusCode, nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRelayNetworkHint(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		ethNetworkName string
+		hasHint        bool
+	}{
+		{"mainnet relay on mainnet", "https://boost-relay.flashbots.net", "mainnet", false},
+		{"testnet relay on mainnet", "https://boost-relay-hoodi.flashbots.net", "mainnet", true},
+		{"matching testnet relay", "https://boost-relay-hoodi.flashbots.net", "hoodi", false},
+		{"other testnet relay", "https://boost-relay-holesky.flashbots.net", "hoodi", true},
+		{"unnamed relay on a testnet", "https://relay.example.com", "hoodi", true},
+		{"uppercase hostname", "https://BOOST-RELAY-HOODI.example.com", "hoodi", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hint := getRelayNetworkHint(test.url, test.ethNetworkName)
+			if test.hasHint {
+				require.NotEmpty(t, hint)
+			} else {
+				require.Empty(t, hint)
+			}
+		})
+	}
+}
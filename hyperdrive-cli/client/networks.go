@@ -0,0 +1,351 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
+	"github.com/rocket-pool/node-manager-core/config"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The folder in the user directory that the built-in and custom network settings are merged into for the daemons
+	deployedNetworksDir string = "runtime-networks"
+
+	// The folder the daemons load network settings from when there aren't any custom networks
+	systemNetworksDir string = "/usr/share/hyperdrive/networks"
+
+	// The length of a fork version, in bytes
+	forkVersionLength int = 4
+)
+
+var (
+	// The allowed format for custom network keys
+	networkKeyRegex *regexp.Regexp = regexp.MustCompile("^[a-z0-9][a-z0-9-]*$")
+)
+
+// A user-defined network, with the settings for Hyperdrive and each of its modules in a single file
+type CustomNetworkDefinition struct {
+	*hdconfig.HyperdriveSettings `yaml:",inline"`
+
+	// The settings for each module on the network
+	Modules CustomNetworkModules `yaml:"modules"`
+}
+
+// The module settings for a user-defined network
+type CustomNetworkModules struct {
+	// The StakeWise settings, if the network supports StakeWise
+	StakeWise *swconfig.StakeWiseSettings `yaml:"stakewise,omitempty"`
+
+	// The Constellation settings, if the network supports Constellation
+	Constellation *csconfig.ConstellationSettings `yaml:"constellation,omitempty"`
+}
+
+// Load a custom network definition from a file, rejecting any fields that aren't part of the schema
+func LoadCustomNetworkDefinition(path string) (*CustomNetworkDefinition, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading network file [%s]: %w", path, err)
+	}
+
+	definition := &CustomNetworkDefinition{
+		HyperdriveSettings: &hdconfig.HyperdriveSettings{},
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+	err = decoder.Decode(definition)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing network file [%s]: %w", path, err)
+	}
+	return definition, nil
+}
+
+// Validate a custom network definition against the built-in networks.
+// Returns warnings for settings that are allowed but likely to cause problems, and an error describing every invalid setting.
+func (d *CustomNetworkDefinition) Validate(builtInNetworks []config.Network) ([]string, error) {
+	warnings := []string{}
+	errs := []error{}
+	if d.NetworkSettings == nil {
+		return nil, fmt.Errorf("network settings are missing")
+	}
+
+	// Identity
+	key := string(d.Key)
+	if !networkKeyRegex.MatchString(key) {
+		errs = append(errs, fmt.Errorf("key [%s] must start with a lowercase letter or number and contain only lowercase letters, numbers, and dashes", key))
+	}
+	for _, builtIn := range builtInNetworks {
+		if d.Key == builtIn {
+			errs = append(errs, fmt.Errorf("key [%s] is already used by a built-in network", key))
+		}
+	}
+	if strings.TrimSpace(d.Name) == "" {
+		errs = append(errs, fmt.Errorf("name is missing"))
+	}
+
+	// Network resources
+	resources := d.NetworkResources
+	if resources == nil {
+		errs = append(errs, fmt.Errorf("networkResources is missing"))
+	} else {
+		if strings.TrimSpace(resources.EthNetworkName) == "" {
+			errs = append(errs, fmt.Errorf("networkResources.ethNetworkName is missing"))
+		}
+		if resources.ChainID == 0 {
+			errs = append(errs, fmt.Errorf("networkResources.chainID must be greater than zero"))
+		}
+		if len(resources.GenesisForkVersion) != forkVersionLength {
+			errs = append(errs, fmt.Errorf("networkResources.genesisForkVersion must be %d bytes but was %d", forkVersionLength, len(resources.GenesisForkVersion)))
+		}
+		if resources.DepositContractAddress == (common.Address{}) {
+			errs = append(errs, fmt.Errorf("networkResources.depositContractAddress is missing"))
+		}
+		if resources.MulticallAddress == (common.Address{}) {
+			warnings = append(warnings, "networkResources.multicallAddress is not set, so batched contract calls will fail")
+		}
+		if resources.BalanceBatcherAddress == (common.Address{}) {
+			warnings = append(warnings, "networkResources.balanceBatcherAddress is not set, so batched balance lookups will fail")
+		}
+	}
+
+	// Hyperdrive resources
+	hdResources := d.HyperdriveResources
+	if hdResources == nil {
+		errs = append(errs, fmt.Errorf("hyperdriveResources is missing"))
+	} else {
+		apiUrl, err := url.Parse(hdResources.NodeSetApiUrl)
+		if err != nil || (apiUrl.Scheme != "http" && apiUrl.Scheme != "https") || apiUrl.Host == "" {
+			errs = append(errs, fmt.Errorf("hyperdriveResources.nodeSetApiUrl [%s] must be an http or https URL", hdResources.NodeSetApiUrl))
+		}
+		if strings.TrimSpace(hdResources.EncryptionPubkey) == "" {
+			warnings = append(warnings, "hyperdriveResources.encryptionPubkey is not set, so encrypted uploads to the NodeSet API will fail")
+		}
+	}
+
+	// StakeWise
+	sw := d.Modules.StakeWise
+	if sw == nil || sw.StakeWiseResources == nil {
+		warnings = append(warnings, "the StakeWise module has no settings, so it can't be used on this network")
+	} else {
+		swResources := sw.StakeWiseResources
+		if strings.TrimSpace(swResources.DeploymentName) == "" {
+			errs = append(errs, fmt.Errorf("modules.stakewise.stakeWiseResources.deploymentName is missing"))
+		}
+		if swResources.Vault == (common.Address{}) {
+			errs = append(errs, fmt.Errorf("modules.stakewise.stakeWiseResources.vault is missing"))
+		}
+		if swResources.FeeRecipient == (common.Address{}) {
+			errs = append(errs, fmt.Errorf("modules.stakewise.stakeWiseResources.feeRecipient is missing"))
+		}
+		if swResources.Keeper == (common.Address{}) {
+			errs = append(errs, fmt.Errorf("modules.stakewise.stakeWiseResources.keeper is missing"))
+		}
+	}
+
+	// Constellation
+	cs := d.Modules.Constellation
+	if cs == nil || cs.ConstellationResources == nil {
+		warnings = append(warnings, "the Constellation module has no settings, so it can't be used on this network")
+	} else {
+		csResources := cs.ConstellationResources
+		if strings.TrimSpace(csResources.DeploymentName) == "" {
+			errs = append(errs, fmt.Errorf("modules.constellation.constellationResources.deploymentName is missing"))
+		}
+		if isMissingAddress(csResources.Directory) {
+			errs = append(errs, fmt.Errorf("modules.constellation.constellationResources.directory is missing"))
+		}
+		if isMissingAddress(csResources.RocketStorage) {
+			errs = append(errs, fmt.Errorf("modules.constellation.constellationResources.rocketStorage is missing"))
+		}
+		if isMissingAddress(csResources.FeeRecipient) {
+			errs = append(errs, fmt.Errorf("modules.constellation.constellationResources.feeRecipient is missing"))
+		}
+		if cs.SmartNodeResources == nil {
+			errs = append(errs, fmt.Errorf("modules.constellation.smartNodeResources is missing"))
+		}
+	}
+
+	return warnings, errors.Join(errs...)
+}
+
+// Get the keys of the networks that ship with Hyperdrive
+func (c *HyperdriveClient) GetBuiltInNetworks() ([]config.Network, error) {
+	settings, err := hdconfig.LoadSettingsFiles(c.Context.NetworksDir)
+	if err != nil {
+		return nil, fmt.Errorf("error loading built-in network settings: %w", err)
+	}
+	networks := make([]config.Network, len(settings))
+	for i, setting := range settings {
+		networks[i] = setting.Key
+	}
+	return networks, nil
+}
+
+// Save a custom network definition into the user directory, split into the Hyperdrive and module settings files the daemons expect
+func (c *HyperdriveClient) SaveCustomNetwork(definition *CustomNetworkDefinition) error {
+	customDir, err := c.Context.GetCustomNetworksDir()
+	if err != nil {
+		return err
+	}
+	key := definition.Key
+
+	// Every module needs settings for the network, even if it can't be used there
+	swSettings := definition.Modules.StakeWise
+	if swSettings == nil {
+		swSettings = &swconfig.StakeWiseSettings{}
+	}
+	if swSettings.StakeWiseResources == nil {
+		swSettings.StakeWiseResources = &swconfig.StakeWiseResources{}
+	}
+	swSettings.Key = key
+	csSettings := definition.Modules.Constellation
+	if csSettings == nil {
+		csSettings = &csconfig.ConstellationSettings{}
+	}
+	if csSettings.ConstellationResources == nil {
+		csSettings.ConstellationResources = &csconfig.ConstellationResources{}
+	}
+	csSettings.Key = key
+
+	files := map[string]any{
+		filepath.Join(customDir, getNetworkFilename(key)):                                            definition.HyperdriveSettings,
+		filepath.Join(customDir, hdconfig.ModulesName, swconfig.ModuleName, getNetworkFilename(key)): swSettings,
+		filepath.Join(customDir, hdconfig.ModulesName, csconfig.ModuleName, getNetworkFilename(key)): csSettings,
+	}
+	for path, settings := range files {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			return fmt.Errorf("error creating network settings folder [%s]: %w", filepath.Dir(path), err)
+		}
+		contents, err := yaml.Marshal(settings)
+		if err != nil {
+			return fmt.Errorf("error serializing network settings for [%s]: %w", path, err)
+		}
+		err = os.WriteFile(path, contents, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing network settings file [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Check if a custom network with the provided key has been added
+func (c *HyperdriveClient) CustomNetworkExists(key config.Network) (bool, error) {
+	customDir, err := c.Context.GetCustomNetworksDir()
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(filepath.Join(customDir, getNetworkFilename(key)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking for network [%s]: %w", key, err)
+	}
+	return true, nil
+}
+
+// Remove a custom network's settings files from the user directory
+func (c *HyperdriveClient) RemoveCustomNetwork(key config.Network) error {
+	customDir, err := c.Context.GetCustomNetworksDir()
+	if err != nil {
+		return err
+	}
+	paths := []string{
+		filepath.Join(customDir, getNetworkFilename(key)),
+		filepath.Join(customDir, hdconfig.ModulesName, swconfig.ModuleName, getNetworkFilename(key)),
+		filepath.Join(customDir, hdconfig.ModulesName, csconfig.ModuleName, getNetworkFilename(key)),
+	}
+	for _, path := range paths {
+		err = os.Remove(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("error deleting network settings file [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Merge the built-in and custom network settings into a folder in the user directory the daemons can read, if there are any custom networks.
+// Returns the folder the daemons should load network settings from.
+func (c *HyperdriveClient) deployNetworkSettings(hyperdriveDir string) (string, error) {
+	customDir, err := c.Context.GetCustomNetworksDir()
+	if err != nil {
+		return "", err
+	}
+	deployedFolder := filepath.Join(hyperdriveDir, deployedNetworksDir)
+
+	// Clear out the old copy
+	err = os.RemoveAll(deployedFolder)
+	if err != nil {
+		return "", fmt.Errorf("error deleting deployed network settings folder [%s]: %w", deployedFolder, err)
+	}
+	_, err = os.Stat(customDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return systemNetworksDir, nil
+	}
+
+	// Copy the built-in networks last so a custom network can never replace one of them
+	for _, sourceDir := range []string{customDir, c.Context.NetworksDir} {
+		err = copyNetworkSettings(sourceDir, deployedFolder)
+		if err != nil {
+			return "", err
+		}
+	}
+	return deployedFolder, nil
+}
+
+// Copy the network settings files from one folder to another, keeping the module folder layout
+func copyNetworkSettings(sourceDir string, targetDir string) error {
+	return filepath.WalkDir(sourceDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading network settings [%s]: %w", path, err)
+		}
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return fmt.Errorf("error getting relative path for [%s]: %w", path, err)
+		}
+		targetPath := filepath.Join(targetDir, relPath)
+		if entry.IsDir() {
+			err = os.MkdirAll(targetPath, 0755)
+			if err != nil {
+				return fmt.Errorf("error creating network settings folder [%s]: %w", targetPath, err)
+			}
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if !entry.Type().IsRegular() || (ext != ".yml" && ext != ".yaml") {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading network settings file [%s]: %w", path, err)
+		}
+		err = os.WriteFile(targetPath, contents, 0644)
+		if err != nil {
+			return fmt.Errorf("error writing network settings file [%s]: %w", targetPath, err)
+		}
+		return nil
+	})
+}
+
+// Get the name of the settings file for a network
+func getNetworkFilename(key config.Network) string {
+	return fmt.Sprintf("%s.yml", key)
+}
+
+// Check if an optional address is missing or empty
+func isMissingAddress(address *common.Address) bool {
+	return address == nil || *address == (common.Address{})
+}
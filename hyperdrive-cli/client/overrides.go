@@ -0,0 +1,345 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/compose-spec/compose-go/v2/loader"
+	"github.com/compose-spec/compose-go/v2/schema"
+	"github.com/compose-spec/compose-go/v2/types"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// The prefix of docker compose extension keys, which compose ignores
+const composeExtensionPrefix string = "x-"
+
+// The results of checking an override file
+type OverrideCheck struct {
+	// The path of the override file, relative to the override folder
+	Name string
+
+	// True if the current settings deploy the container this override is for
+	InUse bool
+
+	// Problems that will keep Docker Compose from starting the service
+	Errors []string
+
+	// Parts of the override that are likely stale, such as services or variables the templates don't produce anymore
+	Warnings []string
+}
+
+// The results of checking all of the override files
+type OverrideCheckResults struct {
+	// The results for each override file, sorted by name
+	Overrides []OverrideCheck
+
+	// The error Docker Compose will hit loading the whole service with every override applied, or blank if it loads
+	ProjectError string
+}
+
+// The changes an override file makes to the compose file it overrides
+type OverrideDiff struct {
+	// The path of the override file, relative to the override folder
+	Name string
+
+	// A unified diff from the compose file without the override to the compose file with it, or blank if it doesn't change anything
+	Diff string
+}
+
+// Check every override file: parse it, validate it against the Compose spec, merge it with the compose file the current settings generate for it,
+// and look for services or environment variables the templates don't produce anymore.
+func (c *HyperdriveClient) CheckOverrides(composeFiles []string) (*OverrideCheckResults, error) {
+	cfg, hyperdriveDir, files, err := c.deployComposeFiles(composeFiles)
+	if err != nil {
+		return nil, err
+	}
+	overrideFolder := filepath.Join(hyperdriveDir, overrideDir)
+	runtimeFolder := filepath.Join(hyperdriveDir, runtimeDir)
+	projectName := cfg.Hyperdrive.ProjectName.Value
+	names, err := getOverrideNames(overrideFolder)
+	if err != nil {
+		return nil, err
+	}
+	deployed := map[string]bool{}
+	for _, file := range files {
+		deployed[file] = true
+	}
+
+	results := &OverrideCheckResults{
+		Overrides: []OverrideCheck{},
+	}
+	for _, name := range names {
+		check := OverrideCheck{
+			Name: name,
+		}
+		overridePath := filepath.Join(overrideFolder, name)
+		basePath := filepath.Join(runtimeFolder, name)
+		check.InUse = deployed[basePath] && deployed[overridePath]
+
+		// Parse it and validate it on its own
+		override, err := parseComposeFile(overridePath)
+		if err != nil {
+			check.Errors = append(check.Errors, err.Error())
+			results.Overrides = append(results.Overrides, check)
+			continue
+		}
+		if _, exists := override["version"]; exists {
+			check.Warnings = append(check.Warnings, "it has a `version` key, which Docker Compose doesn't use anymore")
+			delete(override, "version")
+		}
+		err = schema.Validate(override)
+		if err != nil {
+			check.Errors = append(check.Errors, err.Error())
+		}
+
+		// Make sure it still belongs to a container
+		if !check.InUse {
+			_, err = os.Stat(filepath.Join(c.Context.OverrideSourceDir, name))
+			if errors.Is(err, fs.ErrNotExist) {
+				check.Warnings = append(check.Warnings, "it isn't for any container this version of Hyperdrive deploys, so it's never used")
+			}
+			results.Overrides = append(results.Overrides, check)
+			continue
+		}
+
+		// Look for anything the template doesn't produce anymore
+		base, err := parseComposeFile(basePath)
+		if err != nil {
+			return nil, err
+		}
+		check.Warnings = append(check.Warnings, getStaleOverrideWarnings(base, override)...)
+
+		// Make sure it merges cleanly with the compose file it overrides
+		if len(check.Errors) == 0 {
+			_, err = loadComposeProject(hyperdriveDir, projectName, true, basePath, overridePath)
+			if err != nil {
+				check.Errors = append(check.Errors, err.Error())
+			}
+		}
+		results.Overrides = append(results.Overrides, check)
+	}
+
+	// Load the whole project the way Docker Compose will
+	_, err = loadComposeProject(hyperdriveDir, projectName, false, files...)
+	if err != nil {
+		results.ProjectError = err.Error()
+	}
+	return results, nil
+}
+
+// Get the changes each override file in use makes to the compose file the current settings generate for it, sorted by name
+func (c *HyperdriveClient) DiffOverrides(composeFiles []string) ([]OverrideDiff, error) {
+	cfg, hyperdriveDir, files, err := c.deployComposeFiles(composeFiles)
+	if err != nil {
+		return nil, err
+	}
+	overrideFolder := filepath.Join(hyperdriveDir, overrideDir)
+	runtimeFolder := filepath.Join(hyperdriveDir, runtimeDir)
+	projectName := cfg.Hyperdrive.ProjectName.Value
+	names, err := getOverrideNames(overrideFolder)
+	if err != nil {
+		return nil, err
+	}
+	deployed := map[string]bool{}
+	for _, file := range files {
+		deployed[file] = true
+	}
+
+	diffs := []OverrideDiff{}
+	for _, name := range names {
+		overridePath := filepath.Join(overrideFolder, name)
+		basePath := filepath.Join(runtimeFolder, name)
+		if !deployed[basePath] || !deployed[overridePath] {
+			continue
+		}
+
+		before, err := loadComposeProject(hyperdriveDir, projectName, true, basePath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading compose file [%s]: %w", basePath, err)
+		}
+		after, err := loadComposeProject(hyperdriveDir, projectName, true, basePath, overridePath)
+		if err != nil {
+			return nil, fmt.Errorf("error applying override [%s]: %w", name, err)
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(before),
+			B:        difflib.SplitLines(after),
+			FromFile: filepath.Join(runtimeDir, name),
+			ToFile:   filepath.Join(overrideDir, name),
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error comparing override [%s]: %w", name, err)
+		}
+		diffs = append(diffs, OverrideDiff{
+			Name: name,
+			Diff: diff,
+		})
+	}
+	return diffs, nil
+}
+
+// Get the paths of all of the override files, relative to the override folder, sorted by name
+func getOverrideNames(overrideFolder string) ([]string, error) {
+	names := []string{}
+	err := filepath.WalkDir(overrideFolder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".yml" {
+			return nil
+		}
+		name, err := filepath.Rel(overrideFolder, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating override folder [%s]: %w", overrideFolder, err)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Read and parse a compose file
+func parseComposeFile(path string) (map[string]any, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	file, err := loader.ParseYAML(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing YAML: %w", err)
+	}
+	if file == nil {
+		file = map[string]any{}
+	}
+	return file, nil
+}
+
+// Load a set of compose files into one project the way Docker Compose does, and return it as YAML.
+// If partial is set, references to services, networks, or volumes outside of the provided files are allowed.
+// Extension keys like the stock overrides' x-rp-comment are left out.
+func loadComposeProject(hyperdriveDir string, projectName string, partial bool, files ...string) (string, error) {
+	configFiles := []types.ConfigFile{}
+	for _, path := range files {
+		file, err := parseComposeFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		removeComposeExtensions(file)
+		configFiles = append(configFiles, types.ConfigFile{
+			Filename: path,
+			Config:   file,
+		})
+	}
+
+	project, err := loader.LoadWithContext(context.Background(), types.ConfigDetails{
+		WorkingDir:  hyperdriveDir,
+		ConfigFiles: configFiles,
+		Environment: types.NewMapping(os.Environ()),
+	}, func(opts *loader.Options) {
+		opts.SetProjectName(projectName, true)
+		opts.SkipConsistencyCheck = partial
+		opts.SkipResolveEnvironment = true
+	})
+	if err != nil {
+		return "", err
+	}
+	bytes, err := project.MarshalYAML()
+	if err != nil {
+		return "", fmt.Errorf("error serializing compose project: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// Get warnings for the parts of an override that the compose file it overrides doesn't have anymore
+func getStaleOverrideWarnings(base map[string]any, override map[string]any) []string {
+	warnings := []string{}
+	baseServices, _ := base["services"].(map[string]any)
+	overrideServices, _ := override["services"].(map[string]any)
+	serviceNames := []string{}
+	for name := range overrideServices {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	for _, name := range serviceNames {
+		overrideService, _ := overrideServices[name].(map[string]any)
+		baseService, exists := baseServices[name].(map[string]any)
+		if !exists {
+			// Services with their own image or build are new ones rather than changes to the template's
+			_, hasImage := overrideService["image"]
+			_, hasBuild := overrideService["build"]
+			if !hasImage && !hasBuild {
+				warnings = append(warnings, fmt.Sprintf("it overrides service [%s], which the template doesn't produce anymore", name))
+			}
+			continue
+		}
+		baseVariables := getComposeEnvironmentNames(baseService["environment"])
+		for _, variable := range getComposeEnvironmentNames(overrideService["environment"]) {
+			if !containsString(baseVariables, variable) {
+				warnings = append(warnings, fmt.Sprintf("it sets environment variable [%s] on [%s], which the template doesn't set anymore", variable, name))
+			}
+		}
+	}
+	return warnings
+}
+
+// Get the names of the variables in a service's environment, which can be a list of KEY=VALUE strings or a map
+func getComposeEnvironmentNames(environment any) []string {
+	names := []string{}
+	switch environment := environment.(type) {
+	case []any:
+		for _, entry := range environment {
+			if entry, isString := entry.(string); isString {
+				name, _, _ := strings.Cut(entry, "=")
+				names = append(names, name)
+			}
+		}
+	case map[string]any:
+		for name := range environment {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove the extension keys from the top level of a compose file and from each of its services
+func removeComposeExtensions(file map[string]any) {
+	for key := range file {
+		if strings.HasPrefix(key, composeExtensionPrefix) {
+			delete(file, key)
+		}
+	}
+	services, _ := file["services"].(map[string]any)
+	for _, service := range services {
+		service, isMap := service.(map[string]any)
+		if !isMap {
+			continue
+		}
+		for key := range service {
+			if strings.HasPrefix(key, composeExtensionPrefix) {
+				delete(service, key)
+			}
+		}
+	}
+}
+
+// Check if a sorted slice contains a string
+func containsString(values []string, value string) bool {
+	index := sort.SearchStrings(values, value)
+	return index < len(values) && values[index] == value
+}
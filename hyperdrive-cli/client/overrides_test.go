@@ -0,0 +1,98 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetComposeEnvironmentNames(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment any
+		expected    []string
+	}{
+		{"missing", nil, []string{}},
+		{"list", []any{"B=2", "A=1", "C"}, []string{"A", "B", "C"}},
+		{"list with a value containing =", []any{"FLAGS=--a=b"}, []string{"FLAGS"}},
+		{"map", map[string]any{"B": "2", "A": nil}, []string{"A", "B"}},
+		{"unexpected type", "A=1", []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, getComposeEnvironmentNames(test.environment))
+		})
+	}
+}
+
+func TestGetStaleOverrideWarnings(t *testing.T) {
+	base := map[string]any{
+		"services": map[string]any{
+			"ec": map[string]any{
+				"image":       "ethereum/client-go",
+				"environment": []any{"CLIENT=geth", "EC_HTTP_PORT=8545"},
+			},
+		},
+	}
+	tests := []struct {
+		name     string
+		override map[string]any
+		warnings int
+	}{
+		{
+			name:     "empty",
+			override: map[string]any{},
+			warnings: 0,
+		},
+		{
+			name: "variables the template sets, as a map",
+			override: map[string]any{
+				"services": map[string]any{
+					"ec": map[string]any{"environment": map[string]any{"CLIENT": "nethermind"}},
+				},
+			},
+			warnings: 0,
+		},
+		{
+			name: "variables the template doesn't set, as a list",
+			override: map[string]any{
+				"services": map[string]any{
+					"ec": map[string]any{"environment": []any{"EC_HTTP_PORT=8546", "OLD_FLAG=1", "OTHER"}},
+				},
+			},
+			warnings: 2,
+		},
+		{
+			name: "service the template doesn't produce",
+			override: map[string]any{
+				"services": map[string]any{
+					"eth1": map[string]any{"restart": "always"},
+				},
+			},
+			warnings: 1,
+		},
+		{
+			name: "new service with an image",
+			override: map[string]any{
+				"services": map[string]any{
+					"shipper": map[string]any{"image": "busybox"},
+				},
+			},
+			warnings: 0,
+		},
+		{
+			name: "new service with a build",
+			override: map[string]any{
+				"services": map[string]any{
+					"exporter": map[string]any{"build": "."},
+				},
+			},
+			warnings: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Len(t, getStaleOverrideWarnings(base, test.override), test.warnings)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"syscall"
+
+	dtc "github.com/docker/docker/api/types/container"
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The label Docker Compose puts on containers with the name of their project
+	composeProjectLabel string = "com.docker.compose.project"
+)
+
+// A port that one of the Hyperdrive containers binds on the host
+type HostPort struct {
+	// The parameter that sets the port
+	Param *config.Parameter[uint16]
+
+	// The path to the parameter in the settings file, such as hyperdrive.apiPort
+	Path string
+
+	// The network protocol, either tcp or udp
+	Protocol string
+
+	// True if the port is only bound to localhost
+	Localhost bool
+
+	// The name of the container that binds the port
+	Container string
+}
+
+// A host port that's already in use by something other than Hyperdrive
+type PortConflict struct {
+	Port HostPort
+
+	// What's using the port
+	Owner string
+}
+
+// A port setting that was moved to a free port
+type PortReassignment struct {
+	Port    HostPort
+	OldPort uint16
+}
+
+// A port number and protocol
+type hostPortKey struct {
+	port     uint16
+	protocol string
+}
+
+// Get the ports the Hyperdrive containers will bind on the host with the current settings
+func (c *GlobalConfig) GetHostPorts() []HostPort {
+	paths := map[config.IParameter]string{}
+	for _, paramPath := range c.getParameterPaths() {
+		paths[paramPath.param] = paramPath.path
+	}
+
+	hd := c.Hyperdrive
+	ports := []HostPort{}
+	add := func(param *config.Parameter[uint16], localhost bool, container string, protocols ...string) {
+		for _, protocol := range protocols {
+			ports = append(ports, HostPort{
+				Param:     param,
+				Path:      paths[param],
+				Protocol:  protocol,
+				Localhost: localhost,
+				Container: hd.GetDockerArtifactName(container),
+			})
+		}
+	}
+	addRpc := func(param *config.Parameter[uint16], mode config.RpcPortMode, container string) {
+		if mode.IsOpen() {
+			add(param, mode == config.RpcPortMode_OpenLocalhost, container, "tcp")
+		}
+	}
+
+	// The daemons' APIs are always bound to localhost
+	add(&hd.ApiPort, true, string(config.ContainerID_Daemon), "tcp")
+	if c.StakeWise.Enabled.Value {
+		add(&c.StakeWise.ApiPort, true, string(swconfig.ContainerID_StakeWiseDaemon), "tcp")
+		add(&c.StakeWise.RelayPort, true, string(swconfig.ContainerID_StakeWiseDaemon), "tcp")
+	}
+	if c.Constellation.Enabled.Value {
+		add(&c.Constellation.ApiPort, true, string(csconfig.ContainerID_ConstellationDaemon), "tcp")
+	}
+
+	// Local clients
+	if hd.IsLocalMode() {
+		ec := hd.LocalExecutionClient
+		ecName := string(config.ContainerID_ExecutionClient)
+		add(&ec.P2pPort, false, ecName, "tcp", "udp")
+		addRpc(&ec.HttpPort, ec.OpenApiPorts.Value, ecName)
+		addRpc(&ec.WebsocketPort, ec.OpenApiPorts.Value, ecName)
+
+		bn := hd.LocalBeaconClient
+		bnName := string(config.ContainerID_BeaconNode)
+		add(&bn.P2pPort, false, bnName, "tcp", "udp")
+		switch bn.BeaconNode.Value {
+		case config.BeaconNode_Lighthouse:
+			add(&bn.Lighthouse.P2pQuicPort, false, bnName, "udp")
+		case config.BeaconNode_Prysm:
+			add(&bn.Prysm.P2pQuicPort, false, bnName, "udp")
+			addRpc(&bn.Prysm.RpcPort, bn.Prysm.OpenRpcPort.Value, bnName)
+		}
+		addRpc(&bn.HttpPort, bn.OpenHttpPort.Value, bnName)
+	}
+
+	// Metrics
+	metrics := hd.Metrics
+	if metrics.EnableMetrics.Value {
+		add(&metrics.Grafana.Port, false, string(config.ContainerID_Grafana), "tcp")
+		add(&metrics.ExporterMetricsPort, false, string(config.ContainerID_Exporter), "tcp")
+		addRpc(&metrics.Prometheus.Port, metrics.Prometheus.OpenPort.Value, string(config.ContainerID_Prometheus))
+	}
+
+	// MEV-Boost
+	if hd.MevBoost.Enable.Value && hd.MevBoost.Mode.Value == config.ClientMode_Local {
+		addRpc(&hd.MevBoost.Port, hd.MevBoost.OpenRpcPort.Value, string(config.ContainerID_MevBoost))
+	}
+	return ports
+}
+
+// Find the host ports Hyperdrive needs that are already used by another process on the host, another Docker container, or another Hyperdrive setting.
+// Ports held by Hyperdrive's own running containers aren't conflicts, since they'll be released when the containers are recreated.
+func (c *HyperdriveClient) FindPortConflicts(cfg *GlobalConfig) ([]PortConflict, error) {
+	ports := cfg.GetHostPorts()
+	ownContainers, otherPorts, err := c.getDockerPortUsage(cfg.Hyperdrive.ProjectName.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []PortConflict{}
+	claimed := map[hostPortKey]HostPort{}
+	for _, port := range ports {
+		key := hostPortKey{port: port.Param.Value, protocol: port.Protocol}
+
+		// Settings that share a port with each other, other than a container's own TCP and UDP pair
+		if other, exists := claimed[key]; exists && other.Param != port.Param {
+			conflicts = append(conflicts, PortConflict{
+				Port:  port,
+				Owner: fmt.Sprintf("the %s setting (%s)", other.Param.Name, other.Path),
+			})
+			continue
+		}
+		claimed[key] = port
+
+		// Other Docker containers
+		if owner, exists := otherPorts[key]; exists {
+			conflicts = append(conflicts, PortConflict{
+				Port:  port,
+				Owner: owner,
+			})
+			continue
+		}
+
+		// Other processes on the host; skip this if the container that binds it is already running, since it's most likely the one holding it
+		if ownContainers[port.Container] {
+			continue
+		}
+		if !isHostPortFree(port.Param.Value, port.Protocol, port.Localhost) {
+			conflicts = append(conflicts, PortConflict{
+				Port:  port,
+				Owner: "another process on this machine",
+			})
+		}
+	}
+	return conflicts, nil
+}
+
+// Move each conflicting port setting to the next free port above it. The settings aren't saved.
+func (c *HyperdriveClient) ReassignConflictingPorts(cfg *GlobalConfig, conflicts []PortConflict) ([]PortReassignment, error) {
+	_, otherPorts, err := c.getDockerPortUsage(cfg.Hyperdrive.ProjectName.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get all of the ports that are spoken for, so none of them are picked
+	reserved := map[uint16]bool{}
+	for _, port := range cfg.GetHostPorts() {
+		reserved[port.Param.Value] = true
+	}
+	for key := range otherPorts {
+		reserved[key.port] = true
+	}
+
+	reassignments := []PortReassignment{}
+	moved := map[*config.Parameter[uint16]]bool{}
+	for _, conflict := range conflicts {
+		param := conflict.Port.Param
+		if moved[param] {
+			continue
+		}
+
+		// A setting can bind both TCP and UDP, so the new port has to be free for all of them
+		protocols := []HostPort{}
+		for _, port := range cfg.GetHostPorts() {
+			if port.Param == param {
+				protocols = append(protocols, port)
+			}
+		}
+
+		oldPort := param.Value
+		newPort := uint16(0)
+		for candidate := oldPort + 1; candidate > oldPort; candidate++ { // Stops when it wraps around past 65535
+			if reserved[candidate] {
+				continue
+			}
+			free := true
+			for _, port := range protocols {
+				if !isHostPortFree(candidate, port.Protocol, port.Localhost) {
+					free = false
+					break
+				}
+			}
+			if free {
+				newPort = candidate
+				break
+			}
+		}
+		if newPort == 0 {
+			return nil, fmt.Errorf("couldn't find a free port for the %s setting (%s)", param.Name, conflict.Port.Path)
+		}
+
+		param.Value = newPort
+		reserved[newPort] = true
+		moved[param] = true
+		reassignments = append(reassignments, PortReassignment{
+			Port:    conflict.Port,
+			OldPort: oldPort,
+		})
+	}
+	return reassignments, nil
+}
+
+// Get the names of the project's running containers, and the host ports published by every other running container along with a description of who owns them
+func (c *HyperdriveClient) getDockerPortUsage(projectName string) (map[string]bool, map[hostPortKey]string, error) {
+	d, err := c.GetDocker()
+	if err != nil {
+		return nil, nil, err
+	}
+	cl, err := d.ContainerList(context.Background(), dtc.ListOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting container list: %w", err)
+	}
+
+	ownContainers := map[string]bool{}
+	otherPorts := map[hostPortKey]string{}
+	for _, container := range cl {
+		name := ""
+		if len(container.Names) > 0 {
+			name = strings.TrimPrefix(container.Names[0], "/") // Docker throws a leading / on names
+		}
+		project := container.Labels[composeProjectLabel]
+		if project == projectName || strings.HasPrefix(name, projectName+"_") {
+			ownContainers[name] = true
+			continue
+		}
+
+		owner := fmt.Sprintf("the Docker container [%s]", name)
+		if project != "" {
+			owner = fmt.Sprintf("the Docker container [%s] from the [%s] Compose project", name, project)
+		}
+		for _, port := range container.Ports {
+			if port.PublicPort != 0 {
+				otherPorts[hostPortKey{port: port.PublicPort, protocol: port.Type}] = owner
+			}
+		}
+	}
+	return ownContainers, otherPorts, nil
+}
+
+// Check if a port can be bound on the host. Ports that can't be checked, such as privileged ports when not running as root, are treated as free.
+func isHostPortFree(port uint16, protocol string, localhost bool) bool {
+	address := fmt.Sprintf(":%d", port)
+	if localhost {
+		address = fmt.Sprintf("127.0.0.1:%d", port)
+	}
+
+	var err error
+	switch protocol {
+	case "udp":
+		var conn net.PacketConn
+		conn, err = net.ListenPacket("udp", address)
+		if err == nil {
+			_ = conn.Close()
+		}
+	default:
+		var listener net.Listener
+		listener, err = net.Listen("tcp", address)
+		if err == nil {
+			_ = listener.Close()
+		}
+	}
+	return !errors.Is(err, syscall.EADDRINUSE)
+}
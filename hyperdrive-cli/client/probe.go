@@ -0,0 +1,304 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// The timeout for each request made while probing a client
+	probeRequestTimeout time.Duration = 5 * time.Second
+)
+
+// The results of probing an external client's API
+type ClientProbe struct {
+	// The URL that was probed
+	Url string
+
+	// True if the client responded
+	Reachable bool
+
+	// The client's self-reported name and version
+	Identity string
+
+	// True if the client is on the expected network
+	CorrectNetwork bool
+
+	// A description of the network the client is on
+	NetworkInfo string
+
+	// True if the client is still syncing
+	Syncing bool
+
+	// A description of the client's sync progress
+	SyncInfo string
+
+	// Problems found while probing the client
+	Problems []string
+}
+
+// Check if the probe found the client to be usable
+func (p *ClientProbe) IsOk() bool {
+	return p.Reachable && p.CorrectNetwork && len(p.Problems) == 0
+}
+
+// Get a one-line summary of the probe results
+func (p *ClientProbe) Summary() string {
+	if !p.Reachable {
+		return fmt.Sprintf("%s is unreachable: %s", p.Url, strings.Join(p.Problems, "; "))
+	}
+	parts := []string{}
+	if p.Identity != "" {
+		parts = append(parts, p.Identity)
+	}
+	if p.NetworkInfo != "" {
+		parts = append(parts, p.NetworkInfo)
+	}
+	if p.SyncInfo != "" {
+		parts = append(parts, p.SyncInfo)
+	}
+	parts = append(parts, p.Problems...)
+	return strings.Join(parts, ", ")
+}
+
+// Probe an Execution client's HTTP JSON-RPC API, checking its chain ID against the expected one
+func ProbeExecutionClient(ctx context.Context, rpcUrl string, expectedChainID uint) *ClientProbe {
+	probe := &ClientProbe{
+		Url: rpcUrl,
+	}
+
+	// Chain ID
+	var chainIDHex string
+	err := callJsonRpc(ctx, rpcUrl, "eth_chainId", &chainIDHex)
+	if err != nil {
+		probe.Problems = append(probe.Problems, err.Error())
+		return probe
+	}
+	probe.Reachable = true
+	chainID, err := strconv.ParseUint(strings.TrimPrefix(chainIDHex, "0x"), 16, 64)
+	if err != nil {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("invalid chain ID [%s]", chainIDHex))
+	} else {
+		probe.CorrectNetwork = (chainID == uint64(expectedChainID))
+		if probe.CorrectNetwork {
+			probe.NetworkInfo = fmt.Sprintf("chain ID %d", chainID)
+		} else {
+			probe.NetworkInfo = fmt.Sprintf("WRONG chain ID %d (expected %d)", chainID, expectedChainID)
+		}
+	}
+
+	// Identity
+	var clientVersion string
+	err = callJsonRpc(ctx, rpcUrl, "web3_clientVersion", &clientVersion)
+	if err == nil {
+		probe.Identity = clientVersion
+	}
+
+	// Sync status - eth_syncing returns false when synced, or an object with progress while syncing
+	var syncing json.RawMessage
+	err = callJsonRpc(ctx, rpcUrl, "eth_syncing", &syncing)
+	if err != nil {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("couldn't get sync status: %s", err.Error()))
+	} else if string(syncing) == "false" {
+		probe.SyncInfo = "synced"
+	} else {
+		probe.Syncing = true
+		var progress struct {
+			CurrentBlock string `json:"currentBlock"`
+			HighestBlock string `json:"highestBlock"`
+		}
+		_ = json.Unmarshal(syncing, &progress)
+		current, currentErr := strconv.ParseUint(strings.TrimPrefix(progress.CurrentBlock, "0x"), 16, 64)
+		highest, highestErr := strconv.ParseUint(strings.TrimPrefix(progress.HighestBlock, "0x"), 16, 64)
+		if currentErr == nil && highestErr == nil && highest > 0 {
+			probe.SyncInfo = fmt.Sprintf("syncing (%.2f%%)", float64(current)*100/float64(highest))
+		} else {
+			probe.SyncInfo = "syncing"
+		}
+	}
+	return probe
+}
+
+// Probe a Beacon Node's HTTP API, checking its genesis fork version against the expected one
+func ProbeBeaconNode(ctx context.Context, apiUrl string, expectedForkVersion []byte) *ClientProbe {
+	probe := &ClientProbe{
+		Url: apiUrl,
+	}
+	apiUrl = strings.TrimRight(apiUrl, "/")
+
+	// Genesis fork version
+	var genesis struct {
+		Data struct {
+			GenesisForkVersion string `json:"genesis_fork_version"`
+		} `json:"data"`
+	}
+	err := getJson(ctx, apiUrl+"/eth/v1/beacon/genesis", &genesis)
+	if err != nil {
+		probe.Problems = append(probe.Problems, err.Error())
+		return probe
+	}
+	probe.Reachable = true
+	expected := "0x" + hex.EncodeToString(expectedForkVersion)
+	probe.CorrectNetwork = strings.EqualFold(genesis.Data.GenesisForkVersion, expected)
+	if probe.CorrectNetwork {
+		probe.NetworkInfo = fmt.Sprintf("genesis fork %s", genesis.Data.GenesisForkVersion)
+	} else {
+		probe.NetworkInfo = fmt.Sprintf("WRONG genesis fork %s (expected %s)", genesis.Data.GenesisForkVersion, expected)
+	}
+
+	// Identity
+	var version struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	err = getJson(ctx, apiUrl+"/eth/v1/node/version", &version)
+	if err == nil {
+		probe.Identity = version.Data.Version
+	}
+
+	// Sync status
+	var syncing struct {
+		Data struct {
+			IsSyncing    bool   `json:"is_syncing"`
+			SyncDistance string `json:"sync_distance"`
+			IsOptimistic bool   `json:"is_optimistic"`
+			ElOffline    bool   `json:"el_offline"`
+		} `json:"data"`
+	}
+	err = getJson(ctx, apiUrl+"/eth/v1/node/syncing", &syncing)
+	if err != nil {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("couldn't get sync status: %s", err.Error()))
+		return probe
+	}
+	probe.Syncing = syncing.Data.IsSyncing
+	if syncing.Data.IsSyncing {
+		probe.SyncInfo = fmt.Sprintf("syncing (%s slots behind)", syncing.Data.SyncDistance)
+	} else {
+		probe.SyncInfo = "synced"
+	}
+	if syncing.Data.ElOffline {
+		probe.Problems = append(probe.Problems, "its Execution client is offline")
+	}
+	return probe
+}
+
+// Check that a TCP connection can be made to the host of the provided URL, for endpoints that can't be probed over HTTP (such as websockets or gRPC)
+func ProbeTcpEndpoint(ctx context.Context, endpoint string) *ClientProbe {
+	probe := &ClientProbe{
+		Url:            endpoint,
+		CorrectNetwork: true,
+	}
+
+	// gRPC endpoints are usually written without a scheme
+	host := endpoint
+	parsedUrl, err := url.Parse(endpoint)
+	if err == nil && parsedUrl.Host != "" {
+		host = parsedUrl.Host
+		if parsedUrl.Port() == "" {
+			switch parsedUrl.Scheme {
+			case "https", "wss":
+				host = net.JoinHostPort(parsedUrl.Hostname(), "443")
+			default:
+				host = net.JoinHostPort(parsedUrl.Hostname(), "80")
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeRequestTimeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		probe.Problems = append(probe.Problems, fmt.Sprintf("couldn't connect: %s", err.Error()))
+		return probe
+	}
+	_ = conn.Close()
+	probe.Reachable = true
+	probe.NetworkInfo = "accepting connections"
+	return probe
+}
+
+// Call a JSON-RPC method with no parameters and unmarshal its result
+func callJsonRpc(ctx context.Context, rpcUrl string, method string, result any) error {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","method":"%s","params":[],"id":1}`, method)
+	responseBody, err := sendProbeRequest(ctx, http.MethodPost, rpcUrl, []byte(body))
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(responseBody, &response)
+	if err != nil {
+		return fmt.Errorf("error parsing %s response: %w", method, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s failed: %s", method, response.Error.Message)
+	}
+	err = json.Unmarshal(response.Result, result)
+	if err != nil {
+		return fmt.Errorf("error parsing %s result: %w", method, err)
+	}
+	return nil
+}
+
+// Send a GET request and unmarshal the JSON response
+func getJson(ctx context.Context, url string, result any) error {
+	responseBody, err := sendProbeRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(responseBody, result)
+	if err != nil {
+		return fmt.Errorf("error parsing response from [%s]: %w", url, err)
+	}
+	return nil
+}
+
+// Send a request with the probe timeout, returning the response body if it succeeded
+func sendProbeRequest(ctx context.Context, method string, url string, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeRequestTimeout)
+	defer cancel()
+
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't connect: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with code %d", response.StatusCode)
+	}
+	return responseBody, nil
+}
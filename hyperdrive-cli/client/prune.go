@@ -0,0 +1,256 @@
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The path of the client data inside of the EC and BN containers
+	ClientDataMountPath string = "/ethclient"
+
+	// The name of the container used to flag the EC volume for offline pruning
+	pruneProvisionerContainerSuffix string = "prune_provisioner"
+
+	// The file listing the system's mount points
+	procMountsPath string = "/proc/mounts"
+)
+
+// The way an Execution client prunes its database
+type EcPruneMechanism string
+
+const (
+	// The client can't be pruned manually
+	EcPruneMechanism_None EcPruneMechanism = ""
+
+	// The client must be shut down and restarted with a prune flag (Geth, Besu)
+	EcPruneMechanism_Offline EcPruneMechanism = "offline"
+
+	// The client prunes itself while running when requested via its admin RPC (Nethermind)
+	EcPruneMechanism_Online EcPruneMechanism = "online"
+)
+
+// Get the pruning mechanism used by an Execution client
+func GetEcPruneMechanism(client config.ExecutionClient) EcPruneMechanism {
+	switch client {
+	case config.ExecutionClient_Geth, config.ExecutionClient_Besu:
+		return EcPruneMechanism_Offline
+	case config.ExecutionClient_Nethermind:
+		return EcPruneMechanism_Online
+	default:
+		return EcPruneMechanism_None
+	}
+}
+
+// A snapshot of an Execution client's pruning progress
+type EcPruneProgress struct {
+	// True if the client has finished pruning and restarted normally
+	Complete bool
+
+	// The latest pruning-related line the client logged, if any
+	LatestMessage string
+
+	// The free space on the EC volume, in bytes
+	FreeSpace uint64
+}
+
+// Manages pruning of the local Execution client
+type EcPruneManager struct {
+	// The selected Execution client
+	Client config.ExecutionClient
+
+	// How the selected client prunes
+	Mechanism EcPruneMechanism
+
+	// The name of the EC container
+	ContainerName string
+
+	// The name of the EC data volume
+	VolumeName string
+
+	hd             *HyperdriveClient
+	cfg            *GlobalConfig
+	startTime      time.Time
+	containerStart time.Time
+}
+
+// Create a new prune manager for the local Execution client
+func NewEcPruneManager(hd *HyperdriveClient, cfg *GlobalConfig) (*EcPruneManager, error) {
+	if !cfg.Hyperdrive.IsLocalMode() {
+		return nil, fmt.Errorf("Hyperdrive is using an externally-managed Execution client, so it cannot prune it")
+	}
+
+	client := cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(config.ContainerID_ExecutionClient))
+	volumeName, err := hd.GetClientVolumeName(containerName, ClientDataMountPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Execution client volume name: %w", err)
+	}
+
+	return &EcPruneManager{
+		Client:        client,
+		Mechanism:     GetEcPruneMechanism(client),
+		ContainerName: containerName,
+		VolumeName:    volumeName,
+		hd:            hd,
+		cfg:           cfg,
+	}, nil
+}
+
+// Get the free space on the disk holding the EC volume, in bytes
+func (m *EcPruneManager) GetFreeSpace() (uint64, error) {
+	volumePath, err := m.hd.GetClientVolumeSource(m.ContainerName, ClientDataMountPath)
+	if err != nil {
+		return 0, fmt.Errorf("error getting Execution client volume path: %w", err)
+	}
+	return GetPathFreeSpace(volumePath)
+}
+
+// Check if the free space on the EC volume has dropped below the configured auto-prune threshold.
+// Returns false if the threshold is disabled.
+func (m *EcPruneManager) IsBelowThreshold() (bool, uint64, error) {
+	threshold := m.cfg.Cli.EcPruning.FreeSpaceThreshold.Value * 1024 * 1024 * 1024
+	freeSpace, err := m.GetFreeSpace()
+	if err != nil {
+		return false, 0, err
+	}
+	if threshold == 0 {
+		return false, freeSpace, nil
+	}
+	return freeSpace < threshold, freeSpace, nil
+}
+
+// Start pruning the Execution client
+func (m *EcPruneManager) Start() error {
+	m.startTime = time.Now()
+	switch m.Mechanism {
+	case EcPruneMechanism_Offline:
+		// Stop the EC, flag the volume for pruning, and start it again so it runs the prune
+		err := m.hd.StopContainer(m.ContainerName)
+		if err != nil {
+			return fmt.Errorf("error stopping Execution client: %w", err)
+		}
+		provisionerName := m.cfg.Hyperdrive.GetDockerArtifactName(pruneProvisionerContainerSuffix)
+		err = m.hd.RunPruneProvisioner(provisionerName, m.VolumeName, m.cfg.Cli.EcPruning.ProvisionerTag.Value)
+		if err != nil {
+			return fmt.Errorf("error running prune provisioner: %w", err)
+		}
+		err = m.hd.StartContainer(m.ContainerName)
+		if err != nil {
+			return fmt.Errorf("error starting Execution client: %w", err)
+		}
+
+	case EcPruneMechanism_Online:
+		status, err := m.hd.RunNethermindPruneStarter(m.ContainerName)
+		if err != nil {
+			return fmt.Errorf("error starting Nethermind pruning: %w", err)
+		}
+		if status != nethermindPruneStatusStarting && status != nethermindPruneStatusInProgress {
+			return fmt.Errorf("Nethermind refused to start pruning (status: %s)", status)
+		}
+
+	default:
+		return fmt.Errorf("%s cannot be pruned manually", m.Client)
+	}
+
+	// Record when the container was started so a restart after pruning can be detected
+	startTime, err := m.hd.GetDockerContainerStartTime(m.ContainerName)
+	if err != nil {
+		return fmt.Errorf("error getting Execution client start time: %w", err)
+	}
+	m.containerStart = startTime
+	return nil
+}
+
+// Poll the Execution client for the progress of a prune started with Start().
+// Both mechanisms end with the client restarting (the offline prune exits when done and Nethermind is configured to shut down after full pruning),
+// so a new container start time means the prune is finished.
+func (m *EcPruneManager) GetProgress() (*EcPruneProgress, error) {
+	progress := &EcPruneProgress{}
+
+	startTime, err := m.hd.GetDockerContainerStartTime(m.ContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Execution client start time: %w", err)
+	}
+	progress.Complete = startTime.After(m.containerStart)
+
+	lines, err := m.hd.GetContainerLogs(m.ContainerName, m.startTime)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(lines) - 1; i >= 0; i-- {
+		lower := strings.ToLower(lines[i])
+		if strings.Contains(lower, "prun") || strings.Contains(lower, "compact") {
+			progress.LatestMessage = strings.TrimSpace(lines[i])
+			break
+		}
+	}
+
+	progress.FreeSpace, err = m.GetFreeSpace()
+	if err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// Get the free space on the filesystem containing the provided path, in bytes.
+// Docker volume paths often aren't readable by normal users, so this falls back to the mount point the path lives on.
+func GetPathFreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err == nil {
+		return stat.Bavail * uint64(stat.Bsize), nil
+	}
+
+	mountPoint, mountErr := getMountPoint(path)
+	if mountErr != nil {
+		return 0, fmt.Errorf("error getting free space of [%s]: %w (and couldn't find its mount point: %s)", path, err, mountErr.Error())
+	}
+	err = syscall.Statfs(mountPoint, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("error getting free space of mount point [%s]: %w", mountPoint, err)
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// Get the mount point with the longest prefix matching the provided path
+func getMountPoint(path string) (string, error) {
+	file, err := os.Open(procMountsPath)
+	if err != nil {
+		return "", fmt.Errorf("error opening [%s]: %w", procMountsPath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	path = filepath.Clean(path)
+	bestMatch := ""
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		mountPoint := fields[1]
+		if mountPoint != "/" && path != mountPoint && !strings.HasPrefix(path, mountPoint+"/") {
+			continue
+		}
+		if len(mountPoint) > len(bestMatch) {
+			bestMatch = mountPoint
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading [%s]: %w", procMountsPath, err)
+	}
+	if bestMatch == "" {
+		return "", fmt.Errorf("no mount point found")
+	}
+	return bestMatch, nil
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/pbnjay/memory"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The range of values the kernel accepts for a process's OOM score adjustment
+	minOomScoreAdj int64 = -1000
+	maxOomScoreAdj int64 = 1000
+)
+
+// Check if the container has a CPU or memory limit or reservation, so the templates know whether to add a deploy section.
+// Used by text/template.
+func (cfg *ContainerResourcesConfig) HasDeployResources() bool {
+	return cfg.CpuLimit.Value > 0 || cfg.MemoryLimit.Value > 0 || cfg.MemoryReservation.Value > 0
+}
+
+// Get the container's limits and reservations as a flow-style docker compose resources section.
+// Used by text/template.
+func (cfg *ContainerResourcesConfig) GetDeployResources() string {
+	limits := []string{}
+	if cfg.CpuLimit.Value > 0 {
+		limits = append(limits, fmt.Sprintf("cpus: \"%s\"", strconv.FormatFloat(cfg.CpuLimit.Value, 'f', -1, 64)))
+	}
+	if cfg.MemoryLimit.Value > 0 {
+		limits = append(limits, fmt.Sprintf("memory: %dM", cfg.MemoryLimit.Value))
+	}
+
+	sections := []string{}
+	if len(limits) > 0 {
+		sections = append(sections, fmt.Sprintf("limits: { %s }", strings.Join(limits, ", ")))
+	}
+	if cfg.MemoryReservation.Value > 0 {
+		sections = append(sections, fmt.Sprintf("reservations: { memory: %dM }", cfg.MemoryReservation.Value))
+	}
+	return fmt.Sprintf("{ %s }", strings.Join(sections, ", "))
+}
+
+// Get the resource settings of each container
+func (c *GlobalConfig) getContainerResources() []*ContainerResourcesConfig {
+	return []*ContainerResourcesConfig{
+		c.Cli.Resources.ExecutionClient,
+		c.Cli.Resources.BeaconNode,
+		c.Cli.Resources.MevBoost,
+		c.Cli.Resources.StakeWiseValidator,
+		c.Cli.Resources.ConstellationValidator,
+	}
+}
+
+// Get the resource settings of the containers that the current settings will run
+func (c *GlobalConfig) getActiveContainerResources() []*ContainerResourcesConfig {
+	resources := []*ContainerResourcesConfig{}
+	if c.Hyperdrive.IsLocalMode() {
+		resources = append(resources, c.Cli.Resources.ExecutionClient, c.Cli.Resources.BeaconNode)
+	}
+	if c.Hyperdrive.MevBoost.Enable.Value && c.Hyperdrive.MevBoost.Mode.Value == config.ClientMode_Local {
+		resources = append(resources, c.Cli.Resources.MevBoost)
+	}
+	if c.StakeWise.Enabled.Value {
+		resources = append(resources, c.Cli.Resources.StakeWiseValidator)
+	}
+	if c.Constellation.Enabled.Value {
+		resources = append(resources, c.Cli.Resources.ConstellationValidator)
+	}
+	return resources
+}
+
+// Get the errors in the containers' resource settings that would keep them from starting
+func (c *GlobalConfig) validateContainerResources() []string {
+	errors := []string{}
+	for _, resources := range c.getContainerResources() {
+		title := resources.GetTitle()
+		if resources.CpuLimit.Value < 0 {
+			errors = append(errors, fmt.Sprintf("%s in %s cannot be negative.", resources.CpuLimit.Name, title))
+		}
+		if resources.MemoryLimit.Value > 0 && resources.MemoryReservation.Value > resources.MemoryLimit.Value {
+			errors = append(errors, fmt.Sprintf("%s in %s must be lower than its %s.", resources.MemoryReservation.Name, title, resources.MemoryLimit.Name))
+		}
+		if resources.OomScoreAdj.Value < minOomScoreAdj || resources.OomScoreAdj.Value > maxOomScoreAdj {
+			errors = append(errors, fmt.Sprintf("%s in %s must be between %d and %d.", resources.OomScoreAdj.Name, title, minOomScoreAdj, maxOomScoreAdj))
+		}
+		if resources.OomScoreAdj.Value < 0 && c.Cli.ContainerRuntime.Runtime.Value.IsRootless() {
+			errors = append(errors, fmt.Sprintf("%s in %s can't be negative with %s.", resources.OomScoreAdj.Name, title, c.Cli.ContainerRuntime.Runtime.Value))
+		}
+	}
+	return errors
+}
+
+// Check the resource settings of the containers that will run against this machine's RAM and CPU cores.
+// Returns a warning for each way they add up to more than the machine has.
+func (c *GlobalConfig) CheckResourceCapacity() []string {
+	totalMemoryMB := memory.TotalMemory() / 1024 / 1024
+	cpuCores := runtime.NumCPU()
+	warnings := []string{}
+	var memoryLimits, memoryReservations uint64
+	var cpuLimits float64
+	for _, resources := range c.getActiveContainerResources() {
+		title := resources.GetTitle()
+		memoryLimits += resources.MemoryLimit.Value
+		memoryReservations += resources.MemoryReservation.Value
+		cpuLimits += resources.CpuLimit.Value
+		if resources.CpuLimit.Value > float64(cpuCores) {
+			warnings = append(warnings, fmt.Sprintf("%s in %s is %s, but this machine only has %d CPU cores, so the container won't start.", resources.CpuLimit.Name, title, resources.CpuLimit.String(), cpuCores))
+		}
+	}
+
+	if totalMemoryMB > 0 {
+		if memoryLimits > totalMemoryMB {
+			warnings = append(warnings, fmt.Sprintf("The containers' memory limits add up to %d MB, but this machine only has %d MB of RAM, so they can't keep it from running out of memory.", memoryLimits, totalMemoryMB))
+		}
+		if memoryReservations > totalMemoryMB {
+			warnings = append(warnings, fmt.Sprintf("The containers' memory reservations add up to %d MB, but this machine only has %d MB of RAM, so they can't all be honored.", memoryReservations, totalMemoryMB))
+		}
+	}
+	if cpuLimits > float64(cpuCores) {
+		warnings = append(warnings, fmt.Sprintf("The containers' CPU limits add up to %s cores, but this machine only has %d, so they'll still compete for CPU time when they're all busy.", strconv.FormatFloat(cpuLimits, 'f', -1, 64), cpuCores))
+	}
+	return warnings
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+)
+
+// A container runtime that Hyperdrive can run its containers with
+type ContainerRuntime string
+
+const (
+	// The standard Docker daemon running as root
+	ContainerRuntime_Docker ContainerRuntime = "docker"
+
+	// Docker running as the current user
+	ContainerRuntime_DockerRootless ContainerRuntime = "docker-rootless"
+
+	// Podman running as the current user
+	ContainerRuntime_Podman ContainerRuntime = "podman"
+)
+
+const (
+	// The socket the root Docker daemon listens on
+	rootDockerSocket string = "/var/run/docker.sock"
+
+	// The socket Podman's API service listens on when it runs as root
+	rootPodmanSocket string = "/run/podman/podman.sock"
+)
+
+// Check if the runtime runs the containers as the current user instead of as root
+func (r ContainerRuntime) IsRootless() bool {
+	return r == ContainerRuntime_DockerRootless || r == ContainerRuntime_Podman
+}
+
+// Get the path of the container runtime's API socket, finding it automatically if one wasn't set.
+// Used by text/template to mount the socket into the daemons.
+func (c *GlobalConfig) GetContainerSocketPath() string {
+	path := c.Cli.ContainerRuntime.SocketPath.Value
+	if path != "" {
+		return path
+	}
+	return findContainerSocket(c.Cli.ContainerRuntime.Runtime.Value)
+}
+
+// Get the URL of the container runtime's API
+func (c *GlobalConfig) GetContainerHost() string {
+	return "unix://" + c.GetContainerSocketPath()
+}
+
+// Get the container CLI command for the configured runtime, pointed at its socket
+func (c *GlobalConfig) getContainerCommand() string {
+	socket := c.GetContainerSocketPath()
+	if c.Cli.ContainerRuntime.Runtime.Value == ContainerRuntime_Podman {
+		// Podman talks to its storage directly unless it's given a specific service to use
+		if c.Cli.ContainerRuntime.SocketPath.Value == "" {
+			return "podman"
+		}
+		return fmt.Sprintf("podman --url %s", shellescape.Quote("unix://"+socket))
+	}
+	if socket == rootDockerSocket {
+		return "docker"
+	}
+	return fmt.Sprintf("DOCKER_HOST=%s docker", shellescape.Quote("unix://"+socket))
+}
+
+// Get the command that deletes a folder the containers may have written to.
+// This only escalates to root for the standard Docker daemon; the rootless runtimes remove the files inside the user's own namespace instead.
+func (c *GlobalConfig) getRemoveCommand(path string) (string, error) {
+	quotedPath := shellescape.Quote(path)
+	switch c.Cli.ContainerRuntime.Runtime.Value {
+	case ContainerRuntime_DockerRootless:
+		// Files made by non-root users in the containers belong to the user's subordinate IDs, which rootlesskit maps back
+		exists, err := checkIfCommandExists("rootlesskit")
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return fmt.Sprintf("rootlesskit rm -rf %s", quotedPath), nil
+		}
+		return fmt.Sprintf("rm -rf %s", quotedPath), nil
+
+	case ContainerRuntime_Podman:
+		if os.Geteuid() == 0 {
+			return fmt.Sprintf("rm -rf %s", quotedPath), nil
+		}
+		return fmt.Sprintf("podman unshare rm -rf %s", quotedPath), nil
+
+	default:
+		rootCmd, err := getEscalationCommand()
+		if err != nil {
+			return "", fmt.Errorf("could not get privilege escalation command: %w", err)
+		}
+		return fmt.Sprintf("%s rm -rf %s", rootCmd, quotedPath), nil
+	}
+}
+
+// Make sure the container runtime's socket exists so commands fail with a helpful error if the runtime isn't running
+func (c *GlobalConfig) checkContainerSocket() error {
+	socket := c.GetContainerSocketPath()
+	_, err := os.Stat(socket)
+	if err == nil {
+		return nil
+	}
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the %s socket wasn't found at [%s]; make sure it's running, or set the path of its socket in the Container Runtime section of `hyperdrive service config`", c.Cli.ContainerRuntime.Runtime.Value, socket)
+	}
+	return fmt.Errorf("error checking the %s socket at [%s]: %w", c.Cli.ContainerRuntime.Runtime.Value, socket, err)
+}
+
+// Find the API socket for a container runtime, preferring DOCKER_HOST if it points to one
+func findContainerSocket(runtime ContainerRuntime) string {
+	host, isUnix := strings.CutPrefix(os.Getenv("DOCKER_HOST"), "unix://")
+	if isUnix && host != "" {
+		return host
+	}
+
+	switch runtime {
+	case ContainerRuntime_DockerRootless:
+		return filepath.Join(getUserRuntimeDir(), "docker.sock")
+	case ContainerRuntime_Podman:
+		if os.Geteuid() == 0 {
+			return rootPodmanSocket
+		}
+		return filepath.Join(getUserRuntimeDir(), "podman", "podman.sock")
+	default:
+		return rootDockerSocket
+	}
+}
+
+// Get the current user's runtime folder, where the rootless runtimes put their sockets
+func getUserRuntimeDir() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir != "" {
+		return dir
+	}
+	return fmt.Sprintf("/run/user/%d", os.Getuid())
+}
+
+// Get the container CLI command for the configured runtime
+func (c *HyperdriveClient) getContainerCommand() (string, error) {
+	cfg, _, err := c.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("error loading user settings: %w", err)
+	}
+	return cfg.getContainerCommand(), nil
+}
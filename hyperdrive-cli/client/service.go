@@ -3,13 +3,13 @@ package client
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/alessio/shellescape"
@@ -19,14 +19,19 @@ import (
 )
 
 const (
-	debugColor                    color.Attribute = color.FgYellow
-	nethermindPruneStarterCommand string          = "DELETE_ME"
-	nethermindAdminUrl            string          = "http://127.0.0.1:7434"
+	debugColor                  color.Attribute = color.FgYellow
+	nethermindPruneStarterImage string          = "curlimages/curl:8.10.1"
+	nethermindAdminUrl          string          = "http://127.0.0.1:7434"
+	nethermindPruneRequest      string          = `{"jsonrpc":"2.0","method":"admin_prune","params":[],"id":1}`
+
+	nethermindPruneStatusStarting   string = "Starting"
+	nethermindPruneStatusInProgress string = "InProgress"
 
 	overrideDir        string = "override"
 	runtimeDir         string = "runtime"
 	metricsDir         string = "metrics"
 	extraScrapeJobsDir string = "extra-scrape-jobs"
+	customServicesDir  string = "custom-services"
 	modulePrometheusSd string = "prometheus-sd"
 )
 
@@ -207,10 +212,18 @@ func (c *HyperdriveClient) DownService(composeFiles []string, includeVolumes boo
 
 // Stop Hyperdrive and remove the config folder
 func (c *HyperdriveClient) TerminateService(composeFiles []string, configPath string) error {
-	// Get the command to run with root privileges
-	rootCmd, err := getEscalationCommand()
+	// Get the command to delete the Hyperdrive directory
+	path, err := homedir.Expand(configPath)
+	if err != nil {
+		return fmt.Errorf("error loading Hyperdrive directory: %w", err)
+	}
+	cfg, _, err := c.LoadConfig()
 	if err != nil {
-		return fmt.Errorf("could not get privilege escalation command: %w", err)
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	removeCmd, err := cfg.getRemoveCommand(path)
+	if err != nil {
+		return err
 	}
 
 	// Terminate the Docker containers
@@ -224,13 +237,8 @@ func (c *HyperdriveClient) TerminateService(composeFiles []string, configPath st
 	}
 
 	// Delete the Hyperdrive directory
-	path, err := homedir.Expand(configPath)
-	if err != nil {
-		return fmt.Errorf("error loading Hyperdrive directory: %w", err)
-	}
 	fmt.Printf("Deleting Hyperdrive directory (%s)...\n", path)
-	cmd = fmt.Sprintf("%s rm -rf %s", rootCmd, path)
-	_, err = readOutput(cmd)
+	_, err = readOutput(removeCmd)
 	if err != nil {
 		return fmt.Errorf("error deleting Hyperdrive directory: %w", err)
 	}
@@ -298,18 +306,22 @@ func (c *HyperdriveClient) GetServiceVersion() (string, error) {
 
 // Deletes the data directory, including the node wallet and all validator keys, and restarts the Docker containers if requested
 func (c *HyperdriveClient) PurgeData(composeFiles []string, restart bool) error {
-	// Get the command to run with root privileges
-	rootCmd, err := getEscalationCommand()
-	if err != nil {
-		return fmt.Errorf("could not get privilege escalation command: %w", err)
-	}
-
 	// Get the config
 	cfg, _, err := c.LoadConfig()
 	if err != nil {
 		return fmt.Errorf("error loading user settings: %w", err)
 	}
 
+	// Get the command to delete the user's data directory
+	dataPath, err := homedir.Expand(cfg.Hyperdrive.UserDataPath.Value)
+	if err != nil {
+		return fmt.Errorf("error loading data path: %w", err)
+	}
+	removeCmd, err := cfg.getRemoveCommand(dataPath)
+	if err != nil {
+		return err
+	}
+
 	// Shut down the containers
 	fmt.Println("Stopping containers...")
 	err = c.StopService(composeFiles)
@@ -318,13 +330,8 @@ func (c *HyperdriveClient) PurgeData(composeFiles []string, restart bool) error
 	}
 
 	// Delete the user's data directory
-	dataPath, err := homedir.Expand(cfg.Hyperdrive.UserDataPath.Value)
-	if err != nil {
-		return fmt.Errorf("error loading data path: %w", err)
-	}
 	fmt.Println("Deleting data...")
-	cmd := fmt.Sprintf("%s rm -rf %s", rootCmd, dataPath)
-	_, err = readOutput(cmd)
+	_, err = readOutput(removeCmd)
 	if err != nil {
 		return fmt.Errorf("error deleting data: %w", err)
 	}
@@ -345,7 +352,11 @@ func (c *HyperdriveClient) PurgeData(composeFiles []string, restart bool) error
 // Runs the prune provisioner
 func (c *HyperdriveClient) RunPruneProvisioner(container string, volume string, image string) error {
 	// Run the prune provisioner
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient %s", container, volume, image)
+	containerCmd, err := c.getContainerCommand()
+	if err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("%s run --rm --name %s -v %s:/ethclient %s", containerCmd, container, volume, image)
 	output, err := readOutput(cmd)
 	if err != nil {
 		return err
@@ -359,42 +370,33 @@ func (c *HyperdriveClient) RunPruneProvisioner(container string, volume string,
 	return nil
 }
 
-// Runs the prune provisioner
-func (c *HyperdriveClient) RunNethermindPruneStarter(container string) error {
-	cmd := fmt.Sprintf("docker exec %s %s %s", container, nethermindPruneStarterCommand, nethermindAdminUrl)
-	err := printOutput(cmd)
+// Asks Nethermind to start full pruning via its admin RPC, returning the pruning status it reports.
+// The admin endpoint only listens on localhost inside the container, so the request is sent from a container sharing its network namespace.
+func (c *HyperdriveClient) RunNethermindPruneStarter(container string) (string, error) {
+	containerCmd, err := c.getContainerCommand()
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
-}
-
-// Runs the EC migrator
-func (c *HyperdriveClient) RunEcMigrator(container string, volume string, targetDir string, mode string, image string) error {
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/ethclient -v %s:/mnt/external -e EC_MIGRATE_MODE='%s' %s", container, volume, targetDir, mode, image)
-	err := printOutput(cmd)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// Gets the size of the target directory via the EC migrator for importing, which should have the same permissions as exporting
-func (c *HyperdriveClient) GetDirSizeViaEcMigrator(container string, targetDir string, image string) (uint64, error) {
-	cmd := fmt.Sprintf("docker run --rm --name %s -v %s:/mnt/external -e OPERATION='size' %s", container, targetDir, image)
+	cmd := fmt.Sprintf("%s run --rm --network container:%s %s -s -X POST -H 'Content-Type: application/json' --data %s %s", containerCmd, shellescape.Quote(container), nethermindPruneStarterImage, shellescape.Quote(nethermindPruneRequest), nethermindAdminUrl)
 	output, err := readOutput(cmd)
 	if err != nil {
-		return 0, fmt.Errorf("error getting source directory size: %w", err)
+		return "", fmt.Errorf("error sending prune request: %w", err)
 	}
 
-	trimmedOutput := strings.TrimRight(string(output), "\n")
-	dirSize, err := strconv.ParseUint(trimmedOutput, 0, 64)
+	var response struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(output, &response)
 	if err != nil {
-		return 0, fmt.Errorf("error parsing directory size output [%s]: %w", trimmedOutput, err)
+		return "", fmt.Errorf("error parsing prune response [%s]: %w", strings.TrimSpace(string(output)), err)
 	}
-
-	return dirSize, nil
+	if response.Error != nil {
+		return "", fmt.Errorf("Nethermind returned an error: %s", response.Error.Message)
+	}
+	return response.Result, nil
 }
 
 // =================
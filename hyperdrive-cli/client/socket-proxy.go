@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The ID of the Docker socket proxy container
+	ContainerID_SocketProxy config.ContainerID = "socket-proxy"
+
+	// The volume the proxy's socket is shared with the daemons through
+	socketProxyVolume string = "socket-proxy"
+
+	// The optional API version prefix on Docker API paths
+	dockerApiVersionPattern string = `(/v[0-9.]+)?`
+)
+
+// Used by text/template to name the socket proxy container
+func (c *GlobalConfig) GetSocketProxyContainerName() string {
+	return string(ContainerID_SocketProxy)
+}
+
+// Used by text/template to mount the proxy's socket volume
+func (c *GlobalConfig) GetSocketProxyVolume() string {
+	return socketProxyVolume
+}
+
+// Used by text/template to get the Docker API paths the daemons can read through the proxy.
+// They can look up containers and check the API, but can't see anything else like images, volumes, or secrets.
+func (c *GlobalConfig) GetSocketProxyAllowedGets() string {
+	return escapeComposeVariables(fmt.Sprintf(`^%s/(_ping|version|containers/json|containers/[a-zA-Z0-9_.-]+/json)$`, dockerApiVersionPattern))
+}
+
+// Used by text/template to get the Docker API paths the daemons can post to through the proxy.
+// They can only restart or stop the containers in this project, which is what they need to manage the Validator Clients.
+func (c *GlobalConfig) GetSocketProxyAllowedPosts() string {
+	projectPrefix := regexp.QuoteMeta(c.Hyperdrive.ProjectName.Value + "_")
+	return escapeComposeVariables(fmt.Sprintf(`^%s/containers/%s[a-zA-Z0-9_.-]+/(restart|stop)$`, dockerApiVersionPattern, projectPrefix))
+}
+
+// Escape dollar signs so Docker Compose doesn't treat them as variables
+func escapeComposeVariables(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
@@ -0,0 +1,330 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+)
+
+const (
+	// The folder system-wide systemd units are installed to
+	SystemdUnitDir string = "/etc/systemd/system"
+
+	// The suffix for the health check service and timer names
+	systemdHealthSuffix string = "-health"
+
+	// The suffix for the auto-prune service and timer names
+	systemdPruneSuffix string = "-prune"
+)
+
+// The systemd units that run a Hyperdrive project
+type SystemdUnits struct {
+	// The name of the service that starts and stops the project, such as hyperdrive.service
+	Service string
+
+	// The name of the health check service and its timer
+	HealthService string
+	HealthTimer   string
+
+	// The name of the service that prunes the Execution client when its disk is low, and its timer
+	PruneService string
+	PruneTimer   string
+}
+
+// Get the names of the systemd units for a project
+func GetSystemdUnits(projectName string) SystemdUnits {
+	return SystemdUnits{
+		Service:       projectName + ".service",
+		HealthService: projectName + systemdHealthSuffix + ".service",
+		HealthTimer:   projectName + systemdHealthSuffix + ".timer",
+		PruneService:  projectName + systemdPruneSuffix + ".service",
+		PruneTimer:    projectName + systemdPruneSuffix + ".timer",
+	}
+}
+
+// Check if the service unit for a project is installed
+func IsSystemdUnitInstalled(projectName string) bool {
+	_, err := os.Stat(filepath.Join(SystemdUnitDir, GetSystemdUnits(projectName).Service))
+	return err == nil
+}
+
+// Install systemd units that start the project on boot as the current user and stop it cleanly on shutdown,
+// along with a daily timer that prunes the Execution client once its free space drops below the auto-prune threshold.
+// If healthCheckInterval isn't 0, a timer is installed that checks the project's containers on that interval.
+func (c *HyperdriveClient) InstallSystemdUnits(cfg *GlobalConfig, healthCheckInterval time.Duration) error {
+	units := GetSystemdUnits(cfg.Hyperdrive.ProjectName.Value)
+	currentUser, err := user.Current()
+	if err != nil {
+		return fmt.Errorf("error getting the current user: %w", err)
+	}
+	hyperdriveBin, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the path of the hyperdrive binary: %w", err)
+	}
+	userDir, err := filepath.Abs(c.Context.UserDirPath)
+	if err != nil {
+		return fmt.Errorf("error getting the absolute path of the user directory: %w", err)
+	}
+	hyperdriveCmd := fmt.Sprintf("%s --config-path %s", shellescape.Quote(hyperdriveBin), shellescape.Quote(userDir))
+	if currentUser.Uid == "0" {
+		hyperdriveCmd += " --allow-root"
+	}
+
+	// Make the unit files
+	files := map[string]string{
+		units.Service:      getSystemdServiceUnit(cfg.Hyperdrive.ProjectName.Value, cfg.Cli.ContainerRuntime.Runtime.Value, currentUser.Username, hyperdriveCmd),
+		units.PruneService: getSystemdPruneServiceUnit(cfg.Hyperdrive.ProjectName.Value, units.Service, currentUser.Username, hyperdriveCmd),
+		units.PruneTimer:   getSystemdPruneTimerUnit(cfg.Hyperdrive.ProjectName.Value, units.Service),
+	}
+	if healthCheckInterval > 0 {
+		files[units.HealthService] = getSystemdHealthServiceUnit(cfg.Hyperdrive.ProjectName.Value, units.Service, currentUser.Username, hyperdriveCmd)
+		files[units.HealthTimer] = getSystemdHealthTimerUnit(cfg.Hyperdrive.ProjectName.Value, units.Service, healthCheckInterval)
+	}
+
+	// Get the command to run with root privileges
+	rootCmd, err := getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	// Write each file to a temp file, then copy it into place as root
+	tempDir, err := os.MkdirTemp("", "hyperdrive-systemd-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary folder for the systemd units: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tempDir)
+	}()
+	for name, contents := range files {
+		tempPath := filepath.Join(tempDir, name)
+		err = os.WriteFile(tempPath, []byte(contents), 0644)
+		if err != nil {
+			return fmt.Errorf("error writing systemd unit [%s]: %w", name, err)
+		}
+		err = printOutput(fmt.Sprintf("%s install -m 0644 %s %s", rootCmd, shellescape.Quote(tempPath), shellescape.Quote(filepath.Join(SystemdUnitDir, name))))
+		if err != nil {
+			return fmt.Errorf("error installing systemd unit [%s]: %w", name, err)
+		}
+	}
+
+	// Remove the health check if it was installed before but isn't wanted anymore
+	if healthCheckInterval == 0 {
+		err = removeSystemdUnits(rootCmd, units.HealthTimer, units.HealthService)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Enable them
+	err = printOutput(fmt.Sprintf("%s systemctl daemon-reload", rootCmd))
+	if err != nil {
+		return fmt.Errorf("error reloading systemd: %w", err)
+	}
+	err = printOutput(fmt.Sprintf("%s systemctl enable %s", rootCmd, units.Service))
+	if err != nil {
+		return fmt.Errorf("error enabling [%s]: %w", units.Service, err)
+	}
+	err = printOutput(fmt.Sprintf("%s systemctl enable --now %s", rootCmd, units.PruneTimer))
+	if err != nil {
+		return fmt.Errorf("error enabling [%s]: %w", units.PruneTimer, err)
+	}
+	if healthCheckInterval > 0 {
+		err = printOutput(fmt.Sprintf("%s systemctl enable --now %s", rootCmd, units.HealthTimer))
+		if err != nil {
+			return fmt.Errorf("error enabling [%s]: %w", units.HealthTimer, err)
+		}
+	}
+	return nil
+}
+
+// Remove the systemd units for the project. This doesn't stop the project.
+func (c *HyperdriveClient) UninstallSystemdUnits(cfg *GlobalConfig) error {
+	units := GetSystemdUnits(cfg.Hyperdrive.ProjectName.Value)
+	rootCmd, err := getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	err = removeSystemdUnits(rootCmd, units.HealthTimer, units.HealthService, units.PruneTimer, units.PruneService, units.Service)
+	if err != nil {
+		return err
+	}
+	err = printOutput(fmt.Sprintf("%s systemctl daemon-reload", rootCmd))
+	if err != nil {
+		return fmt.Errorf("error reloading systemd: %w", err)
+	}
+	return nil
+}
+
+// Print the status of the project's systemd units
+func (c *HyperdriveClient) PrintSystemdStatus(cfg *GlobalConfig) error {
+	units := GetSystemdUnits(cfg.Hyperdrive.ProjectName.Value)
+	names := []string{units.Service}
+	_, err := os.Stat(filepath.Join(SystemdUnitDir, units.PruneTimer))
+	if err == nil {
+		names = append(names, units.PruneTimer, units.PruneService)
+	}
+	_, err = os.Stat(filepath.Join(SystemdUnitDir, units.HealthTimer))
+	if err == nil {
+		names = append(names, units.HealthTimer, units.HealthService)
+	}
+
+	// systemctl status exits with an error if a unit isn't running, which isn't a problem here
+	_ = printOutput(fmt.Sprintf("systemctl status --no-pager %s", strings.Join(names, " ")))
+	return nil
+}
+
+// Check if the project's service unit is running
+func IsSystemdUnitActive(projectName string) bool {
+	_, err := readOutput(fmt.Sprintf("systemctl is-active --quiet %s", GetSystemdUnits(projectName).Service))
+	return err == nil
+}
+
+// Reload the project's service unit if it's installed and running, which starts the project again with the latest settings.
+// Returns false if the unit isn't installed or isn't running. This is meant to be run as root.
+func ReloadSystemdUnit(projectName string) (bool, error) {
+	if !IsSystemdUnitInstalled(projectName) {
+		return false, nil
+	}
+	if !IsSystemdUnitActive(projectName) {
+		return false, nil
+	}
+
+	service := GetSystemdUnits(projectName).Service
+	cmd := newCommand(fmt.Sprintf("systemctl reload %s", service))
+	err := runStartServiceCommand(cmd)
+	if err != nil {
+		return false, fmt.Errorf("error reloading [%s]: %w", service, err)
+	}
+	return true, nil
+}
+
+// Disable and delete systemd units, skipping any that aren't installed
+func removeSystemdUnits(rootCmd string, names ...string) error {
+	for _, name := range names {
+		path := filepath.Join(SystemdUnitDir, name)
+		_, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		// Stop timers so they don't fire again, but leave the main service running so the project keeps going
+		disableCmd := ""
+		switch {
+		case strings.HasSuffix(name, ".timer"):
+			disableCmd = fmt.Sprintf("%s systemctl disable --now %s", rootCmd, name)
+		case strings.HasSuffix(name, systemdHealthSuffix+".service"), strings.HasSuffix(name, systemdPruneSuffix+".service"):
+			// The health check and auto-prune are only started by their timers, so there's nothing to disable
+		default:
+			disableCmd = fmt.Sprintf("%s systemctl disable %s", rootCmd, name)
+		}
+		if disableCmd != "" {
+			err = printOutput(disableCmd)
+			if err != nil {
+				return fmt.Errorf("error disabling [%s]: %w", name, err)
+			}
+		}
+		err = printOutput(fmt.Sprintf("%s rm -f %s", rootCmd, shellescape.Quote(path)))
+		if err != nil {
+			return fmt.Errorf("error removing [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Get the service unit that starts the project on boot and stops it on shutdown.
+// Stopping goes through Hyperdrive so the Validator Clients are stopped before the clients they depend on.
+func getSystemdServiceUnit(projectName string, runtime ContainerRuntime, username string, hyperdriveCmd string) string {
+	// The rootless runtimes are run by the user's own service manager, which a system unit can't depend on
+	dependencies := "Requires=docker.service\nAfter=docker.service network-online.target"
+	if runtime.IsRootless() {
+		dependencies = "After=network-online.target"
+	}
+	return fmt.Sprintf(`# Autogenerated by Hyperdrive - changes will be overwritten by `+"`hyperdrive service systemd install`"+`
+[Unit]
+Description=Hyperdrive (%[1]s)
+Documentation=https://docs.nodeset.io
+%[4]s
+Wants=network-online.target
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+User=%[2]s
+ExecStart=%[3]s service start --yes
+ExecReload=%[3]s service start --yes
+ExecStop=%[3]s service systemd stop
+TimeoutStartSec=0
+TimeoutStopSec=5min
+
+[Install]
+WantedBy=multi-user.target
+`, projectName, username, hyperdriveCmd, dependencies)
+}
+
+// Get the service unit that checks the project's containers
+func getSystemdHealthServiceUnit(projectName string, service string, username string, hyperdriveCmd string) string {
+	return fmt.Sprintf(`# Autogenerated by Hyperdrive - changes will be overwritten by `+"`hyperdrive service systemd install`"+`
+[Unit]
+Description=Hyperdrive (%[1]s) health check
+After=%[2]s
+
+[Service]
+Type=oneshot
+User=%[3]s
+ExecStart=%[4]s service systemd health-check
+`, projectName, service, username, hyperdriveCmd)
+}
+
+// Get the timer that runs the health check
+func getSystemdHealthTimerUnit(projectName string, service string, interval time.Duration) string {
+	return fmt.Sprintf(`# Autogenerated by Hyperdrive - changes will be overwritten by `+"`hyperdrive service systemd install`"+`
+[Unit]
+Description=Run the Hyperdrive (%[1]s) health check every %[3]s
+After=%[2]s
+
+[Timer]
+OnBootSec=%[4]ds
+OnUnitActiveSec=%[4]ds
+
+[Install]
+WantedBy=timers.target
+`, projectName, service, interval, int64(interval.Seconds()))
+}
+
+// Get the service unit that prunes the Execution client if its free space is below the auto-prune threshold.
+// It doesn't wait for the prune to finish, since that can take hours.
+func getSystemdPruneServiceUnit(projectName string, service string, username string, hyperdriveCmd string) string {
+	return fmt.Sprintf(`# Autogenerated by Hyperdrive - changes will be overwritten by `+"`hyperdrive service systemd install`"+`
+[Unit]
+Description=Hyperdrive (%[1]s) Execution client auto-prune
+After=%[2]s
+
+[Service]
+Type=oneshot
+User=%[3]s
+ExecStart=%[4]s service prune-ec --auto --no-wait
+`, projectName, service, username, hyperdriveCmd)
+}
+
+// Get the timer that runs the auto-prune check once a day
+func getSystemdPruneTimerUnit(projectName string, service string) string {
+	return fmt.Sprintf(`# Autogenerated by Hyperdrive - changes will be overwritten by `+"`hyperdrive service systemd install`"+`
+[Unit]
+Description=Check the free space for the Hyperdrive (%[1]s) Execution client auto-prune every day
+After=%[2]s
+
+[Timer]
+OnCalendar=daily
+RandomizedDelaySec=1h
+Persistent=true
+
+[Install]
+WantedBy=timers.target
+`, projectName, service)
+}
@@ -32,3 +32,21 @@ func (c *GlobalConfig) StakeWiseApiKeyPath() string {
 func (c *GlobalConfig) ConstellationApiKeyPath() string {
 	return csApiKeyRelPath
 }
+
+// Used by text/template to format ec.yml
+func (c *GlobalConfig) GetEcDataVolume() string {
+	return c.Cli.ClientVolumes.EcDataVolume.Value
+}
+
+// Used by text/template to format bn.yml
+func (c *GlobalConfig) GetBnDataVolume() string {
+	return c.Cli.ClientVolumes.BnDataVolume.Value
+}
+
+// Used by text/template to format daemon.yml and the module daemons
+func (c *GlobalConfig) GetNetworksDir() string {
+	if c.networksDir == "" {
+		return systemNetworksDir
+	}
+	return c.networksDir
+}
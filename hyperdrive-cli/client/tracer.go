@@ -1,94 +1,525 @@
 package client
 
 import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
 	"net/http/httptrace"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/nodeset-org/hyperdrive-daemon/shared"
+	nmcclient "github.com/rocket-pool/node-manager-core/api/client"
 	"github.com/rocket-pool/node-manager-core/log"
 )
 
-func createTracer(file *os.File, logger *slog.Logger) (*httptrace.ClientTrace, error) {
-	tracer := &httptrace.ClientTrace{}
-	tracer.ConnectDone = func(network, addr string, err error) {
-		logger.Debug("HTTP Connect Done",
-			slog.String("network", network),
-			slog.String("addr", addr),
-			log.Err(err),
-		)
-		err = writeToTraceFile(file, fmt.Sprintf("ConnectDone: network=%s, addr=%s, err=%v", network, addr, err))
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
-		}
+const (
+	// The value used in place of redacted data
+	redactedValue string = "<redacted>"
+)
+
+// Header names (lower case) that will always be redacted from traces
+var redactedHeaders = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+}
+
+// Substrings of query parameter and JSON field names (lower case, without separators) that will be redacted from traces
+var redactedFields = []string{
+	"mnemonic",
+	"password",
+	"passphrase",
+	"privatekey",
+	"secret",
+	"seed",
+	"token",
+	"jwt",
+}
+
+// A single entry in an HTTP trace, formatted as a HAR 1.2 entry.
+// Each entry is written to the trace file as a single line of JSON so the file can be appended to safely;
+// wrap the lines in {"log":{"version":"1.2","entries":[...]}} to load them into standard HAR viewers.
+type harEntry struct {
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Connection      string      `json:"connection,omitempty"`
+	Comment         string      `json:"comment,omitempty"`
+	Creator         harCreator  `json:"_creator"`
+}
+
+// The application that created a HAR entry
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Origin  string `json:"origin"`
+}
+
+// A HAR request
+type harRequest struct {
+	Method      string         `json:"method"`
+	Url         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// A HAR response
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectUrl string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+// A name / value pair used for headers and query parameters
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// The body of a HAR request
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+// The body of a HAR response
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+// Timings for each phase of a request, in milliseconds. -1 means the phase did not apply.
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	Dns     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Ssl     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Writes HTTP requests and responses to a trace file in HAR format, redacting sensitive data
+type httpTracer struct {
+	file   *os.File
+	logger *slog.Logger
+	origin string
+	lock   *sync.Mutex
+}
+
+// Create a new HTTP tracer that writes to the provided file
+func createTracer(file *os.File, logger *slog.Logger, origin string) (*httpTracer, error) {
+	if file == nil {
+		return nil, fmt.Errorf("HTTP trace file is not open")
 	}
-	tracer.DNSDone = func(dnsInfo httptrace.DNSDoneInfo) {
-		logger.Debug("HTTP DNS Done",
-			slog.String("addrs", fmt.Sprint(dnsInfo.Addrs)),
-			slog.Bool("coalesced", dnsInfo.Coalesced),
-			log.Err(dnsInfo.Err),
-		)
-		err := writeToTraceFile(file, fmt.Sprintf("DNSDone: addrs=%v, coalesced=%t, err=%v", dnsInfo.Addrs, dnsInfo.Coalesced, dnsInfo.Err))
+	return &httpTracer{
+		file:   file,
+		logger: logger,
+		origin: origin,
+		lock:   &sync.Mutex{},
+	}, nil
+}
+
+// Wrap a network requester context so every request it sends is written to the trace file
+func (t *httpTracer) wrapContext(context *nmcclient.NetworkRequesterContext) nmcclient.IRequesterContext {
+	return &tracingRequesterContext{
+		NetworkRequesterContext: context,
+		tracer:                  t,
+	}
+}
+
+// A requester context that records each request and response it handles
+type tracingRequesterContext struct {
+	*nmcclient.NetworkRequesterContext
+	tracer *httpTracer
+}
+
+// Send an HTTP request to the server, recording it in the trace file
+func (r *tracingRequesterContext) SendRequest(request *http.Request) (*http.Response, error) {
+	// Capture the request body so it can be both traced and sent
+	var requestBody []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
 		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+			return nil, fmt.Errorf("error reading request body for HTTP trace: %w", err)
 		}
+		request.Body = io.NopCloser(bytes.NewReader(requestBody))
 	}
-	tracer.DNSStart = func(dnsInfo httptrace.DNSStartInfo) {
-		logger.Debug("HTTP DNS Start",
-			slog.String("host", dnsInfo.Host),
-		)
-		err := writeToTraceFile(file, fmt.Sprintf("DNSStart: host=%s", dnsInfo.Host))
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+
+	// Time each phase of the request
+	phases := &tracePhases{}
+	request = request.WithContext(httptrace.WithClientTrace(request.Context(), r.tracer.createClientTrace(phases)))
+	phases.start = time.Now()
+	response, err := r.NetworkRequesterContext.SendRequest(request)
+
+	// The auth callback adds headers to the request after it leaves this context, so use the one that was actually sent
+	sentRequest := request
+	if response != nil && response.Request != nil {
+		sentRequest = response.Request
+	}
+	entry := r.tracer.createEntry(sentRequest, requestBody, phases)
+	if err != nil {
+		entry.Comment = fmt.Sprintf("error sending request: %s", err.Error())
+		r.tracer.writeEntry(entry, phases)
+		return nil, err
+	}
+
+	// Capture the response body and replace it with a copy for the caller
+	responseBody, readErr := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+	phases.done = time.Now()
+	if readErr != nil {
+		entry.Comment = fmt.Sprintf("error reading response body: %s", readErr.Error())
+	}
+	entry.Response = createHarResponse(response, responseBody)
+	r.tracer.writeEntry(entry, phases)
+	return response, nil
+}
+
+// Timestamps for each phase of a request
+type tracePhases struct {
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	gotConn      time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+	done         time.Time
+	remoteAddr   string
+	localAddr    string
+}
+
+// Create a client trace that records phase timings for a single request and logs them in debug mode
+func (t *httpTracer) createClientTrace(phases *tracePhases) *httptrace.ClientTrace {
+	logger := t.logger
+	return &httptrace.ClientTrace{
+		DNSStart: func(dnsInfo httptrace.DNSStartInfo) {
+			phases.dnsStart = time.Now()
+			logger.Debug("HTTP DNS Start",
+				slog.String("host", dnsInfo.Host),
+			)
+		},
+		DNSDone: func(dnsInfo httptrace.DNSDoneInfo) {
+			phases.dnsDone = time.Now()
+			logger.Debug("HTTP DNS Done",
+				slog.String("addrs", fmt.Sprint(dnsInfo.Addrs)),
+				slog.Bool("coalesced", dnsInfo.Coalesced),
+				log.Err(dnsInfo.Err),
+			)
+		},
+		ConnectStart: func(network, addr string) {
+			phases.connectStart = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			phases.connectDone = time.Now()
+			logger.Debug("HTTP Connect Done",
+				slog.String("network", network),
+				slog.String("addr", addr),
+				log.Err(err),
+			)
+		},
+		TLSHandshakeStart: func() {
+			phases.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			phases.tlsDone = time.Now()
+			logger.Debug("HTTP TLS Handshake Done",
+				slog.String("serverName", state.ServerName),
+				log.Err(err),
+			)
+		},
+		GotConn: func(connInfo httptrace.GotConnInfo) {
+			phases.gotConn = time.Now()
+			phases.localAddr = connInfo.Conn.LocalAddr().String()
+			phases.remoteAddr = connInfo.Conn.RemoteAddr().String()
+			logger.Debug("HTTP Got Connection",
+				slog.Bool("reused", connInfo.Reused),
+				slog.Bool("wasIdle", connInfo.WasIdle),
+				slog.Duration("idleTime", connInfo.IdleTime),
+				slog.String("localAddr", phases.localAddr),
+				slog.String("remoteAddr", phases.remoteAddr),
+			)
+		},
+		WroteRequest: func(wroteInfo httptrace.WroteRequestInfo) {
+			phases.wroteRequest = time.Now()
+			logger.Debug("HTTP Wrote Request",
+				log.Err(wroteInfo.Err),
+			)
+		},
+		GotFirstResponseByte: func() {
+			phases.firstByte = time.Now()
+			logger.Debug("HTTP Got First Response Byte")
+		},
+		PutIdleConn: func(err error) {
+			logger.Debug("HTTP Put Idle Connection",
+				log.Err(err),
+			)
+		},
+	}
+}
+
+// Create a HAR entry for a request
+func (t *httpTracer) createEntry(request *http.Request, body []byte, phases *tracePhases) *harEntry {
+	entry := &harEntry{
+		StartedDateTime: phases.start,
+		Request: harRequest{
+			Method:      request.Method,
+			Url:         redactUrl(request.URL),
+			HttpVersion: request.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     redactHeaders(request.Header),
+			QueryString: redactQuery(request.URL.Query()),
+			HeadersSize: -1,
+			BodySize:    len(body),
+		},
+		Creator: harCreator{
+			Name:    "hyperdrive-cli",
+			Version: shared.HyperdriveVersion,
+			Origin:  t.origin,
+		},
+	}
+	if len(body) > 0 {
+		mimeType := request.Header.Get("Content-Type")
+		entry.Request.PostData = &harPostData{
+			MimeType: mimeType,
+			Text:     redactBody(mimeType, body),
 		}
 	}
-	tracer.GotConn = func(connInfo httptrace.GotConnInfo) {
-		logger.Debug("HTTP Got Connection",
-			slog.Bool("reused", connInfo.Reused),
-			slog.Bool("wasIdle", connInfo.WasIdle),
-			slog.Duration("idleTime", connInfo.IdleTime),
-			slog.String("localAddr", connInfo.Conn.LocalAddr().String()),
-			slog.String("remoteAddr", connInfo.Conn.RemoteAddr().String()),
-		)
-		err := writeToTraceFile(file, fmt.Sprintf("GotConn: reused=%t, wasIdle=%t, idleTime=%s, localAddr=%s, remoteAddr=%s", connInfo.Reused, connInfo.WasIdle, connInfo.IdleTime, connInfo.Conn.LocalAddr().String(), connInfo.Conn.RemoteAddr().String()))
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+	return entry
+}
+
+// Create a HAR response from an HTTP response and its body
+func createHarResponse(response *http.Response, body []byte) harResponse {
+	mimeType := response.Header.Get("Content-Type")
+	return harResponse{
+		Status:      response.StatusCode,
+		StatusText:  http.StatusText(response.StatusCode),
+		HttpVersion: response.Proto,
+		Cookies:     []harNameValue{},
+		Headers:     redactHeaders(response.Header),
+		Content: harContent{
+			Size:     len(body),
+			MimeType: mimeType,
+			Text:     redactBody(mimeType, body),
+		},
+		HeadersSize: -1,
+		BodySize:    len(body),
+	}
+}
+
+// Fill in the timings for an entry and write it to the trace file
+func (t *httpTracer) writeEntry(entry *harEntry, phases *tracePhases) {
+	if phases.done.IsZero() {
+		phases.done = time.Now()
+	}
+	entry.Timings = harTimings{
+		Blocked: -1,
+		Dns:     phaseDuration(phases.dnsStart, phases.dnsDone),
+		Connect: phaseDuration(phases.connectStart, phases.connectDone),
+		Ssl:     phaseDuration(phases.tlsStart, phases.tlsDone),
+		Send:    phaseDuration(phases.gotConn, phases.wroteRequest),
+		Wait:    phaseDuration(phases.wroteRequest, phases.firstByte),
+		Receive: phaseDuration(phases.firstByte, phases.done),
+	}
+	if !phases.gotConn.IsZero() {
+		entry.Timings.Blocked = phaseDuration(phases.start, phases.gotConn)
+		// DNS, connect and TLS happen while blocked on the connection, so don't count them twice
+		for _, phase := range []float64{entry.Timings.Dns, entry.Timings.Connect} {
+			if phase > 0 {
+				entry.Timings.Blocked -= phase
+			}
+		}
+		if entry.Timings.Blocked < 0 {
+			entry.Timings.Blocked = 0
 		}
 	}
-	tracer.GotFirstResponseByte = func() {
-		logger.Debug("HTTP Got First Response Byte")
-		err := writeToTraceFile(file, "GotFirstResponseByte")
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+	entry.Time = phaseDuration(phases.start, phases.done)
+	entry.ServerIPAddress = phases.remoteAddr
+	entry.Connection = phases.localAddr
+
+	bytes, err := json.Marshal(entry)
+	if err != nil {
+		t.logger.Debug("<error serializing HTTP trace entry>", log.Err(err))
+		return
+	}
+	err = t.writeToTraceFile(bytes)
+	if err != nil {
+		t.logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+	}
+}
+
+// Write a line to the trace file
+func (t *httpTracer) writeToTraceFile(data []byte) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Write the data
+	_, err := t.file.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("error writing to HTTP trace file [%s]: %w", t.file.Name(), err)
+	}
+	return nil
+}
+
+// Get the duration of a phase in milliseconds, or -1 if it didn't happen
+func phaseDuration(start time.Time, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() {
+		return -1
+	}
+	return float64(end.Sub(start).Microseconds()) / 1000
+}
+
+// Check if a field name refers to sensitive data
+func isSensitiveField(name string) bool {
+	normalized := strings.ToLower(name)
+	normalized = strings.NewReplacer("-", "", "_", "", " ", "").Replace(normalized)
+	for _, field := range redactedFields {
+		if strings.Contains(normalized, field) {
+			return true
 		}
 	}
-	tracer.PutIdleConn = func(err error) {
-		logger.Debug("HTTP Put Idle Connection",
-			log.Err(err),
-		)
-		err = writeToTraceFile(file, fmt.Sprintf("PutIdleConn: err=%v", err))
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+	return false
+}
+
+// Convert headers to HAR format, redacting sensitive values
+func redactHeaders(headers http.Header) []harNameValue {
+	result := []harNameValue{}
+	for name, values := range headers {
+		redact := isSensitiveField(name)
+		for _, header := range redactedHeaders {
+			if strings.EqualFold(name, header) {
+				redact = true
+				break
+			}
+		}
+		for _, value := range values {
+			if redact {
+				value = redactedValue
+			}
+			result = append(result, harNameValue{Name: name, Value: value})
 		}
 	}
-	tracer.WroteRequest = func(wroteInfo httptrace.WroteRequestInfo) {
-		logger.Debug("HTTP Wrote Request",
-			log.Err(wroteInfo.Err),
-		)
-		err := writeToTraceFile(file, fmt.Sprintf("WroteRequest: err=%v", wroteInfo.Err))
-		if err != nil {
-			logger.Debug("<error writing to HTTP trace file>", log.Err(err))
+	return result
+}
+
+// Convert query parameters to HAR format, redacting sensitive values
+func redactQuery(query url.Values) []harNameValue {
+	result := []harNameValue{}
+	for name, values := range query {
+		for _, value := range values {
+			if isSensitiveField(name) {
+				value = redactedValue
+			}
+			result = append(result, harNameValue{Name: name, Value: value})
 		}
 	}
+	return result
+}
 
-	return tracer, nil
+// Get the string form of a URL with sensitive query parameters and user info redacted
+func redactUrl(original *url.URL) string {
+	redacted := *original
+	if redacted.User != nil {
+		redacted.User = url.User(redactedValue)
+	}
+	query := redacted.Query()
+	for name := range query {
+		if isSensitiveField(name) {
+			query.Set(name, redactedValue)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
 }
 
-func writeToTraceFile(file *os.File, data string) error {
-	// Write the data
-	_, err := file.WriteString(data + "\n")
+// Redact sensitive values from a request or response body
+func redactBody(mimeType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	// Form bodies use the same rules as query strings
+	if strings.HasPrefix(mimeType, "application/x-www-form-urlencoded") {
+		values, err := url.ParseQuery(string(body))
+		if err == nil {
+			for name := range values {
+				if isSensitiveField(name) {
+					values.Set(name, redactedValue)
+				}
+			}
+			return values.Encode()
+		}
+	}
+
+	// Plain text bodies are usually error messages, so keep them as-is
+	if strings.HasPrefix(mimeType, "text/") {
+		return string(body)
+	}
+
+	// Try to parse the body as JSON regardless of the declared type, since the API server doesn't always set it
+	var parsed any
+	err := json.Unmarshal(body, &parsed)
 	if err != nil {
-		return fmt.Errorf("error writing to HTTP trace file [%s]: %w", file.Name(), err)
+		return fmt.Sprintf("<%d bytes of unparseable body omitted>", len(body))
+	}
+	redacted, err := json.Marshal(redactJson(parsed))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes of unserializable body omitted>", len(body))
+	}
+	return string(redacted)
+}
+
+// Recursively redact sensitive fields in a parsed JSON value
+func redactJson(value any) any {
+	switch typedValue := value.(type) {
+	case map[string]any:
+		for key, child := range typedValue {
+			if isSensitiveField(key) {
+				typedValue[key] = redactedValue
+			} else {
+				typedValue[key] = redactJson(child)
+			}
+		}
+		return typedValue
+	case []any:
+		for i, child := range typedValue {
+			typedValue[i] = redactJson(child)
+		}
+		return typedValue
+	default:
+		return value
 	}
-	return nil
 }
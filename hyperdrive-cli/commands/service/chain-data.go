@@ -0,0 +1,278 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	chainDataCompressFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "compress",
+		Usage: "Compress each file of the export with zstd. This makes the export considerably smaller but takes longer to export and import.",
+	}
+	chainDataForceFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: "Skip the free space checks, and allow importing data from a different client or network",
+	}
+)
+
+// Export the chain data of the EC or BN to an external folder
+func exportChainData(c *cli.Context, dataType client.ChainDataType, targetDir string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+	clientName := dataType.ClientName()
+	if !cfg.Hyperdrive.IsLocalMode() {
+		fmt.Printf("You use an externally-managed %s. Hyperdrive cannot export its chain data.\n", clientName)
+		return nil
+	}
+	force := c.Bool(chainDataForceFlag.Name)
+	compress := c.Bool(chainDataCompressFlag.Name)
+
+	// Prepare the target folder
+	targetDir, err = filepath.Abs(targetDir)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path of [%s]: %w", targetDir, err)
+	}
+	err = os.MkdirAll(targetDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating target folder [%s]: %w", targetDir, err)
+	}
+
+	// Check for an earlier export in the same folder
+	existingInfo, err := client.ReadChainDataExportInfo(targetDir)
+	if err != nil {
+		return err
+	}
+	if existingInfo != nil {
+		if existingInfo.Type != dataType {
+			return fmt.Errorf("[%s] already contains an export of different chain data (%s); please choose another folder", targetDir, existingInfo.Type)
+		}
+		if existingInfo.Compressed != compress {
+			return fmt.Errorf("[%s] already contains an export with compression set to %t; please use the same setting or choose another folder", targetDir, existingInfo.Compressed)
+		}
+		if existingInfo.Complete {
+			fmt.Printf("[%s] already contains a finished export from %s. It will be updated to match your current chain data.\n", targetDir, existingInfo.Timestamp.Format(time.RFC1123))
+		} else {
+			fmt.Printf("[%s] contains an unfinished export from %s. It will be resumed.\n", targetDir, existingInfo.Timestamp.Format(time.RFC1123))
+		}
+	}
+
+	// Get the client container and volume
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(dataType.ContainerID()))
+	volume, err := hd.GetClientVolumeName(containerName, client.ClientDataMountPath)
+	if err != nil {
+		return fmt.Errorf("error getting %s volume name: %w", clientName, err)
+	}
+
+	// Make sure the target has enough space
+	fmt.Println("Checking the size of your chain data...")
+	volumeSize, err := hd.GetVolumeSize(volume)
+	if err != nil {
+		return fmt.Errorf("error getting %s volume size: %w", clientName, err)
+	}
+	freeSpace, err := client.GetPathFreeSpace(targetDir)
+	if err != nil {
+		return fmt.Errorf("error getting free space in [%s]: %w", targetDir, err)
+	}
+	existingSize, err := hd.GetChainDataExportSize(client.GetChainDataMigratorContainerName(cfg), targetDir, cfg.Cli.Images.ChainDataMigratorTag.Value)
+	if err != nil {
+		return fmt.Errorf("error getting the size of the earlier export in [%s]: %w", targetDir, err)
+	}
+	availableSpace := freeSpace + existingSize
+	fmt.Printf("Your %s chain data is %s; the target folder has %s available.\n", clientName, humanize.IBytes(uint64(volumeSize)), humanize.IBytes(availableSpace))
+	if uint64(volumeSize) > availableSpace {
+		if compress {
+			fmt.Printf("%sWARNING: The target folder may not have enough space even with compression.%s\n", terminal.ColorYellow, terminal.ColorReset)
+		} else if !force {
+			fmt.Printf("%sThe target folder doesn't have enough space for the export. Please free up some space, use `--%s`, or re-run this with `--%s` to skip this check.%s\n", terminal.ColorRed, chainDataCompressFlag.Name, chainDataForceFlag.Name, terminal.ColorReset)
+			return nil
+		}
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("Your %s will be stopped while its data is exported to [%s], then restarted.\n", clientName, targetDir)
+	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm("Are you sure you want to export your chain data?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the client
+	fmt.Printf("Stopping %s...\n", containerName)
+	err = hd.StopContainer(containerName)
+	if err != nil {
+		return fmt.Errorf("error stopping %s: %w", clientName, err)
+	}
+
+	// Run the export
+	info := client.ChainDataExportInfo{
+		Type:       dataType,
+		Client:     getChainDataClient(cfg, dataType),
+		Network:    cfg.Hyperdrive.Network.Value,
+		Compressed: compress,
+		DataSize:   uint64(volumeSize),
+		Timestamp:  time.Now(),
+	}
+	if existingInfo != nil && !existingInfo.Complete {
+		info.Timestamp = existingInfo.Timestamp
+	}
+	fmt.Println("Exporting chain data...")
+	exportErr := hd.RunChainDataExport(client.GetChainDataMigratorContainerName(cfg), volume, targetDir, cfg.Cli.Images.ChainDataMigratorTag.Value, info)
+
+	// Restart the client regardless of how the export went
+	fmt.Printf("Starting %s...\n", containerName)
+	err = hd.StartContainer(containerName)
+	if err != nil {
+		fmt.Printf("%sWARNING: Starting %s failed: %s%s\n", terminal.ColorYellow, containerName, err.Error(), terminal.ColorReset)
+	}
+	if exportErr != nil {
+		fmt.Printf("%sThe export didn't finish. You can resume it by running this command again with the same folder.%s\n", terminal.ColorYellow, terminal.ColorReset)
+		return fmt.Errorf("error exporting chain data: %w", exportErr)
+	}
+
+	fmt.Printf("\nDone! Your chain data has been exported to [%s].\n", targetDir)
+	return nil
+}
+
+// Import the chain data of the EC or BN from an external folder
+func importChainData(c *cli.Context, dataType client.ChainDataType, sourceDir string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+	clientName := dataType.ClientName()
+	if !cfg.Hyperdrive.IsLocalMode() {
+		fmt.Printf("You use an externally-managed %s. Hyperdrive cannot import its chain data.\n", clientName)
+		return nil
+	}
+	force := c.Bool(chainDataForceFlag.Name)
+
+	// Check the export
+	sourceDir, err = filepath.Abs(sourceDir)
+	if err != nil {
+		return fmt.Errorf("error getting absolute path of [%s]: %w", sourceDir, err)
+	}
+	info, err := client.ReadChainDataExportInfo(sourceDir)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return fmt.Errorf("[%s] doesn't contain a chain data export", sourceDir)
+	}
+	if !info.Complete {
+		return fmt.Errorf("the export in [%s] is unfinished; please resume it with `hyperdrive service export-%s-data` before importing it", sourceDir, info.Type)
+	}
+	if info.Type != dataType {
+		return fmt.Errorf("[%s] contains %s chain data, not %s chain data", sourceDir, info.Type, dataType)
+	}
+	fmt.Printf("Found a %s export of %s data from the %s network, taken on %s.\n", humanize.IBytes(info.DataSize), info.Client, info.Network, info.Timestamp.Format(time.RFC1123))
+	currentClient := getChainDataClient(cfg, dataType)
+	if info.Client != currentClient || info.Network != cfg.Hyperdrive.Network.Value {
+		if !force {
+			fmt.Printf("%sThis export doesn't match your current %s (%s on the %s network). Re-run this with `--%s` if you're sure you want to import it.%s\n", terminal.ColorRed, clientName, currentClient, cfg.Hyperdrive.Network.Value, chainDataForceFlag.Name, terminal.ColorReset)
+			return nil
+		}
+		fmt.Printf("%sWARNING: This export doesn't match your current %s (%s on the %s network).%s\n", terminal.ColorYellow, clientName, currentClient, cfg.Hyperdrive.Network.Value, terminal.ColorReset)
+	}
+
+	// Get the client container and volume
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(dataType.ContainerID()))
+	volume, err := hd.GetClientVolumeName(containerName, client.ClientDataMountPath)
+	if err != nil {
+		return fmt.Errorf("error getting %s volume name: %w", clientName, err)
+	}
+
+	// Make sure the volume has enough space; the existing data will be replaced so it counts as available
+	fmt.Println("Checking the free space for your chain data...")
+	volumePath, err := hd.GetClientVolumeSource(containerName, client.ClientDataMountPath)
+	if err != nil {
+		return fmt.Errorf("error getting %s volume path: %w", clientName, err)
+	}
+	freeSpace, err := client.GetPathFreeSpace(volumePath)
+	if err != nil {
+		return fmt.Errorf("error getting free space of the %s volume: %w", clientName, err)
+	}
+	volumeSize, err := hd.GetVolumeSize(volume)
+	if err != nil {
+		return fmt.Errorf("error getting %s volume size: %w", clientName, err)
+	}
+	availableSpace := freeSpace + uint64(volumeSize)
+	if info.DataSize > availableSpace {
+		if !force {
+			fmt.Printf("%sYour disk only has %s available, which isn't enough for the %s import. Please free up some space, or re-run this with `--%s` to skip this check.%s\n", terminal.ColorRed, humanize.IBytes(availableSpace), humanize.IBytes(info.DataSize), chainDataForceFlag.Name, terminal.ColorReset)
+			return nil
+		}
+		fmt.Printf("%sWARNING: Your disk only has %s available, so the import may fail.%s\n", terminal.ColorYellow, humanize.IBytes(availableSpace), terminal.ColorReset)
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("%sYour %s will be stopped and its current chain data will be replaced with the export. This cannot be undone!%s\n", terminal.ColorRed, clientName, terminal.ColorReset)
+	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm("Are you sure you want to import this chain data?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the client
+	fmt.Printf("Stopping %s...\n", containerName)
+	err = hd.StopContainer(containerName)
+	if err != nil {
+		return fmt.Errorf("error stopping %s: %w", clientName, err)
+	}
+
+	// Run the import
+	fmt.Println("Importing chain data...")
+	err = hd.RunChainDataImport(client.GetChainDataMigratorContainerName(cfg), volume, sourceDir, cfg.Cli.Images.ChainDataMigratorTag.Value, info.Compressed)
+	if err != nil {
+		fmt.Printf("%sThe import didn't finish, so %s has been left stopped. You can resume the import by running this command again.%s\n", terminal.ColorYellow, containerName, terminal.ColorReset)
+		return fmt.Errorf("error importing chain data: %w", err)
+	}
+
+	// Start the client
+	fmt.Printf("Starting %s...\n", containerName)
+	err = hd.StartContainer(containerName)
+	if err != nil {
+		return fmt.Errorf("error starting %s: %w", clientName, err)
+	}
+
+	fmt.Printf("\nDone! Your chain data has been imported. You can check on your %s with `hyperdrive service logs %s`.\n", clientName, dataType)
+	return nil
+}
+
+// Get the name of the local client that owns the provided chain data
+func getChainDataClient(cfg *client.GlobalConfig, dataType client.ChainDataType) string {
+	switch dataType {
+	case client.ChainDataType_Beacon:
+		return string(cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value)
+	default:
+		return string(cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliutils "github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	cleanupDryRunFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Only list what would be removed and how much space it would free, without removing anything",
+	}
+	cleanupIncludeVolumesFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "include-volumes",
+		Usage: "Also remove unused volumes, permanently deleting their data. This isn't implied by --yes.",
+	}
+)
+
+// Remove the images, volumes, and networks the current settings don't use anymore
+func cleanupService(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Find the stale resources
+	stale, err := hd.FindStaleDockerResources(getComposeFiles(c))
+	if err != nil {
+		return fmt.Errorf("error finding unused Docker resources: %w", err)
+	}
+
+	// Leave the volumes out unless they were explicitly requested
+	includeVolumes := c.Bool(cleanupIncludeVolumesFlag.Name)
+	skippedVolumes := 0
+	if !includeVolumes {
+		resources := []client.StaleDockerResource{}
+		for _, resource := range stale {
+			if resource.Type == client.DockerResourceType_Volume {
+				skippedVolumes++
+				continue
+			}
+			resources = append(resources, resource)
+		}
+		stale = resources
+	}
+	if skippedVolumes > 0 {
+		fmt.Printf("Skipping %d unused volume(s); run with --%s to remove them too.\n\n", skippedVolumes, cleanupIncludeVolumesFlag.Name)
+	}
+	if len(stale) == 0 {
+		fmt.Println("There aren't any unused Hyperdrive images, volumes, or networks to clean up.")
+		return nil
+	}
+
+	// Print them
+	total := uint64(0)
+	fmt.Println("The following Docker resources aren't used by your current Hyperdrive settings:")
+	for _, resource := range stale {
+		size := "unknown size"
+		if resource.Size >= 0 {
+			size = humanize.IBytes(uint64(resource.Size))
+			total += uint64(resource.Size)
+		}
+		fmt.Printf("\t%s\t%s (%s)\n", resource.Type, resource.Name, size)
+	}
+	fmt.Printf("\nRemoving them will free up about %s%s%s.\n", terminal.ColorGreen, humanize.IBytes(total), terminal.ColorReset)
+	if c.Bool(cleanupDryRunFlag.Name) {
+		return nil
+	}
+
+	if includeVolumes {
+		fmt.Printf("%sNOTE: Removing a volume permanently deletes its data. This includes chain data left behind by `hyperdrive service switch-client`, which you won't be able to switch back to without resyncing. Your current client data and metrics volumes are never removed.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	}
+	if !(c.Bool(cliutils.YesFlag.Name) || cliutils.Confirm("Would you like to remove them?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove them
+	failures := 0
+	for _, resource := range stale {
+		err = hd.RemoveStaleDockerResource(resource)
+		if err != nil {
+			fmt.Printf("%s%s%s\n", terminal.ColorRed, err.Error(), terminal.ColorReset)
+			failures++
+			continue
+		}
+		fmt.Printf("Removed %s %s.\n", resource.Type, resource.Name)
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d resource(s) couldn't be removed", failures, len(stale))
+	}
+	fmt.Printf("%sCleanup complete.%s\n", terminal.ColorGreen, terminal.ColorReset)
+	return nil
+}
@@ -3,11 +3,13 @@ package service
 import (
 	"fmt"
 
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/commands/nodeset"
 
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/commands/wallet"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rocket-pool/node-manager-core/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -63,6 +65,8 @@ func createFlagsFromConfigParams(sectionName string, params []config.IParameter,
 func RegisterCommands(app *cli.App, name string, aliases []string) {
 	configFlags := []cli.Flag{
 		configUpdateDefaultsFlag,
+		configWizardAnswersFlag,
+		configRecordWizardAnswersFlag,
 	}
 
 	// TODO: HEADLESS MODE
@@ -120,6 +124,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					// Run command
 					return configureService(c)
 				},
+				Subcommands: []*cli.Command{
+					{
+						Name:  "schema",
+						Usage: "Print a schema of the user settings file, including every parameter's type, options, limits, and defaults for each network, so it can be validated by other tools",
+						Flags: []cli.Flag{
+							configSchemaFormatFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return getConfigSchema(c)
+						},
+					},
+					{
+						Name:      "export",
+						Usage:     "Export your settings to a file so they can be imported on another node. Settings that are specific to this machine, such as paths, client URLs, secrets, and ports bound to localhost, are left out.",
+						ArgsUsage: "file",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 1)
+							path := c.Args().Get(0)
+
+							// Run command
+							return exportConfig(c, path)
+						},
+					},
+					{
+						Name:      "import",
+						Usage:     "Import settings that were exported from another node, keeping this machine's own values for the settings that were left out, and review the changes before saving them",
+						ArgsUsage: "file",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 1)
+							path := c.Args().Get(0)
+
+							// Run command
+							return importConfig(c, path)
+						},
+					},
+				},
 			},
 
 			{
@@ -154,6 +200,8 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Usage:   "Start the Hyperdrive service",
 				Flags: []cli.Flag{
 					ignoreSlashTimerFlag,
+					fixPortsFlag,
+					ignorePortConflictsFlag,
 					nodeset.RegisterEmailFlag,
 					wallet.PasswordFlag,
 					wallet.SavePasswordFlag,
@@ -168,6 +216,190 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:  "pull",
+				Usage: "Pull the images for the current settings with progress for each layer, and record their digests in the image lock file",
+				Flags: []cli.Flag{
+					pullVerifyFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 0)
+
+					// Run command
+					return pullImages(c)
+				},
+			},
+
+			{
+				Name:  "cleanup",
+				Usage: "Remove old Hyperdrive images, volumes, and networks that your current settings don't use anymore",
+				Flags: []cli.Flag{
+					cleanupDryRunFlag,
+					cleanupIncludeVolumesFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 0)
+
+					// Run command
+					return cleanupService(c)
+				},
+			},
+
+			{
+				Name:  "override",
+				Usage: "Inspect your override files, the customizations applied on top of the containers Hyperdrive generates",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "check",
+						Usage: "Parse every override file, validate it against the Docker Compose spec and the current containers, and warn about customizations the templates don't use anymore",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return checkOverrides(c)
+						},
+					},
+					{
+						Name:  "diff",
+						Usage: "Show what each override file changes in the containers Hyperdrive generates",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return diffOverrides(c)
+						},
+					},
+				},
+			},
+
+			{
+				Name:  "check-ports",
+				Usage: "Check whether any of the ports Hyperdrive needs are already in use by another program or Docker container, and optionally move them to free ports",
+				Flags: []cli.Flag{
+					fixPortsFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 0)
+
+					// Run command
+					return checkPorts(c)
+				},
+			},
+
+			{
+				Name:  "firewall",
+				Usage: "Generate inbound firewall rules for the ports Hyperdrive uses with its current settings",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "print",
+						Usage: "Print the firewall rules as a script without applying them",
+						Flags: []cli.Flag{
+							firewallBackendFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return printFirewallRules(c)
+						},
+					},
+					{
+						Name:  "apply",
+						Usage: "Apply the firewall rules, replacing any that Hyperdrive added before",
+						Flags: []cli.Flag{
+							firewallBackendFlag,
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return applyFirewallRules(c)
+						},
+					},
+				},
+			},
+
+			{
+				Name:  "systemd",
+				Usage: "Manage the systemd unit that starts Hyperdrive when this machine boots and stops it when it shuts down",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "install",
+						Usage: "Install and enable the systemd unit for this Hyperdrive installation, running as the current user",
+						Flags: []cli.Flag{
+							systemdHealthCheckIntervalFlag,
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return installSystemdUnits(c)
+						},
+					},
+					{
+						Name:  "uninstall",
+						Usage: "Remove the systemd unit; Hyperdrive keeps running",
+						Flags: []cli.Flag{
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return uninstallSystemdUnits(c)
+						},
+					},
+					{
+						Name:  "status",
+						Usage: "Show the status of the systemd unit",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return getSystemdStatus(c)
+						},
+					},
+					{
+						Name:   "stop",
+						Usage:  "Stop the service for systemd, stopping the Validator Clients first",
+						Hidden: true,
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return stopServiceForSystemd(c)
+						},
+					},
+					{
+						Name:   "health-check",
+						Usage:  "Check for stuck or unhealthy containers for the systemd health check timer",
+						Hidden: true,
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return checkHealthForSystemd(c)
+						},
+					},
+				},
+			},
+
 			{
 				Name:    "stop",
 				Aliases: []string{"pause", "p"},
@@ -268,22 +500,25 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
-			/*
-				{
-					Name:    "prune-ec",
-					Aliases: []string{"prune-eth1", "n"},
-					Usage:   "Shuts down the main ETH1 client and prunes its database, freeing up disk space, then restarts it when it's done.",
-					Action: func(c *cli.Context) error {
-						// Validate args
-						if err := input.ValidateArgCount(c, 0); err != nil {
-							return err
-						}
+			{
+				Name:    "prune-ec",
+				Aliases: []string{"prune-eth1", "n"},
+				Usage:   "Prunes the main Execution client's database to free up disk space. Clients that must be shut down to prune are restarted automatically when they're done.",
+				Flags: []cli.Flag{
+					pruneAutoFlag,
+					pruneForceFlag,
+					pruneNoWaitFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 0)
 
-						// Run command
-						return pruneExecutionClient(c)
-					},
+					// Run command
+					return pruneExecutionClient(c)
 				},
-			*/
+			},
+
 			{
 				Name:    "check-cpu-features",
 				Aliases: []string{"ccf"},
@@ -308,46 +543,124 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return getConfigYaml(c)
 				},
 			},
-			/*
-				{
-					Name:      "export-ec-data",
-					Aliases:   []string{"export-eth1-data"},
-					Usage:     "Exports the execution client (eth1) chain data to an external folder. Use this if you want to back up your chain data before switching execution clients.",
-					ArgsUsage: "target-folder",
-					Flags: []cli.Flag{
-						exportEcDataForceFlag,
-						exportEcDataDirtyFlag,
-						utils.YesFlag,
-					},
-					Action: func(c *cli.Context) error {
-						// Validate args
-						if err := input.ValidateArgCount(c, 1); err != nil {
-							return err
-						}
-						targetDir := c.Args().Get(0)
-
-						// Run command
-						return exportEcData(c, targetDir)
-					},
+
+			{
+				Name:      "export-ec-data",
+				Aliases:   []string{"export-eth1-data"},
+				Usage:     "Exports the Execution client's chain data to an external folder. Use this to back up your chain data or move it to another machine. Interrupted exports can be resumed by running this again.",
+				ArgsUsage: "target-folder",
+				Flags: []cli.Flag{
+					chainDataCompressFlag,
+					chainDataForceFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 1)
+					targetDir := c.Args().Get(0)
+
+					// Run command
+					return exportChainData(c, client.ChainDataType_Execution, targetDir)
+				},
+			},
+
+			{
+				Name:      "import-ec-data",
+				Aliases:   []string{"import-eth1-data"},
+				Usage:     "Imports the Execution client's chain data from a folder created by `export-ec-data`, after verifying its checksums. This replaces your current chain data.",
+				ArgsUsage: "source-folder",
+				Flags: []cli.Flag{
+					chainDataForceFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 1)
+					sourceDir := c.Args().Get(0)
+
+					// Run command
+					return importChainData(c, client.ChainDataType_Execution, sourceDir)
+				},
+			},
+
+			{
+				Name:      "export-bn-data",
+				Aliases:   []string{"export-eth2-data"},
+				Usage:     "Exports the Beacon Node's chain data to an external folder. Use this to back up your chain data or move it to another machine. Interrupted exports can be resumed by running this again.",
+				ArgsUsage: "target-folder",
+				Flags: []cli.Flag{
+					chainDataCompressFlag,
+					chainDataForceFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 1)
+					targetDir := c.Args().Get(0)
+
+					// Run command
+					return exportChainData(c, client.ChainDataType_Beacon, targetDir)
+				},
+			},
+
+			{
+				Name:      "import-bn-data",
+				Aliases:   []string{"import-eth2-data"},
+				Usage:     "Imports the Beacon Node's chain data from a folder created by `export-bn-data`, after verifying its checksums. This replaces your current chain data.",
+				ArgsUsage: "source-folder",
+				Flags: []cli.Flag{
+					chainDataForceFlag,
+					utils.YesFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 1)
+					sourceDir := c.Args().Get(0)
+
+					// Run command
+					return importChainData(c, client.ChainDataType_Beacon, sourceDir)
 				},
+			},
 
-				{
-					Name:      "import-ec-data",
-					Aliases:   []string{"import-eth1-data"},
-					Usage:     "Imports execution client (eth1) chain data from an external folder. Use this if you want to restore the data from an execution client that you previously backed up.",
-					ArgsUsage: "source-folder",
-					Action: func(c *cli.Context) error {
-						// Validate args
-						if err := input.ValidateArgCount(c, 1); err != nil {
-							return err
-						}
-						sourceDir := c.Args().Get(0)
-
-						// Run command
-						return importEcData(c, sourceDir)
+			{
+				Name:      "switch-client",
+				Usage:     "Switch your local Execution client or Beacon Node to a different client. The old client's data is kept until the new client has synced. If this is interrupted, run it again to resume.",
+				ArgsUsage: "ec|bn client",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "abort",
+						Usage: "Undo an in-progress client switch, going back to the old client and its data",
+						Flags: []cli.Flag{
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return abortClientSwitch(c)
+						},
 					},
 				},
-			*/
+				Flags: []cli.Flag{
+					utils.YesFlag,
+					switchClientForceFlag,
+					switchClientNoWaitFlag,
+				},
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 2)
+					dataType := client.ChainDataType(c.Args().Get(0))
+					if dataType != client.ChainDataType_Execution && dataType != client.ChainDataType_Beacon {
+						return fmt.Errorf("invalid client type [%s]; expected 'ec' or 'bn'", dataType)
+					}
+					newClient := c.Args().Get(1)
+
+					// Run command
+					return switchClient(c, dataType, newClient)
+				},
+			},
+
 			{
 				Name:    "resync-ec",
 				Aliases: []string{"resync-eth1"},
@@ -361,6 +674,93 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:  "verify-checkpoint",
+				Usage: "Compares the finalized checkpoint reported by your checkpoint sync provider with your additional providers and fallback Beacon Node, and records it if they agree",
+				Action: func(c *cli.Context) error {
+					// Validate args
+					utils.ValidateArgCount(c, 0)
+
+					// Run command
+					return verifyCheckpoint(c)
+				},
+			},
+
+			{
+				Name:  "mev-boost",
+				Usage: "Check on the MEV-Boost relays Hyperdrive is configured to use",
+				Subcommands: []*cli.Command{
+					{
+						Name:    "check",
+						Aliases: []string{"c"},
+						Usage:   "Measures each configured relay's latency, checks that it's on the correct network, and checks that your StakeWise and Constellation validators are registered with it",
+						Flags: []cli.Flag{
+							mevBoostCheckSkipRegistrationsFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return checkMevBoost(c)
+						},
+					},
+				},
+			},
+
+			{
+				Name:  "network",
+				Usage: "Manage custom network definitions, such as a local devnet",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "add",
+						Aliases:   []string{"a"},
+						Usage:     "Validate a network definition file and add it to the networks Hyperdrive can use",
+						ArgsUsage: "file",
+						Flags: []cli.Flag{
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 1)
+							path := c.Args().Get(0)
+
+							// Run command
+							return addNetwork(c, path)
+						},
+					},
+					{
+						Name:    "list",
+						Aliases: []string{"l"},
+						Usage:   "List the built-in and custom networks",
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 0)
+
+							// Run command
+							return listNetworks(c)
+						},
+					},
+					{
+						Name:      "remove",
+						Aliases:   []string{"r"},
+						Usage:     "Remove a custom network",
+						ArgsUsage: "key",
+						Flags: []cli.Flag{
+							utils.YesFlag,
+						},
+						Action: func(c *cli.Context) error {
+							// Validate args
+							utils.ValidateArgCount(c, 1)
+							key := config.Network(c.Args().Get(0))
+
+							// Run command
+							return removeNetwork(c, key)
+						},
+					},
+				},
+			},
+
 			{
 				Name:    "resync-bn",
 				Aliases: []string{"resync-eth2"},
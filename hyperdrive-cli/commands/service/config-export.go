@@ -0,0 +1,109 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive-daemon/shared"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliconfig "github.com/nodeset-org/hyperdrive/hyperdrive-cli/commands/service/config"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rivo/tview"
+	"github.com/urfave/cli/v2"
+)
+
+// Export the node's settings to a file so they can be imported on another node
+func exportConfig(c *cli.Context, path string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Load the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("there are no settings to export because Hyperdrive hasn't been configured yet; please run `hyperdrive service config` first")
+	}
+
+	// Save the export
+	export := cfg.Export()
+	err = export.Save(path)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported your settings to [%s].\n", path)
+	if len(export.LocalSettings) > 0 {
+		fmt.Println("The following settings are specific to this machine, so they were left out:")
+		for _, setting := range export.LocalSettings {
+			fmt.Printf("\t%s (%s)\n", setting.Path, setting.Reason)
+		}
+	}
+	fmt.Printf("You can import them on another node with `hyperdrive service config import %s`.\n", path)
+	return nil
+}
+
+// Import settings that were exported from another node, reviewing the changes before saving them
+func importConfig(c *cli.Context, path string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the config directory exists first
+	err = os.MkdirAll(hd.Context.UserDirPath, 0700)
+	if err != nil {
+		return fmt.Errorf("error creating Hyperdrive user configuration directory [%s]: %w", hd.Context.UserDirPath, err)
+	}
+
+	// Load the export and the local config
+	export, err := client.LoadConfigExport(path)
+	if err != nil {
+		return err
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+
+	// Update the local config first if Hyperdrive was updated, like `service config` does
+	oldVersion := strings.TrimPrefix(cfg.Hyperdrive.Version, "v")
+	currentVersion := strings.TrimPrefix(shared.HyperdriveVersion, "v")
+	isUpdate := !isNew && oldVersion != currentVersion
+	oldCfg := cfg
+	cfg = cfg.CreateCopy()
+	if isUpdate {
+		cfg.UpdateDefaults()
+	}
+
+	// Merge the export into it
+	warnings, err := cfg.Import(export)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Printf("%sWARNING: %s.%s\n", terminal.ColorYellow, warning, terminal.ColorReset)
+	}
+	errors := cfg.Validate()
+	if len(errors) > 0 {
+		return fmt.Errorf("the imported settings have errors:\n%s", strings.Join(errors, "\n"))
+	}
+
+	// Review the changes before saving
+	app := tview.NewApplication()
+	md := cliconfig.NewReviewDisplay(app, oldCfg, cfg, isNew, isUpdate)
+	err = app.Run()
+	if err != nil {
+		return err
+	}
+	if !md.ShouldSave {
+		fmt.Println("Your changes have not been saved. Your Hyperdrive configuration is the same as it was before.")
+		return nil
+	}
+	return saveConfigChanges(c, hd, md.PreviousConfig, md.Config, md.ChangeNetworks, md.ContainersToRestart, isNew)
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// JSON Schema, Draft 2020-12
+	configSchemaFormatJsonSchema string = "json-schema"
+)
+
+var (
+	configSchemaFormatFlag *cli.StringFlag = &cli.StringFlag{
+		Name:    "format",
+		Aliases: []string{"f"},
+		Usage:   fmt.Sprintf("The format of the schema to print. Supported formats: %s", configSchemaFormatJsonSchema),
+		Value:   configSchemaFormatJsonSchema,
+	}
+)
+
+// Print a schema of the user settings file that can be used to validate it
+func getConfigSchema(c *cli.Context) error {
+	format := c.String(configSchemaFormatFlag.Name)
+	if format != configSchemaFormatJsonSchema {
+		return fmt.Errorf("unsupported schema format [%s]; supported formats: %s", format, configSchemaFormatJsonSchema)
+	}
+
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Build the schema from a default config so it includes every network
+	cfg, err := hd.CreateDefaultConfig()
+	if err != nil {
+		return err
+	}
+	bytes, err := json.MarshalIndent(cfg.GetJsonSchema(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing configuration schema: %w", err)
+	}
+
+	fmt.Println(string(bytes))
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
 	"github.com/rivo/tview"
+	"github.com/rocket-pool/node-manager-core/config"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,6 +21,14 @@ var (
 		Aliases: []string{"u"},
 		Usage:   "Certain configuration values are reset when Hyperdrive is updated, such as Docker container tags; use this flag to force that reset, even if Hyperdrive hasn't been updated",
 	}
+	configWizardAnswersFlag *cli.StringFlag = &cli.StringFlag{
+		Name:  "wizard-answers",
+		Usage: "Run the configuration wizard without the TUI, answering each step from the provided answers file (made with --record-wizard-answers), then save the configuration",
+	}
+	configRecordWizardAnswersFlag *cli.StringFlag = &cli.StringFlag{
+		Name:  "record-wizard-answers",
+		Usage: "Save your answers to the configuration wizard to the provided file, so they can be replayed later with --wizard-answers",
+	}
 )
 
 // Configure the service
@@ -69,98 +78,145 @@ func configureService(c *cli.Context) error {
 		}
 	*/
 
+	// The wizard checks the free space where Docker keeps the chain data; it skips the check if Docker can't be reached
+	dockerRootDir, err := hd.GetDockerRootDir()
+	if err != nil {
+		dockerRootDir = ""
+	}
+
+	// Replay the wizard from an answers file instead of running the TUI
+	answersPath := c.String(configWizardAnswersFlag.Name)
+	if answersPath != "" {
+		return configureFromWizardAnswers(c, hd, oldCfg, cfg, dockerRootDir, isNew, isUpdate, answersPath)
+	}
+
 	// Run the TUI
 	app := tview.NewApplication()
-	md := cliconfig.NewMainDisplay(app, oldCfg, cfg, isNew, isUpdate)
+	md := cliconfig.NewMainDisplay(app, oldCfg, cfg, dockerRootDir, isNew, isUpdate)
 	err = app.Run()
 	if err != nil {
 		return err
 	}
 
+	// Save the wizard answers if requested
+	recordPath := c.String(configRecordWizardAnswersFlag.Name)
+	if recordPath != "" && md.ShouldSave {
+		answers := md.GetWizardAnswers()
+		if answers == nil {
+			fmt.Printf("%sThe configuration wizard wasn't run, so there are no answers to save to [%s].%s\n", terminal.ColorYellow, recordPath, terminal.ColorReset)
+		} else {
+			err = answers.Save(recordPath)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Saved your wizard answers to [%s]. You can replay them with `hyperdrive service config --%s %s`.\n", recordPath, configWizardAnswersFlag.Name, recordPath)
+		}
+	}
+
 	// Deal with saving the config and printing the changes
-	if md.ShouldSave {
-		// Save the config
-		err = hd.SaveConfig(md.Config)
+	if !md.ShouldSave {
+		fmt.Println("Your changes have not been saved. Your Hyperdrive configuration is the same as it was before.")
+		return nil
+	}
+	return saveConfigChanges(c, hd, md.PreviousConfig, md.Config, md.ChangeNetworks, md.ContainersToRestart, isNew)
+}
+
+// Save the config from the TUI, then handle network changes and restarting the affected containers
+func saveConfigChanges(c *cli.Context, hd *client.HyperdriveClient, previousConfig *client.GlobalConfig, cfg *client.GlobalConfig, changingNetworks bool, containersToRestart []config.ContainerID, isNew bool) error {
+	// Save the config
+	err := hd.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your changes have been saved!")
+
+	// Handle network changes
+	prefix := fmt.Sprint(previousConfig.Hyperdrive.ProjectName.Value)
+	if changingNetworks {
+		// Remove the checkpoint sync provider
+		cfg.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value = ""
+		err = hd.SaveConfig(cfg)
 		if err != nil {
 			return fmt.Errorf("error saving config: %w", err)
 		}
-		fmt.Println("Your changes have been saved!")
-
-		// Handle network changes
-		prefix := fmt.Sprint(md.PreviousConfig.Hyperdrive.ProjectName.Value)
-		if md.ChangeNetworks {
-			// Remove the checkpoint sync provider
-			md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value = ""
-			err = hd.SaveConfig(md.Config)
-			if err != nil {
-				return fmt.Errorf("error saving config: %w", err)
-			}
 
-			fmt.Printf("%sWARNING: You have requested to change networks.\n\nAll of your existing chain data, your node wallet, and your validator keys will be removed. If you had a Checkpoint Sync URL provided for your Beacon Node, it will be removed and you will need to specify a different one that supports the new network.\n\nPlease confirm you have backed up everything you want to keep, because it will be deleted if you answer `y` to the prompt below.\n\n%s", terminal.ColorYellow, terminal.ColorReset)
+		fmt.Printf("%sWARNING: You have requested to change networks.\n\nAll of your existing chain data, your node wallet, and your validator keys will be removed. If you had a Checkpoint Sync URL provided for your Beacon Node, it will be removed and you will need to specify a different one that supports the new network.\n\nPlease confirm you have backed up everything you want to keep, because it will be deleted if you answer `y` to the prompt below.\n\n%s", terminal.ColorYellow, terminal.ColorReset)
 
-			if !utils.Confirm("Would you like Hyperdrive to automatically switch networks for you? This will destroy and rebuild your `data` folder and all of Hyperdrive's Docker containers.") {
-				fmt.Println("Please clean up the data folder manually before proceeding.")
-				return nil
-			}
-
-			err = changeNetworks(c)
-			if err != nil {
-				fmt.Printf("%s%s%s\nHyperdrive could not automatically change networks for you, so you will have to remove your old data folder manually.\n", terminal.ColorRed, err.Error(), terminal.ColorReset)
-			}
+		if !utils.Confirm("Would you like Hyperdrive to automatically switch networks for you? This will destroy and rebuild your `data` folder and all of Hyperdrive's Docker containers.") {
+			fmt.Println("Please clean up the data folder manually before proceeding.")
 			return nil
 		}
 
-		// Query for service start if this is a new installation
-		if isNew {
-			if !utils.Confirm("Would you like to start the Hyperdrive services automatically now?") {
-				fmt.Println("Please run `hyperdrive service start` when you are ready to launch.")
-				return nil
-			}
-			return startService(c, StartMode_NoUpdate)
+		err = changeNetworks(c)
+		if err != nil {
+			fmt.Printf("%s%s%s\nHyperdrive could not automatically change networks for you, so you will have to remove your old data folder manually.\n", terminal.ColorRed, err.Error(), terminal.ColorReset)
 		}
+		return nil
+	}
 
-		// Query for service start if this is old and there are containers to change
-		if len(md.ContainersToRestart) > 0 {
-			fmt.Println("The following containers must be restarted for the changes to take effect:")
-			for _, container := range md.ContainersToRestart {
-				fmt.Printf("\t%s_%s\n", prefix, container)
-			}
-			if !utils.Confirm("Would you like to restart them automatically now?") {
-				fmt.Println("Please run `hyperdrive service start` when you are ready to apply the changes.")
-				return nil
-			}
+	// Query for service start if this is a new installation
+	if isNew {
+		if !utils.Confirm("Would you like to start the Hyperdrive services automatically now?") {
+			fmt.Println("Please run `hyperdrive service start` when you are ready to launch.")
+			return nil
+		}
+		return startService(c, StartMode_NoUpdate)
+	}
 
-			runningContainers, err := hd.GetRunningContainers(prefix)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: couldn't check running containers: %s\n", err.Error())
-				runningContainers = map[string]bool{}
-			}
-			for _, container := range md.ContainersToRestart {
-				fullName := fmt.Sprintf("%s_%s", prefix, container)
-				if !runningContainers[fullName] {
-					fmt.Printf("%s is not currently running.\n", fullName)
-				} else {
-					fmt.Printf("Stopping %s... ", fullName)
-					err := hd.StopContainer(fullName)
-					if err != nil {
-						fmt.Println("error!")
-						fmt.Fprintf(os.Stderr, "Error stopping container %s: %s\n", fullName, err.Error())
-						continue
-					}
-					fmt.Println("done!")
+	// Query for service start if this is old and there are containers to change
+	if len(containersToRestart) > 0 {
+		fmt.Println("The following containers must be restarted for the changes to take effect:")
+		for _, container := range containersToRestart {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+		if !utils.Confirm("Would you like to restart them automatically now?") {
+			fmt.Println("Please run `hyperdrive service start` when you are ready to apply the changes.")
+			return nil
+		}
+
+		runningContainers, err := hd.GetRunningContainers(prefix)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: couldn't check running containers: %s\n", err.Error())
+			runningContainers = map[string]bool{}
+		}
+		for _, container := range containersToRestart {
+			fullName := fmt.Sprintf("%s_%s", prefix, container)
+			if !runningContainers[fullName] {
+				fmt.Printf("%s is not currently running.\n", fullName)
+			} else {
+				fmt.Printf("Stopping %s... ", fullName)
+				err := hd.StopContainer(fullName)
+				if err != nil {
+					fmt.Println("error!")
+					fmt.Fprintf(os.Stderr, "Error stopping container %s: %s\n", fullName, err.Error())
+					continue
 				}
+				fmt.Println("done!")
 			}
-
-			fmt.Println()
-			fmt.Println("Applying changes and restarting containers...")
-			return startService(c, StartMode_NoUpdate)
 		}
-	} else {
-		fmt.Println("Your changes have not been saved. Your Hyperdrive configuration is the same as it was before.")
-		return nil
+
+		fmt.Println()
+		fmt.Println("Applying changes and restarting containers...")
+		return startService(c, StartMode_NoUpdate)
+	}
+	return nil
+}
+
+// Configure the service by replaying the config wizard from an answers file
+func configureFromWizardAnswers(c *cli.Context, hd *client.HyperdriveClient, oldCfg *client.GlobalConfig, cfg *client.GlobalConfig, dockerRootDir string, isNew bool, isUpdate bool, answersPath string) error {
+	answers, err := cliconfig.LoadWizardAnswers(answersPath)
+	if err != nil {
+		return err
+	}
+	md, unused, err := cliconfig.ReplayWizard(oldCfg, cfg, dockerRootDir, isNew, isUpdate, answers)
+	if err != nil {
+		return fmt.Errorf("error replaying wizard answers from [%s]: %w", answersPath, err)
+	}
+	for _, step := range unused {
+		fmt.Printf("%sWARNING: the answer for the [%s] step wasn't used because the wizard didn't show that step.%s\n", terminal.ColorYellow, step, terminal.ColorReset)
 	}
 
-	return err
+	return saveConfigChanges(c, hd, md.PreviousConfig, md.Config, md.ChangeNetworks, md.ContainersToRestart, isNew)
 }
 
 // TODO: HEADLESS MODE
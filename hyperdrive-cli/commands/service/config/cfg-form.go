@@ -53,6 +53,9 @@ func getTypedFormItem(param config.IParameter, descriptionBox *tview.TextView) *
 	if intParam, ok := param.(*config.Parameter[int]); ok {
 		return createParameterizedIntField(intParam)
 	}
+	if int64Param, ok := param.(*config.Parameter[int64]); ok {
+		return createParameterizedIntField(int64Param)
+	}
 	if uintParam, ok := param.(*config.Parameter[uint64]); ok {
 		return createParameterizedUintField(uintParam)
 	}
@@ -93,8 +96,8 @@ func createParameterizedCheckbox(param *config.Parameter[bool]) *parameterizedFo
 	}
 }
 
-// Create a standard int field
-func createParameterizedIntField(param *config.Parameter[int]) *parameterizedFormItem {
+// Create a standard signed integer field
+func createParameterizedIntField[IntType int | int64](param *config.Parameter[IntType]) *parameterizedFormItem {
 	item := tview.NewInputField().
 		SetLabel(param.Name).
 		SetAcceptanceFunc(tview.InputFieldInteger)
@@ -102,12 +105,12 @@ func createParameterizedIntField(param *config.Parameter[int]) *parameterizedFor
 		if key == tcell.KeyEscape {
 			item.SetText("")
 		} else {
-			value, err := strconv.ParseInt(item.GetText(), 0, 0)
-			if err != nil {
+			value, err := strconv.ParseInt(item.GetText(), 0, 64)
+			if err != nil || int64(IntType(value)) != value {
 				// TODO: show error modal?
 				item.SetText("")
 			} else {
-				param.Value = int(value)
+				param.Value = IntType(value)
 			}
 		}
 	})
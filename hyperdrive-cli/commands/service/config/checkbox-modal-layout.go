@@ -262,6 +262,7 @@ func (layout *checkBoxModalLayout) createControlGrid() int {
 func (layout *checkBoxModalLayout) generateCheckboxes(labels []string, descriptions []string, settings []bool) {
 
 	layout.form.Clear(true)
+	layout.checkboxes = map[string]*tview.Checkbox{}
 	layout.checkboxDescriptions = descriptions
 	layout.descriptionBox.SetText(descriptions[0])
 
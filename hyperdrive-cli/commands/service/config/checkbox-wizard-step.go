@@ -4,6 +4,7 @@ import "fmt"
 
 type checkBoxWizardStep struct {
 	wiz      *wizard
+	id       string
 	modal    *checkBoxModalLayout
 	showImpl func(*checkBoxModalLayout)
 }
@@ -12,6 +13,7 @@ func newCheckBoxStep(wiz *wizard, currentStep int, totalSteps int, helperText st
 
 	step := &checkBoxWizardStep{
 		wiz:      wiz,
+		id:       pageID,
 		showImpl: showImpl,
 	}
 
@@ -25,7 +27,13 @@ func newCheckBoxStep(wiz *wizard, currentStep int, totalSteps int, helperText st
 		helperText,
 	)
 
-	modal.done = done
+	modal.done = func(settings map[string]bool) {
+		wiz.recordAnswer(WizardAnswer{
+			Step:       pageID,
+			Checkboxes: settings,
+		})
+		done(settings)
+	}
 	modal.back = back
 	step.modal = modal
 
@@ -38,4 +46,7 @@ func newCheckBoxStep(wiz *wizard, currentStep int, totalSteps int, helperText st
 
 func (step *checkBoxWizardStep) show() {
 	step.showImpl(step.modal)
+	if step.wiz.replay != nil {
+		step.wiz.replay.answerCheckBoxStep(step)
+	}
 }
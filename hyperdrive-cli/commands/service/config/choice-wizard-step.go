@@ -4,6 +4,8 @@ import "fmt"
 
 type choiceWizardStep struct {
 	wiz      *wizard
+	id       string
+	labels   []string
 	modal    *choiceModalLayout
 	showImpl func(*choiceModalLayout)
 }
@@ -12,6 +14,8 @@ func newChoiceStep(wiz *wizard, currentStep int, totalSteps int, helperText stri
 
 	step := &choiceWizardStep{
 		wiz:      wiz,
+		id:       pageID,
+		labels:   names,
 		showImpl: showImpl,
 	}
 
@@ -28,7 +32,13 @@ func newChoiceStep(wiz *wizard, currentStep int, totalSteps int, helperText stri
 		direction,
 	)
 
-	modal.done = done
+	modal.done = func(buttonIndex int, buttonLabel string) {
+		wiz.recordAnswer(WizardAnswer{
+			Step:   pageID,
+			Choice: buttonLabel,
+		})
+		done(buttonIndex, buttonLabel)
+	}
 	modal.back = back
 	step.modal = modal
 
@@ -41,4 +51,7 @@ func newChoiceStep(wiz *wizard, currentStep int, totalSteps int, helperText stri
 
 func (step *choiceWizardStep) show() {
 	step.showImpl(step.modal)
+	if step.wiz.replay != nil {
+		step.wiz.replay.answerChoiceStep(step)
+	}
 }
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+// The most edits that can be undone
+const maxUndoDepth int = 100
+
+// A change to a single parameter's value
+type parameterChange struct {
+	param    config.IParameter
+	oldValue any
+	newValue any
+}
+
+// A group of parameter changes that are undone and redone together
+type configEdit struct {
+	changes []parameterChange
+}
+
+// A parameter in the working config paired with the same parameter in another copy of the config
+type parameterPair struct {
+	section    string
+	param      config.IParameter
+	otherParam config.IParameter
+}
+
+// Tracks the changes made to the working config so they can be undone and redone.
+// Rather than hooking every form item, it compares the config against a snapshot of its values before each key press and
+// records anything that changed as one edit, so changes made by the wizard, page callbacks, and network switches are all caught.
+type editHistory struct {
+	cfg       *client.GlobalConfig
+	params    []config.IParameter
+	values    []any
+	undoStack []*configEdit
+	redoStack []*configEdit
+}
+
+// Create a new edit history for the working config, starting with its current values
+func newEditHistory(cfg *client.GlobalConfig) *editHistory {
+	history := &editHistory{
+		cfg:       cfg,
+		undoStack: []*configEdit{},
+		redoStack: []*configEdit{},
+	}
+	for _, pair := range getParameterPairs(cfg, cfg, true) {
+		history.params = append(history.params, pair.param)
+	}
+	history.takeSnapshot()
+	return history
+}
+
+// Record any changes made since the last checkpoint as a new edit. Returns the edit, or nil if nothing changed.
+func (h *editHistory) checkpoint() *configEdit {
+	edit := &configEdit{}
+	for i, param := range h.params {
+		value := param.GetValueAsAny()
+		if value != h.values[i] {
+			edit.changes = append(edit.changes, parameterChange{
+				param:    param,
+				oldValue: h.values[i],
+				newValue: value,
+			})
+			h.values[i] = value
+		}
+	}
+	if len(edit.changes) == 0 {
+		return nil
+	}
+
+	h.undoStack = append(h.undoStack, edit)
+	if len(h.undoStack) > maxUndoDepth {
+		h.undoStack = h.undoStack[1:]
+	}
+	h.redoStack = []*configEdit{}
+	return edit
+}
+
+// Undo the latest edit. Returns the edit, or nil if there was nothing to undo.
+func (h *editHistory) undo() *configEdit {
+	h.checkpoint()
+	if len(h.undoStack) == 0 {
+		return nil
+	}
+	edit := h.undoStack[len(h.undoStack)-1]
+	h.undoStack = h.undoStack[:len(h.undoStack)-1]
+	h.apply(edit, true)
+	h.redoStack = append(h.redoStack, edit)
+	return edit
+}
+
+// Redo the latest edit that was undone. Returns the edit, or nil if there was nothing to redo.
+func (h *editHistory) redo() *configEdit {
+	h.checkpoint()
+	if len(h.redoStack) == 0 {
+		return nil
+	}
+	edit := h.redoStack[len(h.redoStack)-1]
+	h.redoStack = h.redoStack[:len(h.redoStack)-1]
+	h.apply(edit, false)
+	h.undoStack = append(h.undoStack, edit)
+	return edit
+}
+
+// Set a parameter to a new value as its own edit
+func (h *editHistory) setValue(param config.IParameter, value any) *configEdit {
+	h.checkpoint()
+	setParameterValue(h.cfg, param, value)
+	return h.checkpoint()
+}
+
+// Apply the old or new values of an edit to the config
+func (h *editHistory) apply(edit *configEdit, useOldValues bool) {
+	// Switch networks first since that changes other parameters too, which are then set explicitly
+	for _, change := range edit.changes {
+		if change.param == &h.cfg.Hyperdrive.Network {
+			setParameterValue(h.cfg, change.param, getChangeValue(change, useOldValues))
+		}
+	}
+	for _, change := range edit.changes {
+		if change.param != &h.cfg.Hyperdrive.Network {
+			change.param.SetValue(getChangeValue(change, useOldValues))
+		}
+	}
+	h.takeSnapshot()
+}
+
+// Store the current values of all of the parameters
+func (h *editHistory) takeSnapshot() {
+	h.values = make([]any, len(h.params))
+	for i, param := range h.params {
+		h.values[i] = param.GetValueAsAny()
+	}
+}
+
+// Get a short description of an edit's changes
+func (e *configEdit) String() string {
+	if len(e.changes) == 1 {
+		return e.changes[0].param.GetCommon().Name
+	}
+	return fmt.Sprintf("%d settings", len(e.changes))
+}
+
+// Get the value to set from a change
+func getChangeValue(change parameterChange, useOldValue bool) any {
+	if useOldValue {
+		return change.oldValue
+	}
+	return change.newValue
+}
+
+// Set a parameter in the working config, switching networks properly if it's the network parameter
+func setParameterValue(cfg *client.GlobalConfig, param config.IParameter, value any) {
+	if param == &cfg.Hyperdrive.Network {
+		cfg.ChangeNetwork(value.(config.Network))
+		return
+	}
+	param.SetValue(value)
+}
+
+// Get the saved version of a parameter in the working config, or nil if it can't be found
+func getSavedParameter(md *mainDisplay, param config.IParameter) config.IParameter {
+	for _, pair := range getParameterPairs(md.Config, md.PreviousConfig, true) {
+		if pair.param == param {
+			return pair.otherParam
+		}
+	}
+	return nil
+}
+
+// Pair up the parameters of a config with the same parameters in another copy of it, along with the title of the section they're in.
+// If includeDisabledModules is false, the parameters for modules that are disabled in both configs are skipped.
+func getParameterPairs(cfg *client.GlobalConfig, otherCfg *client.GlobalConfig, includeDisabledModules bool) []parameterPair {
+	pairs := []parameterPair{}
+	seen := map[config.IParameter]bool{}
+	pairs = addParameterPairs(cfg.Hyperdrive, otherCfg.Hyperdrive, "", pairs, seen)
+	if includeDisabledModules || cfg.StakeWise.Enabled.Value || otherCfg.StakeWise.Enabled.Value {
+		pairs = addParameterPairs(cfg.StakeWise, otherCfg.StakeWise, "", pairs, seen)
+	}
+	if includeDisabledModules || cfg.Constellation.Enabled.Value || otherCfg.Constellation.Enabled.Value {
+		pairs = addParameterPairs(cfg.Constellation, otherCfg.Constellation, "", pairs, seen)
+	}
+	pairs = addParameterPairs(cfg.Cli, otherCfg.Cli, "", pairs, seen)
+	return pairs
+}
+
+// Add the parameter pairs for a section and its subsections
+func addParameterPairs(section config.IConfigSection, otherSection config.IConfigSection, titlePrefix string, pairs []parameterPair, seen map[config.IParameter]bool) []parameterPair {
+	sectionName := section.GetTitle()
+	if titlePrefix != "" {
+		sectionName = fmt.Sprintf("%s > %s", titlePrefix, sectionName)
+	}
+
+	otherParams := otherSection.GetParameters()
+	for i, param := range section.GetParameters() {
+		if seen[param] {
+			continue
+		}
+		seen[param] = true
+		pairs = append(pairs, parameterPair{
+			section:    sectionName,
+			param:      param,
+			otherParam: otherParams[i],
+		})
+	}
+
+	// Go through the subsections in a consistent order
+	subconfigs := section.GetSubconfigs()
+	otherSubconfigs := otherSection.GetSubconfigs()
+	names := make([]string, 0, len(subconfigs))
+	for name := range subconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		otherSubconfig, exists := otherSubconfigs[name]
+		if !exists {
+			continue
+		}
+		pairs = addParameterPairs(subconfigs[name], otherSubconfig, sectionName, pairs, seen)
+	}
+	return pairs
+}
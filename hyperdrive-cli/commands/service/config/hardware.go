@@ -0,0 +1,341 @@
+package config
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/pbnjay/memory"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/utils/sys"
+)
+
+const (
+	// Memory used by everything other than the clients (the OS, daemons, validator clients), in GB
+	baseMemoryEstimate uint64 = 2
+
+	// The fewest CPU cores that can keep up with the resource-heavy clients
+	minHeavyClientCores int = 4
+
+	// The least memory, in GB, that should be left over after the clients are running before their caches are lowered
+	minMemoryHeadroom uint64 = 2
+
+	// The auto-prune threshold to use when the disk is tight, in GB
+	mainnetAutoPruneThreshold uint64 = 100
+	testnetAutoPruneThreshold uint64 = 25
+
+	// The memory limits and reservations to give the smaller containers, in MB
+	vcMemoryLimit       uint64 = 2048
+	vcMemoryReservation uint64 = 256
+	mevBoostMemoryLimit uint64 = 512
+
+	// The percentage of the memory left for the clients that the Execution client gets; the Beacon Node gets the rest
+	ecMemoryShare uint64 = 60
+
+	// The OOM score adjustment for the Execution client, so the kernel picks it before the containers that are slower to recover
+	ecOomScoreAdj int64 = 300
+)
+
+// The estimated disk space a client needs, in GB
+type diskEstimate struct {
+	mainnet uint64
+	testnet uint64
+}
+
+var (
+	// The estimated memory each Execution client uses, in GB
+	ecMemoryEstimates = map[config.ExecutionClient]uint64{
+		config.ExecutionClient_Geth:       6,
+		config.ExecutionClient_Nethermind: 7,
+		config.ExecutionClient_Besu:       8,
+		config.ExecutionClient_Reth:       8,
+	}
+
+	// The estimated memory each Beacon Node uses, in GB
+	bnMemoryEstimates = map[config.BeaconNode]uint64{
+		config.BeaconNode_Nimbus:     2,
+		config.BeaconNode_Lighthouse: 4,
+		config.BeaconNode_Prysm:      4,
+		config.BeaconNode_Lodestar:   5,
+		config.BeaconNode_Teku:       7,
+	}
+
+	// The estimated disk space each Execution client needs for a full node
+	ecDiskEstimates = map[config.ExecutionClient]diskEstimate{
+		config.ExecutionClient_Geth:       {mainnet: 1200, testnet: 300},
+		config.ExecutionClient_Nethermind: {mainnet: 1100, testnet: 300},
+		config.ExecutionClient_Besu:       {mainnet: 1100, testnet: 300},
+		config.ExecutionClient_Reth:       {mainnet: 1400, testnet: 350},
+	}
+
+	// The estimated disk space a Beacon Node needs
+	bnDiskEstimate = diskEstimate{mainnet: 250, testnet: 100}
+
+	// Clients that need a strong CPU to keep up
+	cpuHeavyEcs = map[config.ExecutionClient]bool{
+		config.ExecutionClient_Besu: true,
+	}
+	cpuHeavyBns = map[config.BeaconNode]bool{
+		config.BeaconNode_Teku: true,
+	}
+)
+
+// The machine's hardware, used to recommend clients and settings that will run well on it
+type hardwareProfile struct {
+	// Total RAM in GB, or 0 if it couldn't be read
+	totalMemoryGB uint64
+
+	// The number of logical CPU cores
+	cpuCores int
+
+	// The CPU architecture
+	arch string
+
+	// CPU features the "modern" client images need that this CPU doesn't have
+	missingCpuFeatures []string
+
+	// Docker's data directory, which holds the chain data, and where the free disk space was read from
+	dockerRootDir string
+
+	// Free disk space in Docker's data directory in GB, if hasDiskInfo is set
+	freeDiskGB  uint64
+	hasDiskInfo bool
+}
+
+// Read the machine's hardware, checking the free disk space in Docker's data directory if it's provided
+func getHardwareProfile(dockerRootDir string) *hardwareProfile {
+	profile := &hardwareProfile{
+		totalMemoryGB: memory.TotalMemory() / 1024 / 1024 / 1024,
+		cpuCores:      runtime.NumCPU(),
+		arch:          runtime.GOARCH,
+		dockerRootDir: dockerRootDir,
+	}
+	if profile.arch == "amd64" {
+		profile.missingCpuFeatures = sys.GetMissingModernCpuFeatures()
+	}
+	if dockerRootDir != "" {
+		freeSpace, err := client.GetPathFreeSpace(dockerRootDir)
+		if err == nil {
+			profile.freeDiskGB = freeSpace / 1024 / 1024 / 1024
+			profile.hasDiskInfo = true
+		}
+	}
+	return profile
+}
+
+// Get a description of the machine's hardware
+func (h *hardwareProfile) String() string {
+	parts := []string{}
+	if h.totalMemoryGB > 0 {
+		parts = append(parts, fmt.Sprintf("%d GB of RAM", h.totalMemoryGB))
+	}
+	parts = append(parts, fmt.Sprintf("%d CPU cores (%s)", h.cpuCores, h.arch))
+	if h.hasDiskInfo {
+		parts = append(parts, fmt.Sprintf("%d GB free for chain data at %s", h.freeDiskGB, h.dockerRootDir))
+	}
+	description := fmt.Sprintf("Detected hardware: %s.", strings.Join(parts, ", "))
+	if len(h.missingCpuFeatures) > 0 {
+		description += fmt.Sprintf("\nYour CPU doesn't support %s, so clients may need their \"portable\" images.", strings.Join(h.missingCpuFeatures, ", "))
+	}
+	return description
+}
+
+// Check if the CPU is too weak for the resource-heavy clients
+func (h *hardwareProfile) isCpuLimited() bool {
+	return h.arch == "arm64" || h.cpuCores < minHeavyClientCores
+}
+
+// Get the reason an Execution client is too heavy for this machine, or an empty string if it should run well.
+// If checkDisk is set, the free disk space is checked too.
+func (h *hardwareProfile) getEcProblem(ec config.ExecutionClient, network config.Network, checkDisk bool) string {
+	if cpuHeavyEcs[ec] && h.isCpuLimited() {
+		return "it needs a stronger CPU than this machine has"
+	}
+	if h.totalMemoryGB > 0 {
+		needed := ecMemoryEstimates[ec] + bnMemoryEstimates[config.BeaconNode_Nimbus] + baseMemoryEstimate
+		if needed > h.totalMemoryGB {
+			return fmt.Sprintf("it needs about %d GB of RAM alongside even the lightest Beacon Node, but this machine has %d GB", needed, h.totalMemoryGB)
+		}
+	}
+	if checkDisk && h.hasDiskInfo {
+		needed := getDiskEstimate(ecDiskEstimates[ec], network) + getDiskEstimate(bnDiskEstimate, network)
+		if needed > h.freeDiskGB {
+			return fmt.Sprintf("it needs about %d GB of disk space with a Beacon Node, but only %d GB is free", needed, h.freeDiskGB)
+		}
+	}
+	return ""
+}
+
+// Get the reason a Beacon Node is too heavy for this machine, or an empty string if it should run well
+func (h *hardwareProfile) getBnProblem(bn config.BeaconNode) string {
+	if cpuHeavyBns[bn] && h.isCpuLimited() {
+		return "it needs a stronger CPU than this machine has"
+	}
+	if h.totalMemoryGB > 0 {
+		needed := bnMemoryEstimates[bn] + ecMemoryEstimates[config.ExecutionClient_Geth] + baseMemoryEstimate
+		if needed > h.totalMemoryGB {
+			return fmt.Sprintf("it needs about %d GB of RAM alongside even the lightest Execution Client, but this machine has %d GB", needed, h.totalMemoryGB)
+		}
+	}
+	return ""
+}
+
+// Get the reason a pair of clients is too heavy to run together on this machine, or an empty string if they should run well
+func (h *hardwareProfile) getPairProblem(ec config.ExecutionClient, bn config.BeaconNode) string {
+	if cpuHeavyEcs[ec] && cpuHeavyBns[bn] && h.cpuCores < 2*minHeavyClientCores {
+		return fmt.Sprintf("both clients are CPU-heavy, and this machine only has %d CPU cores", h.cpuCores)
+	}
+	if h.totalMemoryGB > 0 {
+		needed := ecMemoryEstimates[ec] + bnMemoryEstimates[bn] + baseMemoryEstimate
+		if needed > h.totalMemoryGB {
+			return fmt.Sprintf("together they need about %d GB of RAM, but this machine has %d GB", needed, h.totalMemoryGB)
+		}
+	}
+	return ""
+}
+
+// Tune the settings of the selected local clients to fit this machine. Settings the user has changed from their defaults are left alone.
+// If checkDisk is set, the free disk space is taken into account too.
+// Returns a description of each recommendation.
+func (h *hardwareProfile) applyRecommendations(cfg *client.GlobalConfig, checkDisk bool) []string {
+	notes := []string{}
+	network := cfg.Hyperdrive.Network.Value
+	ec := cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value
+	bn := cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value
+	bnName := getBnName(cfg, bn)
+
+	// Lower the EC cache if the clients leave too little memory free
+	needed := ecMemoryEstimates[ec] + bnMemoryEstimates[bn] + baseMemoryEstimate
+	if h.totalMemoryGB > 0 && needed+minMemoryHeadroom > h.totalMemoryGB {
+		switch ec {
+		case config.ExecutionClient_Nethermind:
+			note := lowerCacheSize(&cfg.Hyperdrive.LocalExecutionClient.Nethermind.CacheSize, network, 512, bnName)
+			if note != "" {
+				notes = append(notes, "Nethermind's "+note)
+			}
+		case config.ExecutionClient_Reth:
+			note := lowerCacheSize(&cfg.Hyperdrive.LocalExecutionClient.Reth.CacheSize, network, 256, bnName)
+			if note != "" {
+				notes = append(notes, "Reth's "+note)
+			}
+		}
+	}
+
+	// Keep a memory spike in one container from taking down the others
+	notes = append(notes, h.recommendResources(cfg, ec, bn)...)
+
+	// Keep the disk from filling up if it's tight
+	if !checkDisk || !h.hasDiskInfo {
+		return notes
+	}
+	diskNeeded := getDiskEstimate(ecDiskEstimates[ec], network) + getDiskEstimate(bnDiskEstimate, network)
+	if h.freeDiskGB >= diskNeeded*3/2 {
+		return notes
+	}
+	if bn == config.BeaconNode_Nimbus && cfg.Hyperdrive.LocalBeaconClient.Nimbus.PruningMode.Value == config.Nimbus_PruningMode_Archive {
+		notes = append(notes, "Nimbus is set to archive mode, which needs much more disk space than you have free; consider switching it to pruned mode")
+	}
+	threshold := &cfg.Cli.EcPruning.FreeSpaceThreshold
+	if client.GetEcPruneMechanism(ec) != client.EcPruneMechanism_None && threshold.Value == threshold.GetDefault(network) && threshold.Value == 0 {
+		threshold.Value = testnetAutoPruneThreshold
+		if network == config.Network_Mainnet {
+			threshold.Value = mainnetAutoPruneThreshold
+		}
+		notes = append(notes, fmt.Sprintf("Automatic Execution Client pruning is set to start when free space drops below %d GB, since your disk doesn't have much room to spare (`hyperdrive service systemd install` sets up the daily check that runs it)", threshold.Value))
+	}
+	return notes
+}
+
+// Split the machine's memory between the containers with limits and reservations, leaving any that the user has already set alone.
+// Both Validator Clients are accounted for since the modules are chosen after this.
+// Returns a description of each recommendation.
+func (h *hardwareProfile) recommendResources(cfg *client.GlobalConfig, ec config.ExecutionClient, bn config.BeaconNode) []string {
+	notes := []string{}
+	if h.totalMemoryGB == 0 {
+		return notes
+	}
+	resources := cfg.Cli.Resources
+	totalMemory := h.totalMemoryGB * 1024
+	reserved := baseMemoryEstimate*1024 + 2*vcMemoryLimit + mevBoostMemoryLimit
+	ecEstimate := ecMemoryEstimates[ec] * 1024
+	bnEstimate := bnMemoryEstimates[bn] * 1024
+
+	// Split the rest between the clients, as long as they each get more than they normally use
+	if totalMemory > reserved && isResourcesUnset(resources.ExecutionClient) && isResourcesUnset(resources.BeaconNode) {
+		clientMemory := totalMemory - reserved
+		ecLimit := clientMemory * ecMemoryShare / 100
+		bnLimit := clientMemory - ecLimit
+		if ecLimit >= ecEstimate && bnLimit >= bnEstimate {
+			resources.ExecutionClient.MemoryLimit.Value = ecLimit
+			resources.ExecutionClient.MemoryReservation.Value = ecEstimate
+			resources.ExecutionClient.OomScoreAdj.Value = ecOomScoreAdj
+			resources.BeaconNode.MemoryLimit.Value = bnLimit
+			resources.BeaconNode.MemoryReservation.Value = bnEstimate
+			ecName := getEcName(cfg, ec)
+			notes = append(notes, fmt.Sprintf("%s's memory was limited to %d MB (with %d MB reserved) and %s's to %d MB (with %d MB reserved), so a memory spike in one of them restarts it instead of running the whole machine out of memory", ecName, ecLimit, ecEstimate, getBnName(cfg, bn), bnLimit, bnEstimate))
+			notes = append(notes, fmt.Sprintf("%s's OOM score adjustment was set to %d, so if the machine runs out of memory anyway, the kernel stops it before the containers that are slower to recover", ecName, ecOomScoreAdj))
+		}
+	}
+
+	// Give the smaller containers what they need
+	vcsLimited := false
+	for _, vc := range []*client.ContainerResourcesConfig{resources.StakeWiseValidator, resources.ConstellationValidator} {
+		if isResourcesUnset(vc) {
+			vc.MemoryLimit.Value = vcMemoryLimit
+			vc.MemoryReservation.Value = vcMemoryReservation
+			vcsLimited = true
+		}
+	}
+	if vcsLimited {
+		notes = append(notes, fmt.Sprintf("The Validator Clients' memory was limited to %d MB (with %d MB reserved)", vcMemoryLimit, vcMemoryReservation))
+	}
+	if isResourcesUnset(resources.MevBoost) {
+		resources.MevBoost.MemoryLimit.Value = mevBoostMemoryLimit
+		notes = append(notes, fmt.Sprintf("MEV-Boost's memory was limited to %d MB", mevBoostMemoryLimit))
+	}
+	return notes
+}
+
+// Check if none of a container's resource settings have been changed from their defaults
+func isResourcesUnset(resources *client.ContainerResourcesConfig) bool {
+	return resources.CpuLimit.Value == 0 && resources.MemoryLimit.Value == 0 && resources.MemoryReservation.Value == 0 && resources.OomScoreAdj.Value == 0
+}
+
+// Halve a cache size if it's still at its default, down to a minimum. Returns a description of the change, or an empty string if it wasn't changed.
+func lowerCacheSize(param *config.Parameter[uint64], network config.Network, minimum uint64, bnName string) string {
+	current := param.Value
+	if current != param.GetDefault(network) || current/2 < minimum {
+		return ""
+	}
+	param.Value = current / 2
+	return fmt.Sprintf("cache size was lowered from %d MB to %d MB to leave enough memory for %s", current, param.Value, bnName)
+}
+
+// Get the display name of an Execution Client
+func getEcName(cfg *client.GlobalConfig, ec config.ExecutionClient) string {
+	for _, option := range cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Options {
+		if option.Value == ec {
+			return strings.TrimPrefix(option.Name, "*")
+		}
+	}
+	return string(ec)
+}
+
+// Get the display name of a Beacon Node
+func getBnName(cfg *client.GlobalConfig, bn config.BeaconNode) string {
+	for _, option := range cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Options {
+		if option.Value == bn {
+			return strings.TrimPrefix(option.Name, "*")
+		}
+	}
+	return string(bn)
+}
+
+// Get the disk estimate for a network
+func getDiskEstimate(estimate diskEstimate, network config.Network) uint64 {
+	if network == config.Network_Mainnet {
+		return estimate.mainnet
+	}
+	return estimate.testnet
+}
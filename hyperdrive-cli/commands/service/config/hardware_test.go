@@ -0,0 +1,198 @@
+package config
+
+import (
+	"testing"
+
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCpuLimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile hardwareProfile
+		limited bool
+	}{
+		{"arm", hardwareProfile{arch: "arm64", cpuCores: 8}, true},
+		{"too few cores", hardwareProfile{arch: "amd64", cpuCores: 2}, true},
+		{"enough cores", hardwareProfile{arch: "amd64", cpuCores: 4}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.limited, test.profile.isCpuLimited())
+		})
+	}
+}
+
+func TestGetEcProblem(t *testing.T) {
+	tests := []struct {
+		name      string
+		profile   hardwareProfile
+		ec        config.ExecutionClient
+		network   config.Network
+		checkDisk bool
+		problem   string
+	}{
+		{"fits", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16}, config.ExecutionClient_Geth, config.Network_Mainnet, false, ""},
+		{"unknown memory", hardwareProfile{arch: "amd64", cpuCores: 8}, config.ExecutionClient_Besu, config.Network_Mainnet, false, ""},
+		{"weak CPU", hardwareProfile{arch: "arm64", cpuCores: 8, totalMemoryGB: 32}, config.ExecutionClient_Besu, config.Network_Mainnet, false, "stronger CPU"},
+		{"weak CPU with a light client", hardwareProfile{arch: "arm64", cpuCores: 8, totalMemoryGB: 32}, config.ExecutionClient_Geth, config.Network_Mainnet, false, ""},
+		{"not enough memory", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 8}, config.ExecutionClient_Geth, config.Network_Mainnet, false, "about 10 GB of RAM"},
+		{"not enough disk", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16, freeDiskGB: 1000, hasDiskInfo: true}, config.ExecutionClient_Geth, config.Network_Mainnet, true, "about 1450 GB of disk space"},
+		{"enough disk on a testnet", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16, freeDiskGB: 1000, hasDiskInfo: true}, config.ExecutionClient_Geth, config.Network_Holesky, true, ""},
+		{"disk not checked", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16, freeDiskGB: 1000, hasDiskInfo: true}, config.ExecutionClient_Geth, config.Network_Mainnet, false, ""},
+		{"disk unknown", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16}, config.ExecutionClient_Geth, config.Network_Mainnet, true, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problem := test.profile.getEcProblem(test.ec, test.network, test.checkDisk)
+			if test.problem == "" {
+				require.Empty(t, problem)
+				return
+			}
+			require.Contains(t, problem, test.problem)
+		})
+	}
+}
+
+func TestGetBnProblem(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile hardwareProfile
+		bn      config.BeaconNode
+		problem string
+	}{
+		{"fits", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16}, config.BeaconNode_Teku, ""},
+		{"weak CPU", hardwareProfile{arch: "amd64", cpuCores: 2, totalMemoryGB: 32}, config.BeaconNode_Teku, "stronger CPU"},
+		{"weak CPU with a light client", hardwareProfile{arch: "amd64", cpuCores: 2, totalMemoryGB: 32}, config.BeaconNode_Nimbus, ""},
+		{"not enough memory", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 12}, config.BeaconNode_Lodestar, "about 13 GB of RAM"},
+		{"Teku with less than 15 GB", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 14}, config.BeaconNode_Teku, "about 15 GB of RAM"},
+		{"unknown memory", hardwareProfile{arch: "amd64", cpuCores: 8}, config.BeaconNode_Lodestar, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problem := test.profile.getBnProblem(test.bn)
+			if test.problem == "" {
+				require.Empty(t, problem)
+				return
+			}
+			require.Contains(t, problem, test.problem)
+		})
+	}
+}
+
+func TestGetPairProblem(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile hardwareProfile
+		ec      config.ExecutionClient
+		bn      config.BeaconNode
+		problem string
+	}{
+		{"fits", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 16}, config.ExecutionClient_Geth, config.BeaconNode_Lighthouse, ""},
+		{"two CPU-heavy clients", hardwareProfile{arch: "amd64", cpuCores: 6, totalMemoryGB: 32}, config.ExecutionClient_Besu, config.BeaconNode_Teku, "only has 6 CPU cores"},
+		{"two CPU-heavy clients with enough cores", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 32}, config.ExecutionClient_Besu, config.BeaconNode_Teku, ""},
+		{"not enough memory", hardwareProfile{arch: "amd64", cpuCores: 8, totalMemoryGB: 12}, config.ExecutionClient_Geth, config.BeaconNode_Teku, "about 15 GB of RAM"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problem := test.profile.getPairProblem(test.ec, test.bn)
+			if test.problem == "" {
+				require.Empty(t, problem)
+				return
+			}
+			require.Contains(t, problem, test.problem)
+		})
+	}
+}
+
+func TestRecommendResources(t *testing.T) {
+	newConfig := func(t *testing.T) *client.GlobalConfig {
+		hdCfg, err := hdconfig.NewHyperdriveConfig(t.TempDir(), nil)
+		require.NoError(t, err)
+		return &client.GlobalConfig{
+			Hyperdrive: hdCfg,
+			Cli:        client.NewCliConfig(),
+		}
+	}
+
+	t.Run("split between the clients", func(t *testing.T) {
+		cfg := newConfig(t)
+		profile := hardwareProfile{totalMemoryGB: 32}
+		notes := profile.recommendResources(cfg, config.ExecutionClient_Geth, config.BeaconNode_Lighthouse)
+		require.Len(t, notes, 4)
+
+		// 32 GB minus 2 GB for the system, 2 GB for the VCs and 512 MB for MEV-Boost, split 60/40
+		resources := cfg.Cli.Resources
+		require.Equal(t, uint64(15667), resources.ExecutionClient.MemoryLimit.Value)
+		require.Equal(t, uint64(6144), resources.ExecutionClient.MemoryReservation.Value)
+		require.Equal(t, ecOomScoreAdj, resources.ExecutionClient.OomScoreAdj.Value)
+		require.Equal(t, uint64(10445), resources.BeaconNode.MemoryLimit.Value)
+		require.Equal(t, uint64(4096), resources.BeaconNode.MemoryReservation.Value)
+		require.Equal(t, vcMemoryLimit, resources.StakeWiseValidator.MemoryLimit.Value)
+		require.Equal(t, vcMemoryLimit, resources.ConstellationValidator.MemoryLimit.Value)
+		require.Equal(t, mevBoostMemoryLimit, resources.MevBoost.MemoryLimit.Value)
+	})
+
+	t.Run("too little memory to split", func(t *testing.T) {
+		cfg := newConfig(t)
+		profile := hardwareProfile{totalMemoryGB: 12}
+		notes := profile.recommendResources(cfg, config.ExecutionClient_Geth, config.BeaconNode_Lighthouse)
+		require.Len(t, notes, 2)
+		require.True(t, isResourcesUnset(cfg.Cli.Resources.ExecutionClient))
+		require.True(t, isResourcesUnset(cfg.Cli.Resources.BeaconNode))
+		require.Equal(t, vcMemoryLimit, cfg.Cli.Resources.StakeWiseValidator.MemoryLimit.Value)
+	})
+
+	t.Run("user settings left alone", func(t *testing.T) {
+		cfg := newConfig(t)
+		cfg.Cli.Resources.BeaconNode.CpuLimit.Value = 2
+		cfg.Cli.Resources.MevBoost.MemoryLimit.Value = 128
+		profile := hardwareProfile{totalMemoryGB: 32}
+		notes := profile.recommendResources(cfg, config.ExecutionClient_Geth, config.BeaconNode_Lighthouse)
+		require.Len(t, notes, 1) // Only the Validator Clients
+		require.True(t, isResourcesUnset(cfg.Cli.Resources.ExecutionClient))
+		require.Equal(t, uint64(0), cfg.Cli.Resources.BeaconNode.MemoryLimit.Value)
+		require.Equal(t, uint64(128), cfg.Cli.Resources.MevBoost.MemoryLimit.Value)
+	})
+
+	t.Run("unknown memory", func(t *testing.T) {
+		cfg := newConfig(t)
+		profile := hardwareProfile{}
+		require.Empty(t, profile.recommendResources(cfg, config.ExecutionClient_Geth, config.BeaconNode_Lighthouse))
+		require.True(t, isResourcesUnset(cfg.Cli.Resources.StakeWiseValidator))
+		require.True(t, isResourcesUnset(cfg.Cli.Resources.MevBoost))
+	})
+}
+
+func TestLowerCacheSize(t *testing.T) {
+	newParam := func(value uint64) *config.Parameter[uint64] {
+		return &config.Parameter[uint64]{
+			ParameterCommon: &config.ParameterCommon{},
+			Default:         map[config.Network]uint64{config.Network_All: 1024},
+			Value:           value,
+		}
+	}
+
+	param := newParam(1024)
+	require.NotEmpty(t, lowerCacheSize(param, config.Network_Mainnet, 512, "Lighthouse"))
+	require.Equal(t, uint64(512), param.Value)
+
+	// Changed by the user
+	param = newParam(2048)
+	require.Empty(t, lowerCacheSize(param, config.Network_Mainnet, 512, "Lighthouse"))
+	require.Equal(t, uint64(2048), param.Value)
+
+	// Already near the minimum
+	param = newParam(1024)
+	require.Empty(t, lowerCacheSize(param, config.Network_Mainnet, 768, "Lighthouse"))
+	require.Equal(t, uint64(1024), param.Value)
+}
+
+func TestGetDiskEstimate(t *testing.T) {
+	estimate := diskEstimate{mainnet: 1200, testnet: 300}
+	require.Equal(t, uint64(1200), getDiskEstimate(estimate, config.Network_Mainnet))
+	require.Equal(t, uint64(300), getDiskEstimate(estimate, config.Network_Holesky))
+}
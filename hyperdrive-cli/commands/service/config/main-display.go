@@ -19,6 +19,8 @@ type mainDisplay struct {
 	mainGrid            *tview.Grid
 	wizard              *wizard
 	settingsHome        *settingsHome
+	search              *settingsSearch
+	history             *editHistory
 	isNew               bool
 	isUpdate            bool
 	previousWidth       int
@@ -30,8 +32,8 @@ type mainDisplay struct {
 	ChangeNetworks      bool
 }
 
-// Creates a new MainDisplay instance.
-func NewMainDisplay(app *tview.Application, previousConfig *client.GlobalConfig, config *client.GlobalConfig, isNew bool, isUpdate bool) *mainDisplay {
+// Creates a new MainDisplay instance. The wizard checks the free disk space at dockerRootDir if it isn't empty.
+func NewMainDisplay(app *tview.Application, previousConfig *client.GlobalConfig, config *client.GlobalConfig, dockerRootDir string, isNew bool, isUpdate bool) *mainDisplay {
 	// Create a copy of the original config for comparison purposes
 	if previousConfig == nil {
 		previousConfig = config.CreateCopy()
@@ -104,7 +106,12 @@ func NewMainDisplay(app *tview.Application, previousConfig *client.GlobalConfig,
 
 	// Create all of the child elements
 	md.settingsHome = newSettingsHome(md)
-	md.wizard = newWizard(md)
+	md.wizard = newWizard(md, dockerRootDir)
+	md.search = newSettingsSearch(md)
+	md.history = newEditHistory(config)
+
+	// Set up the keys that work across the settings pages
+	md.app.SetInputCapture(md.handleGlobalKey)
 
 	// Set up the resize warning
 	md.app.SetBeforeDrawFunc(func(screen tcell.Screen) bool {
@@ -139,3 +146,177 @@ func (md *mainDisplay) setPage(page *page) {
 	md.navHeader.SetText(page.getHeader())
 	md.pages.SwitchToPage(page.id)
 }
+
+// Handle the search, undo / redo, and reset keys on the settings pages
+func (md *mainDisplay) handleGlobalKey(event *tcell.EventKey) *tcell.EventKey {
+	// Record whatever the previous key changed so it can be undone
+	md.history.checkpoint()
+
+	currentPage := md.getSearchablePage()
+	if currentPage == nil {
+		return event
+	}
+
+	switch event.Key() {
+	case tcell.KeyRune:
+		if event.Rune() != '/' {
+			return event
+		}
+		if _, isInputField := md.app.GetFocus().(*tview.InputField); isInputField {
+			return event
+		}
+		md.search.show(currentPage)
+
+	case tcell.KeyCtrlZ:
+		edit := md.history.undo()
+		md.refreshSettingsPages()
+		if edit != nil {
+			md.showEditStatus(fmt.Sprintf("Undid the change to %s.", edit))
+		}
+
+	case tcell.KeyCtrlY:
+		edit := md.history.redo()
+		md.refreshSettingsPages()
+		if edit != nil {
+			md.showEditStatus(fmt.Sprintf("Redid the change to %s.", edit))
+		}
+
+	case tcell.KeyCtrlD:
+		item := md.getFocusedFormItem()
+		if item == nil {
+			return event
+		}
+		param := item.parameter
+		network := md.Config.Hyperdrive.Network.Value
+		edit := md.history.setValue(param, param.GetDefaultAsAny(network))
+		md.refreshSettingsPages()
+		if edit != nil {
+			md.showEditStatus(fmt.Sprintf("Reset %s to its default value. Press Ctrl+Z to undo.", param.GetCommon().Name))
+		} else {
+			md.showEditStatus(fmt.Sprintf("%s is already set to its default value.", param.GetCommon().Name))
+		}
+
+	case tcell.KeyCtrlR:
+		item := md.getFocusedFormItem()
+		if item == nil {
+			return event
+		}
+		param := item.parameter
+		savedParam := getSavedParameter(md, param)
+		if savedParam == nil {
+			return nil
+		}
+		edit := md.history.setValue(param, savedParam.GetValueAsAny())
+		md.refreshSettingsPages()
+		if edit != nil {
+			md.showEditStatus(fmt.Sprintf("Reverted %s to its saved value. Press Ctrl+Z to undo.", param.GetCommon().Name))
+		} else {
+			md.showEditStatus(fmt.Sprintf("%s already has its saved value.", param.GetCommon().Name))
+		}
+
+	default:
+		return event
+	}
+	return nil
+}
+
+// Get the layout of the settings page currently on display, or nil if it isn't a settings page with a form
+func (md *mainDisplay) getCurrentLayout() *standardLayout {
+	currentID, _ := md.pages.GetFrontPage()
+	home := md.settingsHome
+	pages := []settingsPage{}
+	pages = append(pages, home.settingsSubpages...)
+	pages = append(pages, home.modulesPage.addonSubpages...)
+	for _, settingsPage := range pages {
+		searchablePage, ok := settingsPage.(searchableSettingsPage)
+		if ok && searchablePage.getPage().id == currentID {
+			return searchablePage.getLayout()
+		}
+	}
+	return nil
+}
+
+// Get the form item that has focus on the current settings page, or nil if there isn't one
+func (md *mainDisplay) getFocusedFormItem() *parameterizedFormItem {
+	layout := md.getCurrentLayout()
+	if layout == nil {
+		return nil
+	}
+	formItem, ok := md.app.GetFocus().(tview.FormItem)
+	if !ok {
+		return nil
+	}
+	return layout.parameters[formItem]
+}
+
+// Redraw all of the settings pages after the config was changed outside of their forms, keeping the focus on the current page
+func (md *mainDisplay) refreshSettingsPages() {
+	// Remember what had focus
+	layout := md.getCurrentLayout()
+	var focusedItem tview.FormItem
+	if item := md.getFocusedFormItem(); item != nil {
+		focusedItem = item.item
+	}
+
+	home := md.settingsHome
+	for _, settingsPage := range home.settingsSubpages {
+		settingsPage.handleLayoutChanged()
+	}
+	for _, settingsPage := range home.modulesPage.addonSubpages {
+		settingsPage.handleLayoutChanged()
+	}
+	if layout == nil {
+		return
+	}
+
+	// Restore the focus, or go to the top of the form if the item isn't shown anymore
+	focusIndex := 0
+	for i := 0; i < layout.form.GetFormItemCount(); i++ {
+		if layout.form.GetFormItem(i) == focusedItem {
+			focusIndex = i
+			break
+		}
+	}
+	layout.form.SetFocus(focusIndex)
+	md.app.SetFocus(layout.form)
+	if layout.form.GetFormItemCount() > 0 {
+		layout.showDescription(layout.form.GetFormItem(focusIndex))
+	}
+}
+
+// Show the result of an undo, redo, or reset in the current page's description box
+func (md *mainDisplay) showEditStatus(status string) {
+	layout := md.getCurrentLayout()
+	if layout == nil {
+		return
+	}
+	layout.descriptionBox.SetText(fmt.Sprintf("[green]%s[-]\n\n%s", tview.Escape(status), layout.descriptionBox.GetText(false)))
+	layout.descriptionBox.ScrollToBeginning()
+}
+
+// Get the page currently on display if the settings search can be opened from it, or nil if it can't
+func (md *mainDisplay) getSearchablePage() *page {
+	currentID, _ := md.pages.GetFrontPage()
+	home := md.settingsHome
+	if currentID == home.homePage.id {
+		return home.homePage
+	}
+	pages := []settingsPage{}
+	pages = append(pages, home.settingsSubpages...)
+	pages = append(pages, home.modulesPage.addonSubpages...)
+	for _, settingsPage := range pages {
+		if settingsPage.getPage().id == currentID {
+			return settingsPage.getPage()
+		}
+	}
+	return nil
+}
+
+// Creates a MainDisplay that opens on the review page, for reviewing changes that were made to the config outside of the TUI.
+func NewReviewDisplay(app *tview.Application, previousConfig *client.GlobalConfig, config *client.GlobalConfig, isNew bool, isUpdate bool) *mainDisplay {
+	// Skip the wizard even for new configs, since the changes are already made
+	md := NewMainDisplay(app, previousConfig, config, "", false, isUpdate)
+	md.isNew = isNew
+	md.settingsHome.showReviewPage()
+	return md
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/rivo/tview"
+)
+
+const (
+	// How long to wait after the last keystroke before probing an endpoint on the settings pages
+	probeDebounceTime time.Duration = 750 * time.Millisecond
+)
+
+// Format the result of probing an endpoint for display
+func formatProbe(label string, probe *client.ClientProbe) string {
+	switch {
+	case probe.IsOk():
+		return fmt.Sprintf("[green]OK - %s: %s[-]", label, tview.Escape(probe.Summary()))
+	case probe.Reachable:
+		return fmt.Sprintf("[orange]WARNING - %s: %s[-]", label, tview.Escape(probe.Summary()))
+	default:
+		return fmt.Sprintf("[red]ERROR - %s: %s[-]", label, tview.Escape(probe.Summary()))
+	}
+}
+
+// Combine several probe results into one display string and overall status
+func formatProbes(labels []string, probes []*client.ClientProbe) (string, bool) {
+	lines := []string{}
+	ok := true
+	for i, probe := range probes {
+		lines = append(lines, formatProbe(labels[i], probe))
+		ok = ok && probe.IsOk()
+	}
+	return strings.Join(lines, "\n"), ok
+}
+
+// Probe an Execution client's HTTP API against the selected network
+func probeEcHttp(cfg *client.GlobalConfig, url string) *client.ClientProbe {
+	resources := cfg.GetNetworkResources()
+	if resources == nil {
+		return &client.ClientProbe{Url: url, Problems: []string{"the selected network is unknown"}}
+	}
+	return client.ProbeExecutionClient(context.Background(), url, resources.ChainID)
+}
+
+// Probe a Beacon Node's HTTP API against the selected network
+func probeBnHttp(cfg *client.GlobalConfig, url string) *client.ClientProbe {
+	resources := cfg.GetNetworkResources()
+	if resources == nil {
+		return &client.ClientProbe{Url: url, Problems: []string{"the selected network is unknown"}}
+	}
+	return client.ProbeBeaconNode(context.Background(), url, resources.GenesisForkVersion)
+}
+
+// Probe an endpoint that only supports a connection check
+func probeTcp(url string) *client.ClientProbe {
+	return client.ProbeTcpEndpoint(context.Background(), url)
+}
+
+// Probes the endpoint entered into a settings page's form item in the background whenever it changes,
+// showing the result in the description box underneath the setting's description
+func (layout *standardLayout) addProbe(app *tview.Application, item *parameterizedFormItem, probe func(text string) *client.ClientProbe) {
+	inputField, ok := item.item.(*tview.InputField)
+	if !ok {
+		return
+	}
+	if layout.probeResults == nil {
+		layout.probeResults = map[tview.FormItem]string{}
+	}
+
+	label := item.parameter.GetCommon().Name
+	var timer *time.Timer
+	sequence := 0
+	inputField.SetChangedFunc(func(text string) {
+		// Restart the debounce timer
+		sequence++
+		current := sequence
+		if timer != nil {
+			timer.Stop()
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			delete(layout.probeResults, item.item)
+			return
+		}
+
+		layout.probeResults[item.item] = fmt.Sprintf("[yellow]Checking %s...[-]", label)
+		timer = time.AfterFunc(probeDebounceTime, func() {
+			result := formatProbe(label, probe(text))
+			app.QueueUpdateDraw(func() {
+				// Ignore results for text that has since changed
+				if current != sequence {
+					return
+				}
+				layout.probeResults[item.item] = result
+				if layout.isFocused(item.item) {
+					layout.showDescription(item.item)
+				}
+			})
+		})
+	})
+}
+
+// Check if the provided form item is the one that currently has focus
+func (layout *standardLayout) isFocused(formItem tview.FormItem) bool {
+	index, _ := layout.form.GetFocusedItemIndex()
+	return index >= 0 && index < layout.form.GetFormItemCount() && layout.form.GetFormItem(index) == formItem
+}
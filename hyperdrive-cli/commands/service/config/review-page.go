@@ -14,18 +14,23 @@ import (
 )
 
 // Constants
-const reviewPageID string = "review-settings"
+const (
+	reviewPageID string = "review-settings"
+
+	// The tallest the list of containers to restart can be before it starts scrolling
+	maxReviewRestartHeight int = 8
+)
 
 // The changed settings review page
 type ReviewPage struct {
-	md              *mainDisplay
-	changedSettings []*config.ChangedSection
-	page            *page
+	md            *mainDisplay
+	reviewChanges []parameterPair
+	page          *page
 }
 
 // Create a page to review any changes
 func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *client.GlobalConfig) *ReviewPage {
-	var changedSettings []*config.ChangedSection
+	width := 86
 	var totalAffectedContainers map[config.ContainerID]bool
 	var changeNetworks bool
 	var containersToRestart []config.ContainerID
@@ -34,19 +39,27 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 	changeBox := tview.NewTextView().
 		SetDynamicColors(true).
 		SetWordWrap(true)
-	changeBox.SetBorder(true)
 	changeBox.SetBackgroundColor(BackgroundColor)
-	changeBox.SetBorderPadding(0, 0, 1, 1)
+
+	// Create the list of individual changes that can be discarded
+	changeList := tview.NewList().
+		ShowSecondaryText(true).
+		SetSecondaryTextColor(tcell.ColorLightGray).
+		SetSelectedBackgroundColor(tcell.Color46).
+		SetSelectedTextColor(tcell.ColorBlack).
+		SetMainTextColor(tcell.ColorLightGray)
+	changeList.SetBackgroundColor(BackgroundColor)
 
 	builder := strings.Builder{}
 	errors := newConfig.Validate()
+	reviewChanges := []parameterPair{}
 	if len(errors) > 0 {
 		builder.WriteString("[orange]WARNING: Your configuration encountered errors. You must correct the following in order to save it:\n\n")
 		for _, err := range errors {
 			builder.WriteString(fmt.Sprintf("%s\n\n", err))
 		}
 	} else {
-		changedSettings, totalAffectedContainers, changeNetworks = newConfig.GetChanges(oldConfig)
+		_, totalAffectedContainers, changeNetworks = newConfig.GetChanges(oldConfig)
 
 		// Add changed containers if this is an update
 		if md.isUpdate {
@@ -54,11 +67,15 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 			builder.WriteString(fmt.Sprintf("Updated to Hyperdrive v%s (will affect several containers)\n\n", shared.HyperdriveVersion))
 		}
 
-		// Get the map of changed settings by section name
-		if len(changedSettings) > 0 {
-			for _, change := range changedSettings {
-				addChangesToDescription(change, "", &builder)
+		// List each of the changed settings
+		for _, pair := range getParameterPairs(newConfig, oldConfig, false) {
+			oldValue := pair.otherParam.String()
+			newValue := pair.param.String()
+			if oldValue == newValue {
+				continue
 			}
+			reviewChanges = append(reviewChanges, pair)
+			changeList.AddItem(fmt.Sprintf("%s: %s => %s", pair.param.GetCommon().Name, oldValue, newValue), "  "+pair.section, 0, nil)
 		}
 
 		// TEMP: Restart all of the module daemons if the HD daemon is being restarted
@@ -81,7 +98,7 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 		}
 
 		// Print the list of containers to restart
-		if builder.String() == "" {
+		if len(reviewChanges) == 0 && !md.isUpdate {
 			builder.WriteString("<No changes>")
 		} else {
 			builder.WriteString("The following containers must be restarted for these changes to take effect:")
@@ -91,15 +108,43 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 				containersToRestart = append(containersToRestart, container)
 			}
 		}
+
+		// Warn about resource limits that don't fit on this machine
+		for _, warning := range newConfig.CheckResourceCapacity() {
+			builder.WriteString(fmt.Sprintf("\n\n[orange]NOTE: %s[-]", tview.Escape(warning)))
+		}
 	}
 
 	changeBox.SetText(builder.String())
 
-	// Create the layout
-	width := 86
+	// Discard the selected change, putting the setting back to its saved value
+	discardChange := func() {
+		if len(reviewChanges) == 0 {
+			return
+		}
+		change := reviewChanges[changeList.GetCurrentItem()]
+		md.history.setValue(change.param, change.otherParam.GetValueAsAny())
+		md.refreshSettingsPages()
+		md.settingsHome.showReviewPage()
+	}
+
+	// Put the changes and the containers to restart together in one box
+	changePanel := tview.NewFlex().SetDirection(tview.FlexRow)
+	changePanel.SetBorder(true)
+	changePanel.SetBackgroundColor(BackgroundColor)
+	changePanel.SetBorderPadding(0, 0, 1, 1)
+	if len(reviewChanges) > 0 {
+		changeBoxHeight := len(tview.WordWrap(changeBox.GetText(true), width-6)) + 1
+		changePanel.
+			AddItem(changeList, 0, 1, false).
+			AddItem(tview.NewBox().SetBackgroundColor(BackgroundColor), 1, 0, false).
+			AddItem(changeBox, min(changeBoxHeight, maxReviewRestartHeight), 0, false)
+	} else {
+		changePanel.AddItem(changeBox, 0, 1, false)
+	}
 
 	// Create the main text view
-	descriptionText := "Please review your changes below.\nScroll through them using the arrow keys, and press Enter when you're ready to save them."
+	descriptionText := "Please review your changes below.\nSelect a change with the arrow keys and press Delete to discard it, or press Enter when you're ready to save them."
 	lines := tview.WordWrap(descriptionText, width-4)
 	textViewHeight := len(lines) + 1
 	textView := tview.NewTextView().
@@ -121,8 +166,16 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 		// Create the save button
 		saveButton := tview.NewButton("Save Settings")
 		saveButton.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-			if event.Key() == tcell.KeyUp || event.Key() == tcell.KeyDown {
-				changeBox.InputHandler()(event, nil)
+			switch event.Key() {
+			case tcell.KeyUp, tcell.KeyDown:
+				if len(reviewChanges) > 0 {
+					changeList.InputHandler()(event, nil)
+				} else {
+					changeBox.InputHandler()(event, nil)
+				}
+				return nil
+			case tcell.KeyDelete, tcell.KeyBackspace, tcell.KeyBackspace2:
+				discardChange()
 				return nil
 			}
 			return event
@@ -169,7 +222,7 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 		AddItem(spacer1, 0, 1, 1, 1, 0, 0, false).
 		AddItem(textView, 1, 1, 1, 1, 0, 0, false).
 		AddItem(spacer2, 2, 1, 1, 1, 0, 0, false).
-		AddItem(changeBox, 3, 1, 1, 1, 0, 0, false).
+		AddItem(changePanel, 3, 1, 1, 1, 0, 0, false).
 		AddItem(spacer3, 4, 1, 1, 1, 0, 0, false).
 		AddItem(buttonGrid, 5, 1, 1, 1, 0, 0, true).
 		AddItem(spacer4, 6, 1, 1, 1, 0, 0, false).
@@ -198,7 +251,7 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 	borderGrid.SetRows(1, 0, 1, 1, 1)
 
 	// Create the nav footer text view
-	navString1 := "Arrow keys: Navigate     Space/Enter: Select"
+	navString1 := "Arrow keys: Select a Change     Delete: Discard the Change     Enter: Save"
 	navTextView1 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -230,33 +283,8 @@ func NewReviewPage(md *mainDisplay, oldConfig *client.GlobalConfig, newConfig *c
 	page := newPage(nil, reviewPageID, "Review Settings", "", borderGrid)
 
 	return &ReviewPage{
-		md:              md,
-		changedSettings: changedSettings,
-		page:            page,
-	}
-}
-
-// Add all of the changed parameters to the description builder
-func addChangesToDescription(section *config.ChangedSection, titlePrefix string, description *strings.Builder) {
-	// Get the full section name, including the title
-	var sectionName string
-	if titlePrefix == "" {
-		sectionName = section.Name
-	} else {
-		sectionName = fmt.Sprintf("%s > %s", titlePrefix, section.Name)
-	}
-
-	// Handle the parameters
-	if len(section.Settings) > 0 {
-		fmt.Fprintf(description, "{%s}\n", sectionName)
-		for _, setting := range section.Settings {
-			fmt.Fprintf(description, "\t%s: %s => %s\n", setting.Name, setting.OldValue, setting.NewValue)
-		}
-		description.WriteString("\n")
-	}
-
-	// Handle the subsections
-	for _, subsection := range section.Subsections {
-		addChangesToDescription(subsection, sectionName, description)
+		md:            md,
+		reviewChanges: reviewChanges,
+		page:          page,
 	}
 }
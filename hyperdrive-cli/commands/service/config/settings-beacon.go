@@ -21,6 +21,8 @@ type BeaconConfigPage struct {
 	nimbusItems        []*parameterizedFormItem
 	prysmItems         []*parameterizedFormItem
 	tekuItems          []*parameterizedFormItem
+	checkpointItems    []*parameterizedFormItem
+	resourceItems      []*parameterizedFormItem
 	externalBnItems    []*parameterizedFormItem
 }
 
@@ -48,6 +50,11 @@ func (configPage *BeaconConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *BeaconConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Beacon Node settings page
 func (configPage *BeaconConfigPage) createContent() {
 	// Create the layout
@@ -66,6 +73,8 @@ func (configPage *BeaconConfigPage) createContent() {
 	configPage.prysmItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.LocalBeaconClient.Prysm.GetParameters(), configPage.layout.descriptionBox)
 	configPage.tekuItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.LocalBeaconClient.Teku.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalBnItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.ExternalBeaconClient.GetParameters(), configPage.layout.descriptionBox)
+	configPage.checkpointItems = createParameterizedFormItems(configPage.masterConfig.Cli.CheckpointSync.GetParameters(), configPage.layout.descriptionBox)
+	configPage.resourceItems = createParameterizedFormItems(configPage.masterConfig.Cli.Resources.BeaconNode.GetParameters(), configPage.layout.descriptionBox)
 
 	// Take the client selections out since they're done explicitly
 	localBnItems := []*parameterizedFormItem{}
@@ -86,6 +95,19 @@ func (configPage *BeaconConfigPage) createContent() {
 	}
 	configPage.externalBnItems = externalBnItems
 
+	// Check the external client in the background as its URLs are entered
+	app := configPage.home.md.app
+	for _, item := range configPage.externalBnItems {
+		switch item.parameter.GetCommon().ID {
+		case ids.HttpUrlID:
+			configPage.layout.addProbe(app, item, func(text string) *client.ClientProbe {
+				return probeBnHttp(configPage.masterConfig, text)
+			})
+		case ids.PrysmRpcUrlID:
+			configPage.layout.addProbe(app, item, probeTcp)
+		}
+	}
+
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.clientModeDropdown, configPage.localBnDropdown, configPage.externalBnDropdown)
 	configPage.layout.mapParameterizedFormItems(configPage.localBnItems...)
@@ -94,6 +116,8 @@ func (configPage *BeaconConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.nimbusItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.prysmItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.tekuItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.checkpointItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.resourceItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalBnItems...)
 
 	// Set up the setting callbacks
@@ -159,6 +183,8 @@ func (configPage *BeaconConfigPage) handleLocalBnChanged() {
 	case config.BeaconNode_Teku:
 		configPage.layout.addFormItemsWithCommonParams(configPage.localBnItems, configPage.tekuItems, nil)
 	}
+	configPage.layout.addFormItems(configPage.checkpointItems)
+	configPage.layout.addFormItems(configPage.resourceItems)
 
 	configPage.layout.refresh()
 }
@@ -17,6 +17,7 @@ type ConstellationConfigPage struct {
 
 	constellationItems []*parameterizedFormItem
 	vcCommonItems      []*parameterizedFormItem
+	resourceItems      []*parameterizedFormItem
 	lighthouseItems    []*parameterizedFormItem
 	lodestarItems      []*parameterizedFormItem
 	nimbusItems        []*parameterizedFormItem
@@ -50,6 +51,11 @@ func (configPage *ConstellationConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *ConstellationConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Constellation settings page
 func (configPage *ConstellationConfigPage) createContent() {
 
@@ -62,6 +68,7 @@ func (configPage *ConstellationConfigPage) createContent() {
 	configPage.enableConstellationBox = createParameterizedCheckbox(&configPage.masterConfig.Constellation.Enabled)
 	configPage.constellationItems = createParameterizedFormItems(configPage.masterConfig.Constellation.GetParameters(), configPage.layout.descriptionBox)
 	configPage.vcCommonItems = createParameterizedFormItems(configPage.masterConfig.Constellation.VcCommon.GetParameters(), configPage.layout.descriptionBox)
+	configPage.resourceItems = createParameterizedFormItems(configPage.masterConfig.Cli.Resources.ConstellationValidator.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lighthouseItems = createParameterizedFormItems(configPage.masterConfig.Constellation.Lighthouse.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lodestarItems = createParameterizedFormItems(configPage.masterConfig.Constellation.Lodestar.GetParameters(), configPage.layout.descriptionBox)
 	configPage.nimbusItems = createParameterizedFormItems(configPage.masterConfig.Constellation.Nimbus.GetParameters(), configPage.layout.descriptionBox)
@@ -72,6 +79,7 @@ func (configPage *ConstellationConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.enableConstellationBox)
 	configPage.layout.mapParameterizedFormItems(configPage.constellationItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.vcCommonItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.resourceItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lighthouseItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lodestarItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.nimbusItems...)
@@ -123,6 +131,7 @@ func (configPage *ConstellationConfigPage) handleLayoutChanged() {
 		case config.BeaconNode_Teku:
 			configPage.layout.addFormItems(configPage.tekuItems)
 		}
+		configPage.layout.addFormItems(configPage.resourceItems)
 	}
 
 	configPage.layout.refresh()
@@ -20,6 +20,8 @@ type ExecutionConfigPage struct {
 	nethermindItems    []*parameterizedFormItem
 	besuItems          []*parameterizedFormItem
 	rethItems          []*parameterizedFormItem
+	pruningItems       []*parameterizedFormItem
+	resourceItems      []*parameterizedFormItem
 	externalEcItems    []*parameterizedFormItem
 }
 
@@ -47,6 +49,11 @@ func (configPage *ExecutionConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *ExecutionConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Execution client settings page
 func (configPage *ExecutionConfigPage) createContent() {
 	// Create the layout
@@ -69,6 +76,8 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.besuItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.LocalExecutionClient.Besu.GetParameters(), configPage.layout.descriptionBox)
 	configPage.rethItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.LocalExecutionClient.Reth.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalEcItems = createParameterizedFormItems(configPage.masterConfig.Hyperdrive.ExternalExecutionClient.GetParameters(), configPage.layout.descriptionBox)
+	configPage.pruningItems = createParameterizedFormItems(configPage.masterConfig.Cli.EcPruning.GetParameters(), configPage.layout.descriptionBox)
+	configPage.resourceItems = createParameterizedFormItems(configPage.masterConfig.Cli.Resources.ExecutionClient.GetParameters(), configPage.layout.descriptionBox)
 
 	// Take the client selections out since they're done explicitly
 	localEcItems := []*parameterizedFormItem{}
@@ -89,6 +98,19 @@ func (configPage *ExecutionConfigPage) createContent() {
 	}
 	configPage.externalEcItems = externalEcItems
 
+	// Check the external client in the background as its URLs are entered
+	app := configPage.home.md.app
+	for _, item := range configPage.externalEcItems {
+		switch item.parameter.GetCommon().ID {
+		case ids.HttpUrlID:
+			configPage.layout.addProbe(app, item, func(text string) *client.ClientProbe {
+				return probeEcHttp(configPage.masterConfig, text)
+			})
+		case ids.ExternalEcWebsocketUrlID:
+			configPage.layout.addProbe(app, item, probeTcp)
+		}
+	}
+
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.clientModeDropdown, configPage.localEcDropdown, configPage.externalEcDropdown)
 	configPage.layout.mapParameterizedFormItems(configPage.localEcItems...)
@@ -96,6 +118,8 @@ func (configPage *ExecutionConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.nethermindItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.besuItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.rethItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.pruningItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.resourceItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalEcItems...)
 
 	// Set up the setting callbacks
@@ -160,6 +184,12 @@ func (configPage *ExecutionConfigPage) handleLocalEcChanged() {
 		configPage.layout.addFormItemsWithCommonParams(configPage.localEcItems, configPage.rethItems, nil)
 	}
 
+	// Add the pruning settings for clients that Hyperdrive can prune
+	if client.GetEcPruneMechanism(selectedEc) != client.EcPruneMechanism_None {
+		configPage.layout.addFormItems(configPage.pruningItems)
+	}
+	configPage.layout.addFormItems(configPage.resourceItems)
+
 	configPage.layout.refresh()
 }
 
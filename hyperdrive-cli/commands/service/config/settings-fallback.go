@@ -40,6 +40,11 @@ func (configPage *FallbackConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *FallbackConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the fallback client settings page
 func (configPage *FallbackConfigPage) createContent() {
 	// Create the layout
@@ -158,7 +158,7 @@ func (home *settingsHome) createContent() {
 func (home *settingsHome) createFooter() (tview.Primitive, int) {
 
 	// Nav bar
-	navString1 := "Arrow keys: Navigate             Space/Enter: Select"
+	navString1 := "Arrow keys: Navigate   Space/Enter: Select   /: Search"
 	navTextView1 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -169,7 +169,7 @@ func (home *settingsHome) createFooter() (tview.Primitive, int) {
 		AddItem(nil, 0, 1, false)
 	fmt.Fprint(navTextView1, navString1)
 
-	navString2 := "Tab: Go to the Buttons   Ctrl+C: Quit without Saving"
+	navString2 := "Tab: Go to the Buttons   Ctrl+Z: Undo   Ctrl+Y: Redo   Ctrl+C: Quit without Saving"
 	navTextView2 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -34,6 +34,11 @@ func (configPage *HyperdriveConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *HyperdriveConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Hyperdrive settings page
 func (configPage *HyperdriveConfigPage) createContent() {
 
@@ -64,6 +69,15 @@ func (configPage *HyperdriveConfigPage) createContent() {
 			})
 		}
 	}
+
+	// Add the container runtime, socket proxy, and image settings
+	runtimeParams := append(masterConfig.Cli.ContainerRuntime.GetParameters(), masterConfig.Cli.DockerSocketProxy.GetParameters()...)
+	runtimeParams = append(runtimeParams, masterConfig.Cli.Images.GetParameters()...)
+	runtimeItems := createParameterizedFormItems(runtimeParams, layout.descriptionBox)
+	for _, formItem := range runtimeItems {
+		layout.form.AddFormItem(formItem.item)
+		layout.parameters[formItem.item] = formItem
+	}
 	layout.refresh()
 
 }
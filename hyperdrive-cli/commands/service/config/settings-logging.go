@@ -37,6 +37,11 @@ func (configPage *LoggingConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *LoggingConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the logging settings page
 func (configPage *LoggingConfigPage) createContent() {
 	// Create the layout
@@ -48,6 +48,11 @@ func (configPage *MetricsConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *MetricsConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the monitoring / stats settings page
 func (configPage *MetricsConfigPage) createContent() {
 	// Create the layout
@@ -53,6 +53,11 @@ func (configPage *MevBoostConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *MevBoostConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the MEV-Boost settings page
 func (configPage *MevBoostConfigPage) createContent() {
 	// Create the layout
@@ -72,6 +77,7 @@ func (configPage *MevBoostConfigPage) createContent() {
 		&configPage.masterConfig.Hyperdrive.MevBoost.ContainerTag,
 		&configPage.masterConfig.Hyperdrive.MevBoost.AdditionalFlags,
 	}
+	localParams = append(localParams, configPage.masterConfig.Cli.Resources.MevBoost.GetParameters()...)
 	externalParams := []config.IParameter{&configPage.masterConfig.Hyperdrive.MevBoost.ExternalUrl}
 
 	configPage.localItems = createParameterizedFormItems(localParams, configPage.layout.descriptionBox)
@@ -0,0 +1,371 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const (
+	settingsSearchID string = "settings-search"
+
+	// The most results to show at once
+	maxSearchResults int = 100
+
+	// Scores for where a search term matched; higher scores are listed first
+	searchScoreNamePrefix    int = 150
+	searchScoreNameSubstring int = 100
+	searchScoreNameFuzzy     int = 40
+	searchScoreID            int = 30
+	searchScoreDescription   int = 10
+)
+
+// A parameter that can be found with the settings search
+type searchEntry struct {
+	settingsPage searchableSettingsPage
+	item         *parameterizedFormItem
+	location     string
+}
+
+// A search result and how well it matched
+type searchResult struct {
+	entry *searchEntry
+	score int
+}
+
+// The page for searching across all of the settings and jumping to one
+type settingsSearch struct {
+	md           *mainDisplay
+	page         *page
+	layout       *standardLayout
+	input        *tview.InputField
+	resultList   *tview.List
+	entries      []*searchEntry
+	results      []*searchEntry
+	previousPage *page
+}
+
+// Creates the settings search page and adds it to the main display
+func newSettingsSearch(md *mainDisplay) *settingsSearch {
+	search := &settingsSearch{
+		md: md,
+	}
+	search.createContent()
+	search.page = newPage(md.settingsHome.homePage, settingsSearchID, "Search", "", search.layout.grid)
+	md.pages.AddPage(search.page.id, search.page.content, true, false)
+	return search
+}
+
+// Create the search box, result list, and footer
+func (search *settingsSearch) createContent() {
+	layout := newStandardLayout()
+	search.layout = layout
+
+	// The search box
+	input := tview.NewInputField().
+		SetLabel("Search: ").
+		SetFieldBackgroundColor(tcell.ColorBlack)
+	input.SetBackgroundColor(BackgroundColor)
+	input.SetChangedFunc(func(text string) {
+		search.updateResults(text)
+	})
+	input.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			if len(search.results) > 0 {
+				search.jumpTo(search.results[search.resultList.GetCurrentItem()])
+			}
+		case tcell.KeyTab, tcell.KeyBacktab:
+			search.md.app.SetFocus(search.resultList)
+		case tcell.KeyEscape:
+			search.close()
+		}
+	})
+	input.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyDown && search.resultList.GetItemCount() > 0 {
+			search.md.app.SetFocus(search.resultList)
+			return nil
+		}
+		return event
+	})
+	search.input = input
+
+	// The results
+	resultList := tview.NewList().
+		ShowSecondaryText(true).
+		SetSecondaryTextColor(tcell.ColorLightGray).
+		SetChangedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+			if index < len(search.results) {
+				search.showDescription(search.results[index])
+			}
+		}).
+		SetSelectedFunc(func(index int, mainText, secondaryText string, shortcut rune) {
+			if index < len(search.results) {
+				search.jumpTo(search.results[index])
+			}
+		})
+	resultList.SetBackgroundColor(BackgroundColor)
+	resultList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			search.close()
+			return nil
+		case tcell.KeyTab, tcell.KeyBacktab:
+			search.md.app.SetFocus(search.input)
+			return nil
+		case tcell.KeyUp:
+			if search.resultList.GetCurrentItem() == 0 {
+				search.md.app.SetFocus(search.input)
+				return nil
+			}
+		case tcell.KeyRune:
+			// Typing while in the list goes back to the search box
+			search.md.app.SetFocus(search.input)
+			search.input.SetText(search.input.GetText() + string(event.Rune()))
+			return nil
+		}
+		return event
+	})
+	search.resultList = resultList
+
+	content := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(input, 1, 0, true).
+		AddItem(nil, 1, 0, false).
+		AddItem(resultList, 0, 1, false)
+	content.SetBackgroundColor(BackgroundColor)
+	layout.setContent(content, content.Box, "Search Settings")
+
+	// The footer
+	navString1 := "Type to search names, IDs, and descriptions   Up/Down: Select a Result"
+	navTextView1 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView1, navString1)
+
+	navString2 := "Enter: Go to the Setting   Tab: Switch Between Search and Results   Esc: Go Back"
+	navTextView2 := tview.NewTextView().
+		SetDynamicColors(false).
+		SetRegions(false).
+		SetWrap(false)
+	fmt.Fprint(navTextView2, navString2)
+
+	navBar := tview.NewFlex().
+		SetDirection(tview.FlexRow).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView1, len(navString1), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false).
+		AddItem(tview.NewFlex().
+			AddItem(tview.NewBox(), 0, 1, false).
+			AddItem(navTextView2, len(navString2), 1, false).
+			AddItem(tview.NewBox(), 0, 1, false),
+			1, 1, false)
+	layout.setFooter(navBar, 2)
+}
+
+// Open the search page, returning to the current page when it's closed
+func (search *settingsSearch) show(previousPage *page) {
+	if search.entries == nil {
+		search.entries = search.buildEntries()
+	}
+	search.previousPage = previousPage
+	search.input.SetText("")
+	search.updateResults("")
+	search.md.setPage(search.page)
+	search.md.app.SetFocus(search.input)
+}
+
+// Close the search page and go back to the page it was opened from
+func (search *settingsSearch) close() {
+	previousPage := search.previousPage
+	if previousPage == nil {
+		previousPage = search.md.settingsHome.homePage
+	}
+	search.md.setPage(previousPage)
+}
+
+// Collect the parameters from every settings page, including the modules
+func (search *settingsSearch) buildEntries() []*searchEntry {
+	home := search.md.settingsHome
+	pages := []settingsPage{}
+	pages = append(pages, home.settingsSubpages...)
+	pages = append(pages, home.modulesPage.addonSubpages...)
+
+	entries := []*searchEntry{}
+	for _, settingsPage := range pages {
+		searchablePage, ok := settingsPage.(searchableSettingsPage)
+		if !ok {
+			continue
+		}
+		location := getSearchLocation(searchablePage.getPage())
+		for _, item := range searchablePage.getLayout().parameters {
+			entries = append(entries, &searchEntry{
+				settingsPage: searchablePage,
+				item:         item,
+				location:     location,
+			})
+		}
+	}
+
+	// The parameters are stored in maps, so sort them for a stable order
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].location != entries[j].location {
+			return entries[i].location < entries[j].location
+		}
+		return entries[i].item.parameter.GetCommon().Name < entries[j].item.parameter.GetCommon().Name
+	})
+	return entries
+}
+
+// Update the result list to match the search text
+func (search *settingsSearch) updateResults(text string) {
+	search.resultList.Clear()
+	search.results = []*searchEntry{}
+	terms := strings.Fields(strings.ToLower(text))
+	if len(terms) == 0 {
+		search.layout.descriptionBox.SetText("Start typing to search for a setting by its name, ID, or description.")
+		return
+	}
+
+	// Score every entry against the search terms
+	results := []searchResult{}
+	for _, entry := range search.entries {
+		score := scoreSearchEntry(entry, terms)
+		if score > 0 {
+			results = append(results, searchResult{
+				entry: entry,
+				score: score,
+			})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].score > results[j].score
+	})
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+
+	// Show them
+	for _, result := range results {
+		search.results = append(search.results, result.entry)
+		search.resultList.AddItem(result.entry.item.parameter.GetCommon().Name, "  "+result.entry.location, 0, nil)
+	}
+	if len(search.results) == 0 {
+		search.layout.descriptionBox.SetText("No settings match your search.")
+		return
+	}
+	search.resultList.SetCurrentItem(0)
+	search.showDescription(search.results[0])
+}
+
+// Show the details of a search result in the description box
+func (search *settingsSearch) showDescription(entry *searchEntry) {
+	common := entry.item.parameter.GetCommon()
+	search.layout.descriptionBox.SetText(fmt.Sprintf("Location: %s\nID: %s\n\n%s", entry.location, common.ID, tview.Escape(common.Description)))
+	search.layout.descriptionBox.ScrollToBeginning()
+}
+
+// Go to the page with a search result and focus it
+func (search *settingsSearch) jumpTo(entry *searchEntry) {
+	// Redraw the page so its form matches the current settings
+	entry.settingsPage.handleLayoutChanged()
+	search.md.setPage(entry.settingsPage.getPage())
+
+	layout := entry.settingsPage.getLayout()
+	for i := 0; i < layout.form.GetFormItemCount(); i++ {
+		if layout.form.GetFormItem(i) == entry.item.item {
+			layout.form.SetFocus(i)
+			search.md.app.SetFocus(layout.form)
+			return
+		}
+	}
+
+	// The setting isn't shown with the current selections
+	layout.descriptionBox.SetText(fmt.Sprintf("[orange]%s isn't shown with your current settings. It depends on another setting on this page, such as which client you're using or whether a feature is enabled.[-]\n\n%s", entry.item.parameter.GetCommon().Name, tview.Escape(entry.item.parameter.GetCommon().Description)))
+	layout.descriptionBox.ScrollToBeginning()
+}
+
+// Get the navigation path to a page, without the root
+func getSearchLocation(settingsPage *page) string {
+	titles := []string{}
+	for p := settingsPage; p != nil && p.parent != nil; p = p.parent {
+		titles = append([]string{p.title}, titles...)
+	}
+	return strings.Join(titles, " > ")
+}
+
+// Score how well an entry matches the search terms. Every term has to match somewhere; returns 0 if one doesn't.
+func scoreSearchEntry(entry *searchEntry, terms []string) int {
+	common := entry.item.parameter.GetCommon()
+	name := strings.ToLower(common.Name)
+	id := strings.ToLower(common.ID)
+	description := strings.ToLower(common.Description)
+
+	total := 0
+	for _, term := range terms {
+		score := 0
+		switch {
+		case strings.HasPrefix(name, term):
+			score = searchScoreNamePrefix
+		case strings.Contains(name, term):
+			score = searchScoreNameSubstring
+		default:
+			score = fuzzyMatchScore(term, name)
+		}
+		if score == 0 && strings.Contains(id, term) {
+			score = searchScoreID
+		}
+		if score == 0 && strings.Contains(description, term) {
+			score = searchScoreDescription
+		}
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
+
+// Check if the characters of a term appear in order in the text, scoring tighter and word-aligned matches higher.
+// Returns 0 if they don't all appear.
+func fuzzyMatchScore(term string, text string) int {
+	termRunes := []rune(term)
+	textRunes := []rune(text)
+	termIndex := 0
+	firstMatch := -1
+	lastMatch := -1
+	wordStarts := 0
+	for i, r := range textRunes {
+		if termIndex == len(termRunes) {
+			break
+		}
+		if r != termRunes[termIndex] {
+			continue
+		}
+		if firstMatch == -1 {
+			firstMatch = i
+		}
+		if i == 0 || !unicode.IsLetter(textRunes[i-1]) && !unicode.IsDigit(textRunes[i-1]) {
+			wordStarts++
+		}
+		lastMatch = i
+		termIndex++
+	}
+	if termIndex < len(termRunes) {
+		return 0
+	}
+
+	// Penalize the characters skipped between the first and last match
+	gaps := (lastMatch - firstMatch + 1) - len(termRunes)
+	score := searchScoreNameFuzzy + wordStarts*5 - gaps
+	if score < 1 {
+		score = 1
+	}
+	return score
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyMatchScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		term  string
+		text  string
+		score int
+	}{
+		{"exact", "abc", "abc", searchScoreNameFuzzy + 5},
+		{"word starts", "mbr", "mev-boost relays", searchScoreNameFuzzy + 3*5 - 8},
+		{"missing character", "xyz", "mev-boost relays", 0},
+		{"out of order", "ba", "ab", 0},
+		{"long gap", "az", "a" + strings.Repeat("x", 100) + "z", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.score, fuzzyMatchScore(test.term, test.text))
+		})
+	}
+
+	// Tighter and word-aligned matches rank higher
+	require.Greater(t, fuzzyMatchScore("cs", "checkpoint sync"), fuzzyMatchScore("cs", "access"))
+	require.Greater(t, fuzzyMatchScore("prt", "port"), fuzzyMatchScore("prt", "p2p reconnect timeout"))
+}
+
+func TestScoreSearchEntry(t *testing.T) {
+	entry := &searchEntry{
+		item: &parameterizedFormItem{
+			parameter: &config.Parameter[uint16]{
+				ParameterCommon: &config.ParameterCommon{
+					ID:          "ecHttpPort",
+					Name:        "HTTP Port",
+					Description: "The port the Execution client serves its API on.",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		text  string
+		score int
+	}{
+		{"name prefix", "http", searchScoreNamePrefix},
+		{"name substring", "port", searchScoreNameSubstring},
+		{"case insensitive", "HTTP", searchScoreNamePrefix},
+		{"name fuzzy", "htp", searchScoreNameFuzzy + 5 - 1},
+		{"ID", "echttp", searchScoreID},
+		{"description", "execution", searchScoreDescription},
+		{"multiple terms", "http execution", searchScoreNamePrefix + searchScoreDescription},
+		{"one term missing", "http graffiti", 0},
+		{"no match", "graffiti", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			terms := strings.Fields(strings.ToLower(test.text))
+			require.Equal(t, test.score, scoreSearchEntry(entry, terms))
+		})
+	}
+}
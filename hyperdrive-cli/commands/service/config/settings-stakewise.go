@@ -17,6 +17,7 @@ type StakewiseConfigPage struct {
 
 	stakewiseItems  []*parameterizedFormItem
 	vcCommonItems   []*parameterizedFormItem
+	resourceItems   []*parameterizedFormItem
 	lighthouseItems []*parameterizedFormItem
 	lodestarItems   []*parameterizedFormItem
 	nimbusItems     []*parameterizedFormItem
@@ -50,6 +51,11 @@ func (configPage *StakewiseConfigPage) getPage() *page {
 	return configPage.page
 }
 
+// Get the layout with the page's settings form
+func (configPage *StakewiseConfigPage) getLayout() *standardLayout {
+	return configPage.layout
+}
+
 // Creates the content for the Stakewise settings page
 func (configPage *StakewiseConfigPage) createContent() {
 
@@ -62,6 +68,7 @@ func (configPage *StakewiseConfigPage) createContent() {
 	configPage.enableStakewiseBox = createParameterizedCheckbox(&configPage.masterConfig.StakeWise.Enabled)
 	configPage.stakewiseItems = createParameterizedFormItems(configPage.masterConfig.StakeWise.GetParameters(), configPage.layout.descriptionBox)
 	configPage.vcCommonItems = createParameterizedFormItems(configPage.masterConfig.StakeWise.VcCommon.GetParameters(), configPage.layout.descriptionBox)
+	configPage.resourceItems = createParameterizedFormItems(configPage.masterConfig.Cli.Resources.StakeWiseValidator.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lighthouseItems = createParameterizedFormItems(configPage.masterConfig.StakeWise.Lighthouse.GetParameters(), configPage.layout.descriptionBox)
 	configPage.lodestarItems = createParameterizedFormItems(configPage.masterConfig.StakeWise.Lodestar.GetParameters(), configPage.layout.descriptionBox)
 	configPage.nimbusItems = createParameterizedFormItems(configPage.masterConfig.StakeWise.Nimbus.GetParameters(), configPage.layout.descriptionBox)
@@ -72,6 +79,7 @@ func (configPage *StakewiseConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.enableStakewiseBox)
 	configPage.layout.mapParameterizedFormItems(configPage.stakewiseItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.vcCommonItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.resourceItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lighthouseItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.lodestarItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.nimbusItems...)
@@ -123,6 +131,7 @@ func (configPage *StakewiseConfigPage) handleLayoutChanged() {
 		case config.BeaconNode_Teku:
 			configPage.layout.addFormItems(configPage.tekuItems)
 		}
+		configPage.layout.addFormItems(configPage.resourceItems)
 	}
 
 	configPage.layout.refresh()
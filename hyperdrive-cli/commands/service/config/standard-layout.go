@@ -16,6 +16,10 @@ type standardLayout struct {
 	footer         tview.Primitive
 	form           *Form
 	parameters     map[tview.FormItem]*parameterizedFormItem
+	networkParam   *config.Parameter[config.Network]
+
+	// The latest endpoint probe results for form items that have them
+	probeResults map[tview.FormItem]string
 }
 
 // Creates a new StandardLayout instance, which includes the grid and description box preconstructed.
@@ -80,19 +84,10 @@ func (layout *standardLayout) createForm(networkParam *config.Parameter[config.N
 		SetBorderPadding(0, 0, 0, 0)
 
 	// Set up the selected parameter change callback to update the description box
+	layout.networkParam = networkParam
 	form.SetChangedFunc(func(index int) {
 		if index < form.GetFormItemCount() {
-			formItem := form.GetFormItem(index)
-			param := layout.parameters[formItem].parameter
-			defaultValue := param.GetDefaultAsAny(networkParam.Value)
-			networkDescription, exists := param.GetCommon().DescriptionsByNetwork[networkParam.Value]
-			if !exists {
-				// Use the default description if there isn't a specific one for the network
-				networkDescription = param.GetCommon().Description
-			}
-			descriptionText := fmt.Sprintf("Default: %v\n\n%s", defaultValue, networkDescription)
-			layout.descriptionBox.SetText(descriptionText)
-			layout.descriptionBox.ScrollToBeginning()
+			layout.showDescription(form.GetFormItem(index))
 		}
 	})
 
@@ -101,6 +96,23 @@ func (layout *standardLayout) createForm(networkParam *config.Parameter[config.N
 	layout.createSettingFooter()
 }
 
+// Shows the description of a form item's parameter in the description box
+func (layout *standardLayout) showDescription(formItem tview.FormItem) {
+	param := layout.parameters[formItem].parameter
+	defaultValue := param.GetDefaultAsAny(layout.networkParam.Value)
+	networkDescription, exists := param.GetCommon().DescriptionsByNetwork[layout.networkParam.Value]
+	if !exists {
+		// Use the default description if there isn't a specific one for the network
+		networkDescription = param.GetCommon().Description
+	}
+	descriptionText := fmt.Sprintf("Default: %v\n\n%s", defaultValue, networkDescription)
+	if probeResult, exists := layout.probeResults[formItem]; exists {
+		descriptionText += "\n\n" + probeResult
+	}
+	layout.descriptionBox.SetText(descriptionText)
+	layout.descriptionBox.ScrollToBeginning()
+}
+
 // Refreshes all of the form items to show the current configured values
 func (layout *standardLayout) refresh() {
 	for i := 0; i < layout.form.GetFormItemCount(); i++ {
@@ -131,14 +143,14 @@ func (layout *standardLayout) refresh() {
 // Create the footer, including the nav bar
 func (layout *standardLayout) createSettingFooter() {
 	// Nav bar
-	navString1 := "Arrow keys: Navigate   Space/Enter: Change Setting"
+	navString1 := "Arrow keys: Navigate   Space/Enter: Change Setting   Ctrl+D: Reset to Default   Ctrl+R: Revert to Saved"
 	navTextView1 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
 		SetWrap(false)
 	fmt.Fprint(navTextView1, navString1)
 
-	navString2 := "Esc: Go Back to Categories"
+	navString2 := "Ctrl+Z: Undo   Ctrl+Y: Redo   /: Search All Settings   Esc: Go Back to Categories"
 	navTextView2 := tview.NewTextView().
 		SetDynamicColors(false).
 		SetRegions(false).
@@ -1,17 +1,30 @@
 package config
 
+import (
+	"context"
+	"time"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+)
+
+// How long to wait for the checkpoint sync providers to respond before giving up on them
+const checkpointVerifyTimeout time.Duration = 30 * time.Second
+
 func createCheckpointSyncStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
 
 	// Create the labels and args
 	checkpointSyncLabel := wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Name
+	additionalProvidersLabel := wiz.md.Config.Cli.CheckpointSync.AdditionalProviders.Name
 
-	helperText := "Your client supports Checkpoint Sync. This powerful feature allows it to copy the most recent state from a separate Beacon Node that you trust, so you don't have to wait for it to sync from scratch - you can start using it instantly!\n\nTake a look at our documentation for an example of how to use it at https://docs.nodeset.io.\n\nIf you would like to use Checkpoint Sync, please provide the provider URL here. If you don't want to use it, leave it blank."
+	helperText := "Your client supports Checkpoint Sync. This powerful feature allows it to copy the most recent state from a separate Beacon Node that you trust, so you don't have to wait for it to sync from scratch - you can start using it instantly!\n\nTake a look at our documentation for an example of how to use it at https://docs.nodeset.io.\n\nIf you would like to use Checkpoint Sync, please provide the provider URL here. If you don't want to use it, leave it blank.\n\nYou can also list other providers you trust, separated by commas. Hyperdrive will make sure they all agree on the checkpoint."
 
+	verifying := false
 	show := func(modal *textBoxModalLayout) {
 		wiz.md.setPage(modal.page)
 		modal.focus()
+		params := append(wiz.md.Config.Hyperdrive.LocalBeaconClient.GetParameters(), wiz.md.Config.Cli.CheckpointSync.GetParameters()...)
 		for label, box := range modal.textboxes {
-			for _, param := range wiz.md.Config.Hyperdrive.LocalBeaconClient.GetParameters() {
+			for _, param := range params {
 				if param.GetCommon().Name == label {
 					box.SetText(param.String())
 				}
@@ -21,7 +34,40 @@ func createCheckpointSyncStep(wiz *wizard, currentStep int, totalSteps int) *tex
 
 	done := func(text map[string]string) {
 		wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value = text[checkpointSyncLabel]
-		wiz.useFallbackModal.show()
+		wiz.md.Config.Cli.CheckpointSync.AdditionalProviders.Value = text[additionalProvidersLabel]
+
+		// Cross-check the providers if there's more than one
+		sources := client.GetCheckpointSources(wiz.md.Config)
+		if wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value == "" || len(sources) < 2 {
+			wiz.useFallbackModal.show()
+			return
+		}
+		showResult := func(verification *client.CheckpointVerification) {
+			if !verification.IsVerified() {
+				wiz.checkpointSyncMismatchModal.show()
+				return
+			}
+			wiz.useFallbackModal.show()
+		}
+
+		// Replays don't run the UI, so there's nothing to block
+		if wiz.replay != nil {
+			showResult(verifyCheckpointSources(sources))
+			return
+		}
+
+		// Query the providers in the background so the UI stays responsive
+		if verifying {
+			return
+		}
+		verifying = true
+		go func() {
+			verification := verifyCheckpointSources(sources)
+			wiz.md.app.QueueUpdateDraw(func() {
+				verifying = false
+				showResult(verification)
+			})
+		}()
 	}
 
 	back := func() {
@@ -35,9 +81,9 @@ func createCheckpointSyncStep(wiz *wizard, currentStep int, totalSteps int) *tex
 		helperText,
 		76,
 		"Beacon Node > Checkpoint Sync",
-		[]string{checkpointSyncLabel},
-		[]int{wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.MaxLength},
-		[]string{wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Regex},
+		[]string{checkpointSyncLabel, additionalProvidersLabel},
+		[]int{wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.MaxLength, wiz.md.Config.Cli.CheckpointSync.AdditionalProviders.MaxLength},
+		[]string{wiz.md.Config.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Regex, wiz.md.Config.Cli.CheckpointSync.AdditionalProviders.Regex},
 		show,
 		done,
 		back,
@@ -45,3 +91,47 @@ func createCheckpointSyncStep(wiz *wizard, currentStep int, totalSteps int) *tex
 	)
 
 }
+
+// Cross-check the checkpoint sync providers, giving up on any that haven't responded before the timeout
+func verifyCheckpointSources(sources []client.CheckpointSource) *client.CheckpointVerification {
+	ctx, cancel := context.WithTimeout(context.Background(), checkpointVerifyTimeout)
+	defer cancel()
+	return client.VerifyCheckpoint(ctx, sources)
+}
+
+func createCheckpointSyncMismatchStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
+	helperText := "[orange]WARNING: Your checkpoint sync providers couldn't be verified. Either they disagree on the latest finalized checkpoint, or some of them didn't respond. One of them may be malicious, stale, or on the wrong network.\n\nYou can run `hyperdrive service verify-checkpoint` after saving to see the details."
+
+	show := func(modal *choiceModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus(0)
+	}
+
+	done := func(buttonIndex int, buttonLabel string) {
+		if buttonIndex == 0 {
+			wiz.checkpointSyncProviderModal.show()
+		} else {
+			wiz.useFallbackModal.show()
+		}
+	}
+
+	back := func() {
+		wiz.checkpointSyncProviderModal.show()
+	}
+
+	return newChoiceStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		[]string{"Change Providers", "Continue Anyway"},
+		[]string{},
+		76,
+		"Beacon Node > Checkpoint Sync",
+		DirectionalModalHorizontal,
+		show,
+		done,
+		back,
+		"step-checkpoint-sync-mismatch",
+	)
+}
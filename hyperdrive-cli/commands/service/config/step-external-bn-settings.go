@@ -1,5 +1,7 @@
 package config
 
+import "github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+
 func createExternalBnSettingsStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
 	// Create the labels
 	httpUrlLabel := wiz.md.Config.Hyperdrive.ExternalBeaconClient.HttpUrl.Name
@@ -27,7 +29,7 @@ func createExternalBnSettingsStep(wiz *wizard, currentStep int, totalSteps int)
 		wiz.externalBnSelectModal.show()
 	}
 
-	return newTextBoxWizardStep(
+	step := newTextBoxWizardStep(
 		wiz,
 		currentStep,
 		totalSteps,
@@ -42,4 +44,13 @@ func createExternalBnSettingsStep(wiz *wizard, currentStep int, totalSteps int)
 		back,
 		"step-external-bn-settings",
 	)
+
+	// Check the client before moving on
+	step.modal.setProbe(func(text map[string]string) (string, bool) {
+		return formatProbes(
+			[]string{httpUrlLabel},
+			[]*client.ClientProbe{probeBnHttp(wiz.md.Config, text[httpUrlLabel])},
+		)
+	})
+	return step
 }
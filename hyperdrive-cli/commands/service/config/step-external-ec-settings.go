@@ -1,5 +1,7 @@
 package config
 
+import "github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+
 func createExternalEcSettingsStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
 	// Create the labels
 	httpLabel := wiz.md.Config.Hyperdrive.ExternalExecutionClient.HttpUrl.Name
@@ -29,7 +31,7 @@ func createExternalEcSettingsStep(wiz *wizard, currentStep int, totalSteps int)
 		wiz.externalEcSelectModal.show()
 	}
 
-	return newTextBoxWizardStep(
+	step := newTextBoxWizardStep(
 		wiz,
 		currentStep,
 		totalSteps,
@@ -44,4 +46,13 @@ func createExternalEcSettingsStep(wiz *wizard, currentStep int, totalSteps int)
 		back,
 		"step-ec-external-settings",
 	)
+
+	// Check the client before moving on
+	step.modal.setProbe(func(text map[string]string) (string, bool) {
+		return formatProbes(
+			[]string{httpLabel, wsLabel},
+			[]*client.ClientProbe{probeEcHttp(wiz.md.Config, text[httpLabel]), probeTcp(text[wsLabel])},
+		)
+	})
+	return step
 }
@@ -1,5 +1,7 @@
 package config
 
+import "github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+
 func createExternalPrysmSettingsStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
 	// Create the labels
 	httpUrlLabel := wiz.md.Config.Hyperdrive.ExternalBeaconClient.HttpUrl.Name
@@ -29,7 +31,7 @@ func createExternalPrysmSettingsStep(wiz *wizard, currentStep int, totalSteps in
 		wiz.externalBnSelectModal.show()
 	}
 
-	return newTextBoxWizardStep(
+	step := newTextBoxWizardStep(
 		wiz,
 		currentStep,
 		totalSteps,
@@ -44,4 +46,13 @@ func createExternalPrysmSettingsStep(wiz *wizard, currentStep int, totalSteps in
 		back,
 		"step-external-prysm-settings",
 	)
+
+	// Check the client before moving on
+	step.modal.setProbe(func(text map[string]string) (string, bool) {
+		return formatProbes(
+			[]string{httpUrlLabel, jsonRpcUrlLabel},
+			[]*client.ClientProbe{probeBnHttp(wiz.md.Config, text[httpUrlLabel]), probeTcp(text[jsonRpcUrlLabel])},
+		)
+	})
+	return step
 }
@@ -48,7 +48,7 @@ func createFinishedStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiz
 		helperText,
 		[]string{
 			"Review All Settings",
-			"Save and Exit",
+			finishedSaveLabel,
 		},
 		nil,
 		40,
@@ -57,7 +57,7 @@ func createFinishedStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiz
 		show,
 		done,
 		back,
-		"step-finished",
+		finishedStepID,
 	)
 }
 
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const hardwareStepID string = "step-hardware"
+
+// Tune the selected local clients to the machine's hardware, and show what was changed or why the clients are too heavy if needed
+func (wiz *wizard) showHardwareStep(currentStep int, totalSteps int) {
+	cfg := wiz.md.Config
+	ec := cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value
+	bn := cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value
+	notes := wiz.hardware.applyRecommendations(cfg, wiz.md.isNew)
+	pairProblem := wiz.hardware.getPairProblem(ec, bn)
+
+	// Replayed answers pick their clients explicitly and may come from a different machine, so don't stop to ask about them
+	if (len(notes) == 0 && pairProblem == "") || wiz.replay != nil {
+		wiz.checkpointSyncProviderModal.show()
+		return
+	}
+
+	wiz.md.pages.RemovePage(hardwareStepID)
+	wiz.hardwareModal = createHardwareStep(wiz, currentStep, totalSteps, notes, pairProblem)
+	wiz.hardwareModal.show()
+}
+
+func createHardwareStep(wiz *wizard, currentStep int, totalSteps int, notes []string, pairProblem string) *choiceWizardStep {
+	cfg := wiz.md.Config
+	helperText := strings.Builder{}
+	if pairProblem != "" {
+		ecName := getEcName(cfg, cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value)
+		bnName := getBnName(cfg, cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value)
+		helperText.WriteString(fmt.Sprintf("[orange]WARNING: %s and %s will likely not perform well together on your system because %s. We recommend you pick lighter clients instead.[white]\n\n", ecName, bnName, pairProblem))
+	}
+	if len(notes) > 0 {
+		helperText.WriteString("Hyperdrive adjusted these settings to fit your hardware:\n")
+		for _, note := range notes {
+			helperText.WriteString(fmt.Sprintf("\t- %s\n", note))
+		}
+		helperText.WriteString("\n")
+	}
+	helperText.WriteString(wiz.hardware.String())
+
+	buttons := []string{"Ok"}
+	if pairProblem != "" {
+		buttons = []string{"Choose Different Clients", "Keep These Clients"}
+	}
+
+	show := func(modal *choiceModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus(0)
+	}
+
+	done := func(buttonIndex int, buttonLabel string) {
+		if pairProblem != "" && buttonIndex == 0 {
+			wiz.localEcModal.show()
+		} else {
+			wiz.checkpointSyncProviderModal.show()
+		}
+	}
+
+	back := func() {
+		wiz.localBnModal.show()
+	}
+
+	return newChoiceStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText.String(),
+		buttons,
+		[]string{},
+		76,
+		"Beacon Node > Hardware",
+		DirectionalModalHorizontal,
+		show,
+		done,
+		back,
+		hardwareStepID,
+	)
+}
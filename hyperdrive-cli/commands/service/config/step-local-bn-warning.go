@@ -1,5 +1,11 @@
 package config
 
+import (
+	"fmt"
+
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
 func createPrysmWarningStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
 	helperText := "[orange]NOTE: Prysm currently has a very high representation of the Beacon Chain. For the health of the network and the overall safety of your funds, please consider choosing a client with a lower representation. Please visit https://clientdiversity.org to learn more."
 
@@ -12,7 +18,7 @@ func createPrysmWarningStep(wiz *wizard, currentStep int, totalSteps int) *choic
 		if buttonIndex == 0 {
 			wiz.localBnModal.show()
 		} else {
-			wiz.checkpointSyncProviderModal.show()
+			wiz.showHardwareStep(currentStep, totalSteps)
 		}
 	}
 
@@ -39,6 +45,9 @@ func createPrysmWarningStep(wiz *wizard, currentStep int, totalSteps int) *choic
 
 func createTekuWarningStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
 	helperText := "[orange]WARNING: Teku is a resource-heavy client and will likely not perform well on your system given your CPU power or amount of available RAM. We recommend you pick a lighter client instead."
+	if problem := wiz.hardware.getBnProblem(config.BeaconNode_Teku); problem != "" {
+		helperText = fmt.Sprintf("[orange]WARNING: Teku is a resource-heavy client and will likely not perform well on your system because %s. We recommend you pick a lighter client instead.", problem)
+	}
 
 	show := func(modal *choiceModalLayout) {
 		wiz.md.setPage(modal.page)
@@ -49,7 +58,7 @@ func createTekuWarningStep(wiz *wizard, currentStep int, totalSteps int) *choice
 		if buttonIndex == 0 {
 			wiz.localBnModal.show()
 		} else {
-			wiz.checkpointSyncProviderModal.show()
+			wiz.showHardwareStep(currentStep, totalSteps)
 		}
 	}
 
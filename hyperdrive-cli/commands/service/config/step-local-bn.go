@@ -3,10 +3,8 @@ package config
 import (
 	"fmt"
 	"math/rand"
-	"runtime"
 	"strings"
 
-	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/node-manager-core/config"
 )
 
@@ -19,11 +17,11 @@ func createLocalBnStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 	clients := []*config.ParameterOption[config.BeaconNode]{}
 	for _, client := range wiz.md.Config.Hyperdrive.LocalBeaconClient.BeaconNode.Options {
 		clientNames = append(clientNames, client.Name)
-		clientDescriptions = append(clientDescriptions, getAugmentedBnDescription(client.Value, client.Description))
+		clientDescriptions = append(clientDescriptions, getAugmentedBnDescription(wiz, client.Value, client.Name, client.Description))
 		clients = append(clients, client)
 	}
 
-	helperText := "Please select the Beacon Node you would like to use.\n\nHighlight each one to see a brief description of it, or go to https://clientdiversity.org/ to learn more about them."
+	helperText := fmt.Sprintf("Please select the Beacon Node you would like to use.\n\nHighlight each one to see a brief description of it, or go to https://clientdiversity.org/ to learn more about them.\n\n%s", wiz.hardware)
 
 	show := func(modal *choiceModalLayout) {
 		wiz.md.setPage(modal.page)
@@ -68,14 +66,13 @@ func createLocalBnStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 			//case config.ConsensusClient_Prysm:
 			//	wiz.consensusLocalPrysmWarning.show()
 			case config.BeaconNode_Teku:
-				totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-				if runtime.GOARCH == "arm64" || totalMemoryGB < 15 {
+				if wiz.hardware.getBnProblem(config.BeaconNode_Teku) != "" {
 					wiz.localBnTekuWarning.show()
 				} else {
-					wiz.checkpointSyncProviderModal.show()
+					wiz.showHardwareStep(currentStep, totalSteps)
 				}
 			default:
-				wiz.checkpointSyncProviderModal.show()
+				wiz.showHardwareStep(currentStep, totalSteps)
 			}
 		}
 	}
@@ -103,33 +100,44 @@ func createLocalBnStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 
 // Get a random client compatible with the user's hardware and EC choices.
 func selectRandomBn(goodOptions []*config.ParameterOption[config.BeaconNode], includeSupermajority bool, wiz *wizard, currentStep int, totalSteps int) {
-	// Get system specs
-	totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-	isLowPower := (totalMemoryGB < 15 || runtime.GOARCH == "arm64")
-
-	// Filter out the clients based on system specs
+	// Filter out the clients that won't run well on this machine, or won't run well alongside the selected EC
+	ec := wiz.md.Config.Hyperdrive.LocalExecutionClient.ExecutionClient.Value
 	filteredClients := []config.BeaconNode{}
+	pairedClients := []config.BeaconNode{}
 	for _, clientOption := range goodOptions {
 		client := clientOption.Value
-		switch client {
-		case config.BeaconNode_Teku:
-			if !isLowPower {
-				filteredClients = append(filteredClients, client)
-			}
 		/*
-			case config.BeaconNode_Prysm:
-				if includeSupermajority {
-					filteredClients = append(filteredClients, client)
-				}
+			if client == config.BeaconNode_Prysm && !includeSupermajority {
+				continue
+			}
 		*/
-		default:
-			filteredClients = append(filteredClients, client)
+		if wiz.hardware.getBnProblem(client) != "" {
+			continue
+		}
+		filteredClients = append(filteredClients, client)
+		if wiz.hardware.getPairProblem(ec, client) == "" {
+			pairedClients = append(pairedClients, client)
+		}
+	}
+	if len(pairedClients) > 0 {
+		filteredClients = pairedClients
+	}
+	if len(filteredClients) == 0 {
+		// Nothing fits, so pick from all of them and let the hardware step warn about it
+		for _, clientOption := range goodOptions {
+			filteredClients = append(filteredClients, clientOption.Value)
 		}
 	}
 
 	// Select a random client
 	selectedClient := filteredClients[rand.Intn(len(filteredClients))]
 	wiz.md.Config.Hyperdrive.LocalBeaconClient.BeaconNode.Value = selectedClient
+	for _, clientOption := range goodOptions {
+		if clientOption.Value == selectedClient {
+			wiz.recordRandomChoice(localBnStepID, clientOption.Name)
+			break
+		}
+	}
 
 	// Show the selection page
 	/*
@@ -146,18 +154,14 @@ func selectRandomBn(goodOptions []*config.ParameterOption[config.BeaconNode], in
 }
 
 // Get a more verbose client description, including warnings
-func getAugmentedBnDescription(client config.BeaconNode, originalDescription string) string {
-	switch client {
+func getAugmentedBnDescription(wiz *wizard, client config.BeaconNode, clientName string, originalDescription string) string {
 	/*
-		case config.BeaconNode_Prysm:
+		if client == config.BeaconNode_Prysm {
 			return fmt.Sprintf("%s\n\n[orange]NOTE: Prysm currently has a very high representation of the Beacon Chain. For the health of the network and the overall safety of your funds, please consider choosing a client with a lower representation. Please visit https://clientdiversity.org to learn more.", originalDescription)
-	*/
-	case config.BeaconNode_Teku:
-		totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-		if runtime.GOARCH == "arm64" || totalMemoryGB < 15 {
-			return fmt.Sprintf("%s\n\n[orange]WARNING: Teku is a resource-heavy client and will likely not perform well on your system given your CPU power or amount of available RAM. We recommend you pick a lighter client instead.", originalDescription)
 		}
+	*/
+	if problem := wiz.hardware.getBnProblem(client); problem != "" {
+		return fmt.Sprintf("%s\n\n[orange]WARNING: %s will likely not perform well on your system because %s. We recommend you pick a lighter client instead.", originalDescription, strings.TrimPrefix(clientName, "*"), problem)
 	}
-
 	return originalDescription
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/rocket-pool/node-manager-core/config"
 )
 
+const localEcStepID string = "step-ec-local"
+
 func createLocalEcStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
 	// Make lists of clients that good and bad
 	goodClients := []*config.ParameterOption[config.ExecutionClient]{}
@@ -44,9 +46,14 @@ func createLocalEcStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 		clientDescriptions = append(clientDescriptions, client.Description)
 	}
 
-	helperText := "Please select the Execution Client you would like to use.\n\nHighlight each one to see a brief description of it, or go to https://clientdiversity.org/ to learn more about them."
+	helperText := fmt.Sprintf("Please select the Execution Client you would like to use.\n\nHighlight each one to see a brief description of it, or go to https://clientdiversity.org/ to learn more about them.\n\n%s", wiz.hardware)
 
 	show := func(modal *choiceModalLayout) {
+		// The disk estimates depend on the network, which is picked after this step is created
+		for i, client := range clients {
+			clientDescriptions[i+1] = getAugmentedEcDescription(wiz, client.Value, client.Name, client.Description)
+		}
+
 		wiz.md.setPage(modal.page)
 		modal.focus(0) // Catch-all for safety
 
@@ -107,31 +114,48 @@ func createLocalEcStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 		show,
 		done,
 		back,
-		"step-ec-local",
+		localEcStepID,
 	)
 }
 
 // Get a random execution client
 func selectRandomEC(goodOptions []*config.ParameterOption[config.ExecutionClient], wiz *wizard, currentStep int, totalSteps int) {
-	// Get system specs
-	//totalMemoryGB := memory.TotalMemory() / 1024 / 1024 / 1024
-	//isLowPower := (totalMemoryGB < 15 || runtime.GOARCH == "arm64")
-
-	// Filter out the clients based on system specs
+	// Filter out the clients that won't run well on this machine
+	network := wiz.md.Config.Hyperdrive.Network.Value
 	filteredClients := []config.ExecutionClient{}
 	for _, clientOption := range goodOptions {
 		client := clientOption.Value
-		switch client {
-		default:
+		if wiz.hardware.getEcProblem(client, network, wiz.md.isNew) == "" {
 			filteredClients = append(filteredClients, client)
 		}
 	}
+	if len(filteredClients) == 0 {
+		// Nothing fits, so pick from all of them and let the hardware step warn about it
+		for _, clientOption := range goodOptions {
+			filteredClients = append(filteredClients, clientOption.Value)
+		}
+	}
 
 	// Select a random client
 	selectedClient := filteredClients[rand.Intn(len(filteredClients))]
 	wiz.md.Config.Hyperdrive.LocalExecutionClient.ExecutionClient.Value = selectedClient
+	for _, clientOption := range goodOptions {
+		if clientOption.Value == selectedClient {
+			wiz.recordRandomChoice(localEcStepID, clientOption.Name)
+			break
+		}
+	}
 
 	// Show the selection page
 	wiz.localEcRandomModal = createRandomEcStep(wiz, currentStep, totalSteps, goodOptions)
 	wiz.localEcRandomModal.show()
 }
+
+// Get a more verbose client description, including warnings
+func getAugmentedEcDescription(wiz *wizard, client config.ExecutionClient, clientName string, originalDescription string) string {
+	network := wiz.md.Config.Hyperdrive.Network.Value
+	if problem := wiz.hardware.getEcProblem(client, network, wiz.md.isNew); problem != "" {
+		return fmt.Sprintf("%s\n\n[orange]WARNING: %s will likely not perform well on your system because %s. We recommend you pick a lighter client instead.", originalDescription, strings.TrimPrefix(clientName, "*"), problem)
+	}
+	return originalDescription
+}
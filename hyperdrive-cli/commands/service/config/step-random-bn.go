@@ -27,7 +27,7 @@ func createRandomBnStep(wiz *wizard, currentStep int, totalSteps int, goodOption
 	}
 
 	done := func(buttonIndex int, buttonLabel string) {
-		wiz.checkpointSyncProviderModal.show()
+		wiz.showHardwareStep(currentStep, totalSteps)
 	}
 
 	back := func() {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
@@ -26,8 +27,22 @@ type textBoxModalLayout struct {
 	textboxes    map[string]*tview.InputField
 
 	page *page
+
+	// Layout sizes, used to make room for the probe status
+	textViewHeight int
+	controlHeight  int
+	bottomSpacer   *tview.Box
+
+	// Optional endpoint probing, run when Next is pressed before moving on
+	probe      func(text map[string]string) (string, bool)
+	probeView  *tview.TextView
+	probedText map[string]string
+	probing    bool
 }
 
+// The height of the probe status view
+const probeStatusHeight int = 6
+
 // Creates a new TextBoxModalLayout instance
 func newTextBoxModalLayout(app *tview.Application, title string, width int, text string, labels []string, maxLengths []int, regexes []string) *textBoxModalLayout {
 
@@ -66,6 +81,7 @@ func newTextBoxModalLayout(app *tview.Application, title string, width int, text
 		AddItem(spacer2, 2, 0, 1, 1, 0, 0, false).
 		AddItem(layout.controlGrid, 3, 0, 1, 1, 0, 0, true).
 		AddItem(spacer3, 4, 0, 1, 1, 0, 0, false)
+	layout.bottomSpacer = spacer3
 	contentGrid.
 		SetBackgroundColor(BackgroundColor).
 		SetBorder(true).
@@ -92,6 +108,8 @@ func newTextBoxModalLayout(app *tview.Application, title string, width int, text
 	lines := tview.WordWrap(text, width-4)
 	textViewHeight := len(lines) + 4
 	borderGrid.SetRows(0, textViewHeight+height+3, 0, 2)
+	layout.textViewHeight = textViewHeight
+	layout.controlHeight = height
 
 	// Create the nav footer text view
 	navString1 := "Arrow keys: Navigate     Space/Enter: Select"
@@ -176,6 +194,10 @@ func (layout *textBoxModalLayout) createControlGrid(labels []string, maxLengths
 			for label, textbox := range layout.textboxes {
 				text[label] = strings.TrimSpace(textbox.GetText())
 			}
+			if layout.probe != nil && !reflect.DeepEqual(text, layout.probedText) {
+				layout.runProbe(text)
+				return
+			}
 			layout.done(text)
 		}
 	}).
@@ -199,4 +221,53 @@ func (layout *textBoxModalLayout) createControlGrid(labels []string, maxLengths
 func (layout *textBoxModalLayout) focus() {
 	layout.app.SetFocus(layout.firstTextbox)
 	layout.form.SetFocus(0)
+	if layout.probeView != nil {
+		layout.probeView.SetText("")
+		layout.probedText = nil
+	}
+}
+
+// Enables probing of the entered values. When Next is pressed, the probe runs in the background and its results are shown
+// below the textboxes; pressing Next again with the same values moves on. The probe returns the formatted results and
+// whether everything looked correct.
+func (layout *textBoxModalLayout) setProbe(probe func(text map[string]string) (string, bool)) {
+	probeView := tview.NewTextView().
+		SetTextAlign(tview.AlignCenter).
+		SetWordWrap(true).
+		SetDynamicColors(true).
+		SetTextColor(tview.Styles.PrimaryTextColor)
+	probeView.SetBackgroundColor(BackgroundColor)
+	probeView.SetBorderPadding(0, 0, 1, 1)
+	layout.probe = probe
+	layout.probeView = probeView
+
+	// Add a row for the results
+	layout.contentGrid.RemoveItem(layout.bottomSpacer)
+	layout.contentGrid.
+		SetRows(1, 0, 1, layout.controlHeight, probeStatusHeight, 1).
+		AddItem(probeView, 4, 0, 1, 1, 0, 0, false).
+		AddItem(layout.bottomSpacer, 5, 0, 1, 1, 0, 0, false)
+	layout.borderGrid.SetRows(0, layout.textViewHeight+layout.controlHeight+probeStatusHeight+3, 0, 2)
+}
+
+// Runs the probe in the background and shows its results
+func (layout *textBoxModalLayout) runProbe(text map[string]string) {
+	if layout.probing {
+		return
+	}
+	layout.probing = true
+	layout.probeView.SetText("[yellow]Checking your client...[-]")
+
+	go func() {
+		results, ok := layout.probe(text)
+		layout.app.QueueUpdateDraw(func() {
+			layout.probing = false
+			layout.probedText = text
+			if ok {
+				layout.probeView.SetText(results + "\nPress Next to continue.")
+			} else {
+				layout.probeView.SetText(results + "\n[orange]Fix the settings above, or press Next again to continue anyway.[-]")
+			}
+		})
+	}()
 }
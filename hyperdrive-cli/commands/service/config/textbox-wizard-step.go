@@ -4,6 +4,7 @@ import "fmt"
 
 type textBoxWizardStep struct {
 	wiz      *wizard
+	id       string
 	modal    *textBoxModalLayout
 	showImpl func(*textBoxModalLayout)
 }
@@ -12,6 +13,7 @@ func newTextBoxWizardStep(wiz *wizard, currentStep int, totalSteps int, helperTe
 
 	step := &textBoxWizardStep{
 		wiz:      wiz,
+		id:       pageID,
 		showImpl: showImpl,
 	}
 
@@ -28,7 +30,13 @@ func newTextBoxWizardStep(wiz *wizard, currentStep int, totalSteps int, helperTe
 		regexes,
 	)
 
-	modal.done = done
+	modal.done = func(text map[string]string) {
+		wiz.recordAnswer(WizardAnswer{
+			Step: pageID,
+			Text: text,
+		})
+		done(text)
+	}
 	modal.back = back
 	step.modal = modal
 
@@ -41,4 +49,7 @@ func newTextBoxWizardStep(wiz *wizard, currentStep int, totalSteps int, helperTe
 
 func (step *textBoxWizardStep) show() {
 	step.showImpl(step.modal)
+	if step.wiz.replay != nil {
+		step.wiz.replay.answerTextBoxStep(step)
+	}
 }
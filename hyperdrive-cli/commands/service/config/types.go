@@ -4,3 +4,9 @@ type settingsPage interface {
 	handleLayoutChanged()
 	getPage() *page
 }
+
+// A settings page with a form of parameters that can be found with the settings search
+type searchableSettingsPage interface {
+	settingsPage
+	getLayout() *standardLayout
+}
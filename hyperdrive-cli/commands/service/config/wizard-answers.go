@@ -0,0 +1,260 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive-daemon/shared"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/rivo/tview"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// The ID of the wizard's last step; it isn't recorded, and replaying always saves and exits there
+	finishedStepID string = "step-finished"
+
+	// The label of the finished step's button that saves and exits
+	finishedSaveLabel string = "Save and Exit"
+)
+
+// The answers given to each step of the config wizard, which can be saved and replayed later
+type WizardAnswers struct {
+	// The version of Hyperdrive the answers were recorded with
+	Version string `yaml:"version"`
+
+	// The answers, in the order the steps were shown
+	Answers []WizardAnswer `yaml:"answers"`
+}
+
+// The answer to a single step of the config wizard
+type WizardAnswer struct {
+	// The ID of the step
+	Step string `yaml:"step"`
+
+	// The label of the button that was selected, for steps with a list of choices
+	Choice string `yaml:"choice,omitempty"`
+
+	// The text entered into each box, by label, for steps with text boxes
+	Text map[string]string `yaml:"text,omitempty"`
+
+	// Whether each box was checked, by label, for steps with checkboxes
+	Checkboxes map[string]bool `yaml:"checkboxes,omitempty"`
+}
+
+// Load a wizard answers file
+func LoadWizardAnswers(path string) (*WizardAnswers, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading wizard answers file [%s]: %w", path, err)
+	}
+	answers := &WizardAnswers{}
+	err = yaml.Unmarshal(bytes, answers)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing wizard answers file [%s]: %w", path, err)
+	}
+	if len(answers.Answers) == 0 {
+		return nil, fmt.Errorf("wizard answers file [%s] doesn't have any answers", path)
+	}
+	return answers, nil
+}
+
+// Save the answers to a file
+func (a *WizardAnswers) Save(path string) error {
+	bytes, err := yaml.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("error serializing wizard answers: %w", err)
+	}
+	err = os.WriteFile(path, bytes, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing wizard answers file [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Get the answers the user gave during the wizard, or nil if the wizard wasn't run
+func (md *mainDisplay) GetWizardAnswers() *WizardAnswers {
+	if len(md.wizard.answers) == 0 {
+		return nil
+	}
+	return &WizardAnswers{
+		Version: shared.HyperdriveVersion,
+		Answers: slices.Clone(md.wizard.answers),
+	}
+}
+
+// Record the answer to a wizard step. If the step was already answered, the user went back to it, so the answers
+// after it are dropped since the steps that follow may be different now.
+func (wiz *wizard) recordAnswer(answer WizardAnswer) {
+	// The steps that show a randomly selected client aren't recorded since the selection step records the client instead,
+	// and the hardware step isn't recorded since replays skip it
+	if wiz.replay != nil || answer.Step == finishedStepID || answer.Step == randomEcID || answer.Step == randomBnID || answer.Step == hardwareStepID {
+		return
+	}
+	for i, existing := range wiz.answers {
+		if existing.Step == answer.Step {
+			wiz.answers = wiz.answers[:i]
+			break
+		}
+	}
+	wiz.answers = append(wiz.answers, answer)
+}
+
+// Record the client that was selected randomly as the answer to its selection step, so replaying the answers selects the same client
+func (wiz *wizard) recordRandomChoice(stepID string, clientName string) {
+	wiz.recordAnswer(WizardAnswer{
+		Step:   stepID,
+		Choice: clientName,
+	})
+}
+
+// Runs the config wizard without the TUI by answering each step from an answers file
+type wizardReplay struct {
+	answers *WizardAnswers
+	byStep  map[string]WizardAnswer
+	used    map[string]bool
+	err     error
+}
+
+// Run the config wizard non-interactively with the provided answers, saving and exiting at the end.
+// Returns the main display with the resulting config, and a list of the answers that weren't used.
+func ReplayWizard(previousConfig *client.GlobalConfig, config *client.GlobalConfig, dockerRootDir string, isNew bool, isUpdate bool, answers *WizardAnswers) (*mainDisplay, []string, error) {
+	app := tview.NewApplication()
+	md := NewMainDisplay(app, previousConfig, config, dockerRootDir, isNew, isUpdate)
+
+	replay := &wizardReplay{
+		answers: answers,
+		byStep:  map[string]WizardAnswer{},
+		used:    map[string]bool{},
+	}
+	for _, answer := range answers.Answers {
+		replay.byStep[answer.Step] = answer
+	}
+	md.wizard.replay = replay
+	md.wizard.welcomeModal.show()
+
+	if replay.err != nil {
+		return nil, nil, replay.err
+	}
+	if !md.ShouldSave {
+		errors := md.Config.Validate()
+		if len(errors) > 0 {
+			return nil, nil, fmt.Errorf("the configuration from the wizard answers has errors:\n%s", strings.Join(errors, "\n"))
+		}
+		return nil, nil, fmt.Errorf("the wizard answers didn't finish the wizard")
+	}
+
+	// Find the answers that weren't used
+	unused := []string{}
+	for _, answer := range answers.Answers {
+		if !replay.used[answer.Step] {
+			unused = append(unused, answer.Step)
+		}
+	}
+	return md, unused, nil
+}
+
+// Get the answer for a step, or nil if there isn't one
+func (r *wizardReplay) getAnswer(stepID string) *WizardAnswer {
+	if r.err != nil {
+		return nil
+	}
+	answer, exists := r.byStep[stepID]
+	if !exists {
+		r.err = fmt.Errorf("the wizard answers file doesn't have an answer for the [%s] step%s", stepID, r.getVersionNote())
+		return nil
+	}
+
+	// Every step is answered the same way each time, so coming back to one means the answers send the wizard in a loop
+	if r.used[stepID] {
+		r.err = fmt.Errorf("the wizard came back to the [%s] step after it was already answered, so replaying the answers would loop forever; please check the answers to the steps that lead back to it%s", stepID, r.getVersionNote())
+		return nil
+	}
+	r.used[stepID] = true
+	return &answer
+}
+
+// Answer a step with a list of choices
+func (r *wizardReplay) answerChoiceStep(step *choiceWizardStep) {
+	// The finished step always saves and exits
+	if step.id == finishedStepID {
+		if r.err == nil {
+			step.modal.done(slices.Index(step.labels, finishedSaveLabel), finishedSaveLabel)
+		}
+		return
+	}
+
+	answer := r.getAnswer(step.id)
+	if answer == nil {
+		return
+	}
+	index := slices.Index(step.labels, answer.Choice)
+	if index == -1 {
+		r.err = fmt.Errorf("[%s] isn't a valid answer for the [%s] step; the options are: %s%s", answer.Choice, step.id, strings.Join(step.labels, ", "), r.getVersionNote())
+		return
+	}
+	step.modal.done(index, answer.Choice)
+}
+
+// Answer a step with text boxes
+func (r *wizardReplay) answerTextBoxStep(step *textBoxWizardStep) {
+	answer := r.getAnswer(step.id)
+	if answer == nil {
+		return
+	}
+
+	// Start with the values the step filled in, then apply the answers
+	text := map[string]string{}
+	labels := []string{}
+	for label, textbox := range step.modal.textboxes {
+		text[label] = strings.TrimSpace(textbox.GetText())
+		labels = append(labels, label)
+	}
+	for label, value := range answer.Text {
+		if _, exists := text[label]; !exists {
+			slices.Sort(labels)
+			r.err = fmt.Errorf("the [%s] step doesn't have a [%s] box; its boxes are: %s%s", step.id, label, strings.Join(labels, ", "), r.getVersionNote())
+			return
+		}
+		text[label] = strings.TrimSpace(value)
+	}
+	step.modal.done(text)
+}
+
+// Answer a step with checkboxes
+func (r *wizardReplay) answerCheckBoxStep(step *checkBoxWizardStep) {
+	answer := r.getAnswer(step.id)
+	if answer == nil {
+		return
+	}
+
+	// Start with the boxes the step is showing, then apply the answers
+	settings := map[string]bool{}
+	labels := []string{}
+	for i := 0; i < step.modal.form.GetFormItemCount(); i++ {
+		checkbox, ok := step.modal.form.GetFormItem(i).(*tview.Checkbox)
+		if !ok {
+			continue
+		}
+		settings[checkbox.GetLabel()] = checkbox.IsChecked()
+		labels = append(labels, checkbox.GetLabel())
+	}
+	for label, checked := range answer.Checkboxes {
+		if _, exists := settings[label]; !exists {
+			r.err = fmt.Errorf("the [%s] step doesn't have a [%s] option; its options are: %s%s", step.id, label, strings.Join(labels, ", "), r.getVersionNote())
+			return
+		}
+		settings[label] = checked
+	}
+	step.modal.done(settings)
+}
+
+// Get a note about the answers being recorded with a different version of Hyperdrive, if they were
+func (r *wizardReplay) getVersionNote() string {
+	if r.answers.Version == "" || strings.TrimPrefix(r.answers.Version, "v") == strings.TrimPrefix(shared.HyperdriveVersion, "v") {
+		return ""
+	}
+	return fmt.Sprintf(" (the answers were recorded with Hyperdrive v%s, but this is v%s, so the wizard may have changed)", strings.TrimPrefix(r.answers.Version, "v"), strings.TrimPrefix(shared.HyperdriveVersion, "v"))
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWizardReplayGetAnswer(t *testing.T) {
+	answers := &WizardAnswers{
+		Answers: []WizardAnswer{
+			{Step: "step-network", Choice: "Hoodi"},
+		},
+	}
+	replay := &wizardReplay{
+		answers: answers,
+		byStep:  map[string]WizardAnswer{"step-network": answers.Answers[0]},
+		used:    map[string]bool{},
+	}
+
+	answer := replay.getAnswer("step-network")
+	require.NotNil(t, answer)
+	require.Equal(t, "Hoodi", answer.Choice)
+	require.NoError(t, replay.err)
+
+	// Coming back to the same step would loop forever
+	require.Nil(t, replay.getAnswer("step-network"))
+	require.ErrorContains(t, replay.err, "loop forever")
+
+	// Steps without an answer fail
+	replay.err = nil
+	require.Nil(t, replay.getAnswer("step-mode"))
+	require.ErrorContains(t, replay.err, "doesn't have an answer for the [step-mode] step")
+}
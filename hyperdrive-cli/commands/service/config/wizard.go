@@ -25,7 +25,9 @@ type wizard struct {
 	localBnRandomModal          *choiceWizardStep
 	localBnPrysmWarning         *choiceWizardStep
 	localBnTekuWarning          *choiceWizardStep
+	hardwareModal               *choiceWizardStep
 	checkpointSyncProviderModal *textBoxWizardStep
+	checkpointSyncMismatchModal *choiceWizardStep
 	externalBnSelectModal       *choiceWizardStep
 	externalBnSettingsModal     *textBoxWizardStep
 	externalPrysmSettingsModal  *textBoxWizardStep
@@ -51,12 +53,22 @@ type wizard struct {
 
 	// Done
 	finishedModal *choiceWizardStep
+
+	// The answers given to each step so far, so they can be saved to an answers file
+	answers []WizardAnswer
+
+	// The answers file being replayed, if the wizard is running without the TUI
+	replay *wizardReplay
+
+	// The machine's hardware, for recommending clients and settings
+	hardware *hardwareProfile
 }
 
 // Create a new Wizard display
-func newWizard(md *mainDisplay) *wizard {
+func newWizard(md *mainDisplay, dockerRootDir string) *wizard {
 	wiz := &wizard{
-		md: md,
+		md:       md,
+		hardware: getHardwareProfile(dockerRootDir),
 	}
 
 	totalSteps := 10
@@ -86,6 +98,7 @@ func newWizard(md *mainDisplay) *wizard {
 	wiz.localBnPrysmWarning = createPrysmWarningStep(wiz, stepCount, totalSteps)
 	wiz.localBnTekuWarning = createTekuWarningStep(wiz, stepCount, totalSteps)
 	wiz.checkpointSyncProviderModal = createCheckpointSyncStep(wiz, stepCount, totalSteps)
+	wiz.checkpointSyncMismatchModal = createCheckpointSyncMismatchStep(wiz, stepCount, totalSteps)
 	wiz.externalBnSelectModal = createExternalBnSelectStep(wiz, stepCount, totalSteps)
 	wiz.externalBnSettingsModal = createExternalBnSettingsStep(wiz, stepCount, totalSteps)
 	wiz.externalPrysmSettingsModal = createExternalPrysmSettingsStep(wiz, stepCount, totalSteps)
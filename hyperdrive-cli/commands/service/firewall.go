@@ -0,0 +1,95 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliutils "github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	firewallBackendFlag *cli.StringFlag = &cli.StringFlag{
+		Name:    "backend",
+		Aliases: []string{"b"},
+		Usage:   fmt.Sprintf("The firewall to generate rules for (%s)", getFirewallBackendNames()),
+		Value:   string(client.FirewallBackend_Ufw),
+	}
+)
+
+// Print the firewall rules for the current settings
+func printFirewallRules(c *cli.Context) error {
+	cfg, backend, err := loadFirewallConfig(c)
+	if err != nil {
+		return err
+	}
+	script, err := cfg.GetFirewallScript(backend)
+	if err != nil {
+		return err
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// Apply the firewall rules for the current settings
+func applyFirewallRules(c *cli.Context) error {
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	cfg, backend, err := loadFirewallConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Show the ports and confirm
+	open, _ := cfg.GetFirewallRules()
+	fmt.Printf("The following ports will be opened with %s:\n", backend)
+	for _, rule := range open {
+		fmt.Printf("\t%d/%s\t%s\n", rule.Port, rule.Protocol, rule.Description)
+	}
+	fmt.Println("Any rules Hyperdrive added before will be replaced. Run `hyperdrive service firewall print` to see the full set of commands.")
+	fmt.Println()
+	if !(c.Bool(cliutils.YesFlag.Name) || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = hd.ApplyFirewallRules(cfg, backend)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sFirewall rules applied successfully.%s\n", terminal.ColorGreen, terminal.ColorReset)
+	return nil
+}
+
+// Load the config and the firewall backend from the command line
+func loadFirewallConfig(c *cli.Context) (*client.GlobalConfig, client.FirewallBackend, error) {
+	backend, err := client.ParseFirewallBackend(c.String(firewallBackendFlag.Name))
+	if err != nil {
+		return nil, "", err
+	}
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return nil, "", err
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return nil, "", fmt.Errorf("no configuration detected; please run `hyperdrive service config` to set up Hyperdrive first")
+	}
+	return cfg, backend, nil
+}
+
+// Get the names of the firewall backends for the flag's usage
+func getFirewallBackendNames() string {
+	names := []string{}
+	for _, backend := range client.FirewallBackends {
+		names = append(names, string(backend))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rocket-pool/node-manager-core/beacon"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	mevBoostCheckSkipRegistrationsFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "skip-registrations",
+		Usage: "Only check the relays' status, without looking up your validators' registrations",
+	}
+)
+
+// A validator to look up on the relays
+type mevBoostValidator struct {
+	module string
+	pubkey beacon.ValidatorPubkey
+}
+
+// Check the configured MEV-Boost relays' health and whether the node's validators are registered with them
+func checkMevBoost(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+	if !cfg.Hyperdrive.MevBoost.Enable.Value {
+		fmt.Println("MEV-Boost is not enabled in your Hyperdrive configuration.")
+		return nil
+	}
+	relays := client.GetMevRelayEndpoints(cfg)
+	if len(relays) == 0 {
+		fmt.Println("You do not have any MEV-Boost relays enabled for this network.")
+		return nil
+	}
+	ethNetworkName := cfg.Hyperdrive.GetEthNetworkName()
+	ctx := context.Background()
+
+	// Check the relays
+	fmt.Printf("Checking %d relay(s) on %s...\n", len(relays), ethNetworkName)
+	reachableRelays := []client.MevRelayEndpoint{}
+	for _, relay := range relays {
+		status := client.CheckMevRelayStatus(ctx, relay, ethNetworkName)
+		if !status.Reachable {
+			fmt.Printf("\t%s (%s): %sUNREACHABLE: %s%s\n", relay.Name, relay.Url, terminal.ColorRed, status.Error.Error(), terminal.ColorReset)
+			continue
+		}
+		fmt.Printf("\t%s (%s): %sOK%s (%d ms)\n", relay.Name, relay.Url, terminal.ColorGreen, terminal.ColorReset, status.Latency.Milliseconds())
+		if status.NetworkHint != "" {
+			fmt.Printf("\t\t%sWARNING: this relay %s. This is only a guess from its name; please make sure it's the right URL for %s.%s\n", terminal.ColorYellow, status.NetworkHint, ethNetworkName, terminal.ColorReset)
+		}
+		reachableRelays = append(reachableRelays, relay)
+	}
+	fmt.Println()
+	if c.Bool(mevBoostCheckSkipRegistrationsFlag.Name) || len(reachableRelays) == 0 {
+		return nil
+	}
+
+	// Get the validators
+	_, ready, err := utils.CheckIfWalletReady(hd)
+	if err != nil {
+		return err
+	}
+	if !ready {
+		return nil
+	}
+	validators, err := getMevBoostValidators(c, hd, cfg)
+	if err != nil {
+		return err
+	}
+	if len(validators) == 0 {
+		fmt.Println("You do not have any active validators to check registrations for.")
+		return nil
+	}
+	pubkeys := make([]beacon.ValidatorPubkey, len(validators))
+	for i, validator := range validators {
+		pubkeys[i] = validator.pubkey
+	}
+
+	// Check the registrations on each relay
+	fmt.Printf("Checking the registrations of %d validator(s)...\n", len(validators))
+	unregisteredCount := 0
+	for _, relay := range reachableRelays {
+		registrations := client.GetMevRelayRegistrations(ctx, relay, pubkeys)
+		registered := 0
+		problems := []string{}
+		for i, registration := range registrations {
+			validator := validators[i]
+			switch {
+			case registration.Error != nil:
+				problems = append(problems, fmt.Sprintf("\t\t%s%s (%s): ERROR: %s%s", terminal.ColorYellow, validator.pubkey.HexWithPrefix(), validator.module, registration.Error.Error(), terminal.ColorReset))
+			case !registration.Registered:
+				problems = append(problems, fmt.Sprintf("\t\t%s%s (%s): NOT REGISTERED%s", terminal.ColorRed, validator.pubkey.HexWithPrefix(), validator.module, terminal.ColorReset))
+				unregisteredCount++
+			default:
+				registered++
+			}
+		}
+		fmt.Printf("\t%s: %d/%d registered\n", relay.Name, registered, len(validators))
+		if len(problems) > 0 {
+			fmt.Println(strings.Join(problems, "\n"))
+		}
+	}
+	fmt.Println()
+
+	if unregisteredCount > 0 {
+		fmt.Printf("%sSome of your validators are not registered with your relays. Validators register with each relay through MEV-Boost once they're active, so new validators may take a few epochs to appear. If they don't, make sure your Validator Client has MEV-Boost enabled and check its logs.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	} else {
+		fmt.Println("All of your validators are registered with all of your reachable relays.")
+	}
+	return nil
+}
+
+// Get the pubkeys of the node's active validators across the enabled modules
+func getMevBoostValidators(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig) ([]mevBoostValidator, error) {
+	validators := []mevBoostValidator{}
+
+	// StakeWise
+	if cfg.StakeWise.Enabled.Value {
+		sw, err := client.NewStakewiseClientFromCtx(c, hd)
+		if err != nil {
+			return nil, err
+		}
+		response, err := sw.Api.Validator.Status(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting StakeWise validator status: %w", err)
+		}
+		switch {
+		case response.Data.NotRegisteredWithNodeSet:
+			fmt.Println("Your node isn't registered with NodeSet, so StakeWise validators will be skipped.")
+		case response.Data.InvalidPermissions:
+			fmt.Println("Your node doesn't have permission to use the StakeWise module, so StakeWise validators will be skipped.")
+		default:
+			for _, vault := range response.Data.Vaults {
+				for _, validator := range vault.Validators {
+					if isMevBoostValidatorExited(validator.State) {
+						continue
+					}
+					validators = append(validators, mevBoostValidator{
+						module: fmt.Sprintf("StakeWise - %s", vault.Name),
+						pubkey: validator.Pubkey,
+					})
+				}
+			}
+		}
+	}
+
+	// Constellation
+	if cfg.Constellation.Enabled.Value {
+		cs, err := client.NewConstellationClientFromCtx(c, hd)
+		if err != nil {
+			return nil, err
+		}
+		response, err := cs.Api.Minipool.GetPubkeys(false)
+		if err != nil {
+			return nil, fmt.Errorf("error getting Constellation minipool pubkeys: %w", err)
+		}
+		for _, info := range response.Data.Infos {
+			validators = append(validators, mevBoostValidator{
+				module: "Constellation",
+				pubkey: info.Pubkey,
+			})
+		}
+	}
+	return validators, nil
+}
+
+// Check if a validator has exited and no longer needs to be registered with relays
+func isMevBoostValidatorExited(state beacon.ValidatorState) bool {
+	switch state {
+	case beacon.ValidatorState_ExitedUnslashed,
+		beacon.ValidatorState_ExitedSlashed,
+		beacon.ValidatorState_WithdrawalPossible,
+		beacon.ValidatorState_WithdrawalDone:
+		return true
+	default:
+		return false
+	}
+}
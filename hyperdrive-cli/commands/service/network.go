@@ -0,0 +1,150 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/urfave/cli/v2"
+)
+
+// Add a custom network from a definition file
+func addNetwork(c *cli.Context, path string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Load and validate the definition
+	definition, err := client.LoadCustomNetworkDefinition(path)
+	if err != nil {
+		return err
+	}
+	builtInNetworks, err := hd.GetBuiltInNetworks()
+	if err != nil {
+		return err
+	}
+	warnings, err := definition.Validate(builtInNetworks)
+	if err != nil {
+		return fmt.Errorf("network file [%s] is invalid:\n%w", path, err)
+	}
+	for _, warning := range warnings {
+		fmt.Printf("%sWARNING: %s.%s\n", terminal.ColorYellow, warning, terminal.ColorReset)
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+	}
+
+	// Check if it will replace an existing network
+	exists, err := hd.CustomNetworkExists(definition.Key)
+	if err != nil {
+		return err
+	}
+	if exists && !(c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("A custom network named [%s] already exists. Would you like to replace it?", definition.Key))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save it
+	err = hd.SaveCustomNetwork(definition)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Added network %s%s%s (%s, chain ID %d).\n", terminal.ColorGreen, definition.Key, terminal.ColorReset, definition.Name, definition.NetworkResources.ChainID)
+	fmt.Println("You can now select it with `hyperdrive service config`.")
+	return nil
+}
+
+// List the built-in and custom networks
+func listNetworks(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	builtInNetworks, err := hd.GetBuiltInNetworks()
+	if err != nil {
+		return err
+	}
+
+	// Get the current network, if there is one
+	var currentNetwork config.Network
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew {
+		currentNetwork = cfg.Hyperdrive.Network.Value
+	}
+
+	fmt.Printf("%-3s%-20s%-30s%-12s%s\n", "", "Key", "Name", "Chain ID", "Type")
+	for _, settings := range hd.Context.HyperdriveNetworkSettings {
+		marker := ""
+		if settings.Key == currentNetwork {
+			marker = "*"
+		}
+		networkType := "custom"
+		if slices.Contains(builtInNetworks, settings.Key) {
+			networkType = "built-in"
+		}
+		var chainID uint
+		if settings.NetworkResources != nil {
+			chainID = settings.NetworkResources.ChainID
+		}
+		fmt.Printf("%-3s%-20s%-30s%-12d%s\n", marker, settings.Key, settings.Name, chainID, networkType)
+	}
+	if currentNetwork != "" {
+		fmt.Println()
+		fmt.Println("* = the network Hyperdrive is currently configured to use")
+	}
+	return nil
+}
+
+// Remove a custom network
+func removeNetwork(c *cli.Context, key config.Network) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Make sure it can be removed
+	builtInNetworks, err := hd.GetBuiltInNetworks()
+	if err != nil {
+		return err
+	}
+	if slices.Contains(builtInNetworks, key) {
+		return fmt.Errorf("[%s] is a built-in network and can't be removed", key)
+	}
+	exists, err := hd.CustomNetworkExists(key)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("there is no custom network named [%s]", key)
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if !isNew && cfg.Hyperdrive.Network.Value == key {
+		return fmt.Errorf("Hyperdrive is currently configured to use [%s]. Please switch to a different network with `hyperdrive service config` before removing it", key)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("Are you sure you want to remove the custom network [%s]?", key))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = hd.RemoveCustomNetwork(key)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Removed network %s.\n", key)
+	return nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+// Check the override files for problems and stale customizations
+func checkOverrides(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	results, err := hd.CheckOverrides(getComposeFiles(c))
+	if err != nil {
+		return fmt.Errorf("error checking override files: %w", err)
+	}
+
+	errorCount := 0
+	warningCount := 0
+	for _, override := range results.Overrides {
+		if len(override.Errors) == 0 && len(override.Warnings) == 0 {
+			continue
+		}
+		status := ""
+		if !override.InUse {
+			status = " (not used by your current settings)"
+		}
+		fmt.Printf("%s%s:\n", override.Name, status)
+		for _, problem := range override.Errors {
+			fmt.Printf("\t%sERROR: %s%s\n", terminal.ColorRed, problem, terminal.ColorReset)
+		}
+		for _, warning := range override.Warnings {
+			fmt.Printf("\t%sWARNING: %s%s\n", terminal.ColorYellow, warning, terminal.ColorReset)
+		}
+		errorCount += len(override.Errors)
+		warningCount += len(override.Warnings)
+	}
+	if results.ProjectError != "" {
+		fmt.Printf("%sDocker Compose won't be able to load Hyperdrive with all of your overrides applied: %s%s\n", terminal.ColorRed, results.ProjectError, terminal.ColorReset)
+		errorCount++
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("found %d error(s) and %d warning(s) in your override files; Hyperdrive won't start until the errors are fixed", errorCount, warningCount)
+	}
+	if warningCount > 0 {
+		fmt.Printf("\nChecked %d override files: no errors, %d warning(s).\n", len(results.Overrides), warningCount)
+		return nil
+	}
+	fmt.Printf("%sChecked %d override files: no problems found.%s\n", terminal.ColorGreen, len(results.Overrides), terminal.ColorReset)
+	return nil
+}
+
+// Print the changes each override file makes to the compose file it overrides
+func diffOverrides(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	diffs, err := hd.DiffOverrides(getComposeFiles(c))
+	if err != nil {
+		return fmt.Errorf("error comparing override files: %w", err)
+	}
+
+	changed := 0
+	for _, diff := range diffs {
+		if diff.Diff == "" {
+			continue
+		}
+		changed++
+		for _, line := range strings.SplitAfter(diff.Diff, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				fmt.Print(line)
+			case strings.HasPrefix(line, "+"):
+				fmt.Printf("%s%s%s", terminal.ColorGreen, line, terminal.ColorReset)
+			case strings.HasPrefix(line, "-"):
+				fmt.Printf("%s%s%s", terminal.ColorRed, line, terminal.ColorReset)
+			default:
+				fmt.Print(line)
+			}
+		}
+		fmt.Println()
+	}
+	if changed == 0 {
+		fmt.Println("None of your override files change anything.")
+	}
+	return nil
+}
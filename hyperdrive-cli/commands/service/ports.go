@@ -0,0 +1,109 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliutils "github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	fixPortsFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "fix-ports",
+		Usage: "Move any port settings that conflict with something else on this machine to free ports, and save them",
+	}
+	ignorePortConflictsFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "ignore-port-conflicts",
+		Usage: "Start the service even if some of the ports it needs are already in use",
+	}
+)
+
+var (
+	// Returned when some of the ports Hyperdrive needs are still in use after checking for conflicts
+	errUnresolvedPortConflicts error = errors.New("some of the ports Hyperdrive needs are already in use")
+)
+
+// Check for ports Hyperdrive needs that are already in use
+func checkPorts(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("no configuration detected; please run `hyperdrive service config` to set up Hyperdrive first")
+	}
+
+	// Print the ports
+	fmt.Println("Hyperdrive will bind the following ports on this machine:")
+	for _, port := range cfg.GetHostPorts() {
+		binding := "all interfaces"
+		if port.Localhost {
+			binding = "localhost only"
+		}
+		fmt.Printf("\t%d/%s\t%s (%s), %s\n", port.Param.Value, port.Protocol, port.Param.Name, port.Path, binding)
+	}
+	fmt.Println()
+
+	return resolvePortConflicts(c, hd, cfg)
+}
+
+// Check for port conflicts, and move the conflicting settings to free ports if the user wants to.
+// Returns errUnresolvedPortConflicts if there are conflicts left.
+func resolvePortConflicts(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig) error {
+	conflicts, err := hd.FindPortConflicts(cfg)
+	if err != nil {
+		return fmt.Errorf("error checking for port conflicts: %w", err)
+	}
+	if len(conflicts) == 0 {
+		fmt.Println("None of the ports Hyperdrive needs are in use.")
+		return nil
+	}
+	return handlePortConflicts(c, hd, cfg, conflicts)
+}
+
+// Print the port conflicts, and move the conflicting settings to free ports if the user wants to.
+// In non-interactive mode they're only moved if --fix-ports is set, so the caller fails instead of silently skipping the conflicts.
+// Returns errUnresolvedPortConflicts if there are conflicts left.
+func handlePortConflicts(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig, conflicts []client.PortConflict) error {
+	// Print the conflicts
+	fmt.Printf("%sSome of the ports Hyperdrive needs are already in use:%s\n", terminal.ColorYellow, terminal.ColorReset)
+	for _, conflict := range conflicts {
+		fmt.Printf("\t%d/%s for %s (%s) is used by %s\n", conflict.Port.Param.Value, conflict.Port.Protocol, conflict.Port.Param.Name, conflict.Port.Path, conflict.Owner)
+	}
+	fmt.Println()
+
+	// Ask to fix them
+	fix := c.Bool(fixPortsFlag.Name)
+	if !fix && c.Bool(cliutils.YesFlag.Name) {
+		return fmt.Errorf("%w; the '%s' flag (non-interactive mode) is specified, so run it again with `--%s` to move them automatically or change them with `hyperdrive service config`", errUnresolvedPortConflicts, cliutils.YesFlag.Name, fixPortsFlag.Name)
+	}
+	if !fix {
+		fix = cliutils.Confirm("Would you like Hyperdrive to move these settings to free ports and save them?")
+	}
+	if !fix {
+		return fmt.Errorf("%w; change them with `hyperdrive service config`, or run this again with `--%s` to move them automatically", errUnresolvedPortConflicts, fixPortsFlag.Name)
+	}
+
+	// Move them and save
+	reassignments, err := hd.ReassignConflictingPorts(cfg, conflicts)
+	if err != nil {
+		return fmt.Errorf("error moving port settings: %w", err)
+	}
+	err = hd.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving settings: %w", err)
+	}
+	for _, reassignment := range reassignments {
+		fmt.Printf("Moved %s (%s) from %d to %d.\n", reassignment.Port.Param.Name, reassignment.Port.Path, reassignment.OldPort, reassignment.Port.Param.Value)
+	}
+	fmt.Printf("%sUpdated settings successfully.%s\n\n", terminal.ColorGreen, terminal.ColorReset)
+	return nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliutils "github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestHandlePortConflicts_NonInteractive(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(cliutils.YesFlag.Name, true, "")
+	set.Bool(fixPortsFlag.Name, false, "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	conflicts := []client.PortConflict{
+		{
+			Port: client.HostPort{
+				Param: &config.Parameter[uint16]{
+					ParameterCommon: &config.ParameterCommon{
+						Name: "API Port",
+					},
+					Value: 8080,
+				},
+				Path:     "hyperdrive.apiPort",
+				Protocol: "tcp",
+			},
+			Owner: "another process on this machine",
+		},
+	}
+
+	// Without --fix-ports, --yes has to fail instead of prompting or silently skipping the start
+	err := handlePortConflicts(c, nil, nil, conflicts)
+	require.ErrorIs(t, err, errUnresolvedPortConflicts)
+	require.Contains(t, err.Error(), fixPortsFlag.Name)
+}
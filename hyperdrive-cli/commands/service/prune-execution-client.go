@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	prunePollInterval time.Duration = 15 * time.Second
+)
+
+var (
+	pruneAutoFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "auto",
+		Usage: "Only prune if the free space on the Execution client's volume is below the auto-prune threshold in the service configuration. Intended to be run from a scheduled job; implies --yes.",
+	}
+	pruneForceFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: fmt.Sprintf("Prune even if no fallback clients are configured or there isn't enough free space for the prune. %sYour validators will miss attestations while the Execution client is offline.%s", terminal.ColorRed, terminal.ColorReset),
+	}
+	pruneNoWaitFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "no-wait",
+		Usage: "Start pruning and exit immediately instead of waiting for it to finish",
+	}
+)
+
+// Prune the Execution client's database
+func pruneExecutionClient(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+
+	// Check the client mode
+	if !cfg.Hyperdrive.IsLocalMode() {
+		fmt.Println("You use an externally-managed Execution client. Hyperdrive cannot prune it for you.")
+		return nil
+	}
+
+	// Get the prune manager
+	mgr, err := client.NewEcPruneManager(hd, cfg)
+	if err != nil {
+		return err
+	}
+	if mgr.Mechanism == client.EcPruneMechanism_None {
+		fmt.Printf("Your Execution client (%s) prunes its database automatically and doesn't support manual pruning.\n", mgr.Client)
+		return nil
+	}
+	auto := c.Bool(pruneAutoFlag.Name)
+	force := c.Bool(pruneForceFlag.Name)
+
+	// Check the free space
+	isBelowThreshold, freeSpace, err := mgr.IsBelowThreshold()
+	if err != nil {
+		return fmt.Errorf("error checking free space on the Execution client volume: %w", err)
+	}
+	fmt.Printf("Your Execution client volume has %s of free space.\n", humanize.IBytes(freeSpace))
+	if auto {
+		threshold := cfg.Cli.EcPruning.FreeSpaceThreshold.Value
+		if threshold == 0 {
+			fmt.Println("Automatic pruning is disabled; set an auto-prune threshold with `hyperdrive service config` to enable it.")
+			return nil
+		}
+		if !isBelowThreshold {
+			fmt.Printf("This is above the auto-prune threshold of %d GB, so no pruning is required.\n", threshold)
+			return nil
+		}
+		fmt.Printf("This is below the auto-prune threshold of %d GB, so Hyperdrive will prune your Execution client now.\n", threshold)
+	}
+
+	// Make sure validators won't be left without a client
+	if !cfg.Hyperdrive.Fallback.UseFallbackClients.Value {
+		if !force {
+			fmt.Printf("%sYou do not have fallback clients configured, so your validators will miss attestations while your Execution client is pruning.\nPlease configure fallback clients with `hyperdrive service config`, or re-run this with `--%s` if you understand the risks.%s\n", terminal.ColorRed, pruneForceFlag.Name, terminal.ColorReset)
+			return nil
+		}
+		fmt.Printf("%sWARNING: You do not have fallback clients configured. Your validators will miss attestations while your Execution client is pruning.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	} else {
+		fmt.Println("You have fallback clients configured. Your validators will use them while your Execution client is pruning.")
+	}
+
+	// Offline pruning needs working space on the disk
+	if mgr.Mechanism == client.EcPruneMechanism_Offline && freeSpace < PruneFreeSpaceRequired {
+		if !force {
+			fmt.Printf("%sYour disk must have at least %s free to prune %s. Please free up some space and try again, or re-run this with `--%s`.%s\n", terminal.ColorRed, humanize.IBytes(PruneFreeSpaceRequired), mgr.Client, pruneForceFlag.Name, terminal.ColorReset)
+			return nil
+		}
+		fmt.Printf("%sWARNING: Your disk has less than %s free, so pruning may fail.%s\n", terminal.ColorYellow, humanize.IBytes(PruneFreeSpaceRequired), terminal.ColorReset)
+	}
+
+	// Describe what will happen
+	switch mgr.Mechanism {
+	case client.EcPruneMechanism_Offline:
+		fmt.Printf("%s will be shut down and restarted in pruning mode. It will not be available until pruning is complete, which can take several hours.\n", mgr.Client)
+	case client.EcPruneMechanism_Online:
+		fmt.Printf("%s will prune itself while it continues to run. It will restart automatically when pruning is complete, which can take several hours.\n", mgr.Client)
+	}
+
+	// Prompt for confirmation
+	if !(auto || c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("Are you sure you want to prune your %s database?", mgr.Client))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Start pruning
+	fmt.Printf("Starting pruning of %s...\n", mgr.ContainerName)
+	err = mgr.Start()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Pruning has started.")
+	if c.Bool(pruneNoWaitFlag.Name) {
+		fmt.Printf("You can follow its progress with `hyperdrive service logs ec`.\n")
+		return nil
+	}
+
+	// Poll for progress
+	fmt.Println("Waiting for pruning to finish; you can safely exit with Ctrl+C and pruning will continue in the background.")
+	lastMessage := ""
+	for {
+		time.Sleep(prunePollInterval)
+		progress, err := mgr.GetProgress()
+		if err != nil {
+			fmt.Printf("%sWARNING: Error checking pruning progress: %s%s\n", terminal.ColorYellow, err.Error(), terminal.ColorReset)
+			continue
+		}
+		if progress.Complete {
+			fmt.Printf("\nDone! Pruning is complete and %s has restarted. Your Execution client volume now has %s of free space.\n", mgr.Client, humanize.IBytes(progress.FreeSpace))
+			return nil
+		}
+		if progress.LatestMessage != "" && progress.LatestMessage != lastMessage {
+			fmt.Printf("[%s free] %s\n", humanize.IBytes(progress.FreeSpace), progress.LatestMessage)
+			lastMessage = progress.LatestMessage
+		}
+	}
+}
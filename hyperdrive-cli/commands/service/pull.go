@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	pullVerifyFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "verify",
+		Usage: "Don't pull anything; instead, ask each image's registry whether its tag still points to the digest in the image lock file",
+	}
+)
+
+// Pull the images for the current settings and record their digests
+func pullImages(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("no configuration detected; please run `hyperdrive service config` to set up Hyperdrive first")
+	}
+
+	// Get the images
+	images, err := hd.GetServiceImages(getComposeFiles(c))
+	if err != nil {
+		return fmt.Errorf("error getting the images for your settings: %w", err)
+	}
+	if c.Bool(pullVerifyFlag.Name) {
+		return verifyImages(hd, images)
+	}
+
+	// Pull each one
+	oldLock, err := hd.LoadImageLock()
+	if err != nil {
+		return err
+	}
+	lock := &client.ImageLock{
+		Images: map[string]string{},
+	}
+	for i, image := range images {
+		fmt.Printf("%s[%d/%d] Pulling %s%s\n", terminal.ColorBlue, i+1, len(images), image, terminal.ColorReset)
+		digest, err := hd.PullImage(image)
+		if err != nil {
+			return err
+		}
+		lock.Images[image] = digest
+
+		oldDigest := oldLock.Images[image]
+		if oldDigest != "" && oldDigest != digest {
+			fmt.Printf("%s%s has moved from %s to %s.%s\n", terminal.ColorYellow, image, oldDigest, digest, terminal.ColorReset)
+		}
+		fmt.Println()
+	}
+
+	// Save the digests
+	err = hd.SaveImageLock(lock)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sPulled %d images and recorded their digests in %s.%s\n", terminal.ColorGreen, len(images), client.ImageLockFile, terminal.ColorReset)
+	if !cfg.Cli.Images.PinDigests.Value {
+		fmt.Printf("Enable \"%s\" in `hyperdrive service config` to start the containers with these exact digests.\n", cfg.Cli.Images.PinDigests.Name)
+	}
+	return nil
+}
+
+// Check whether the image tags still point to the digests in the lock file
+func verifyImages(hd *client.HyperdriveClient, images []string) error {
+	fmt.Printf("Checking %d images against their registries...\n", len(images))
+	drift, err := hd.VerifyImageLock(images)
+	if err != nil {
+		return err
+	}
+	if len(drift) == 0 {
+		fmt.Printf("%sAll of the images match the image lock file.%s\n", terminal.ColorGreen, terminal.ColorReset)
+		return nil
+	}
+
+	for _, image := range drift {
+		if image.LockedDigest == "" {
+			fmt.Printf("%s%s isn't in the image lock file.%s\n", terminal.ColorYellow, image.Image, terminal.ColorReset)
+			continue
+		}
+		fmt.Printf("%s%s has moved:%s\n", terminal.ColorYellow, image.Image, terminal.ColorReset)
+		fmt.Printf("\tLocked:  %s\n", image.LockedDigest)
+		fmt.Printf("\tCurrent: %s\n", image.CurrentDigest)
+	}
+	fmt.Println("Run `hyperdrive service pull` to pull the current images and update the lock file.")
+	return fmt.Errorf("%d image(s) don't match the image lock file", len(drift))
+}
+
+// Warn about images that will be started by tag because they aren't in the image lock file
+func warnUnlockedImages(hd *client.HyperdriveClient, composeFiles []string) {
+	images, err := hd.GetServiceImages(composeFiles)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%sWARNING: couldn't check the images against the image lock file: %s%s\n", terminal.ColorYellow, err.Error(), terminal.ColorReset)
+		return
+	}
+	lock, err := hd.LoadImageLock()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%sWARNING: couldn't check the images against the image lock file: %s%s\n", terminal.ColorYellow, err.Error(), terminal.ColorReset)
+		return
+	}
+
+	unlocked := []string{}
+	for _, image := range images {
+		if _, exists := lock.Images[image]; !exists {
+			unlocked = append(unlocked, image)
+		}
+	}
+	if len(unlocked) == 0 {
+		return
+	}
+	fmt.Printf("%sThe following images aren't in the image lock file, so they'll be started by their tags instead of pinned digests:%s\n", terminal.ColorYellow, terminal.ColorReset)
+	for _, image := range unlocked {
+		fmt.Printf("\t%s\n", image)
+	}
+	fmt.Println("Run `hyperdrive service pull` to lock them.")
+	fmt.Println()
+}
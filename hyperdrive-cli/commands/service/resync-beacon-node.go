@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
@@ -44,6 +45,27 @@ func resyncBeaconNode(c *cli.Context) error {
 		fmt.Printf("You have a checkpoint sync provider configured (%s).\nYour Beacon Node will use it to sync to the head of the Beacon Chain instantly after being rebuilt.\n\n", checkpointSyncUrl)
 	}
 
+	// Make sure the checkpoint sync provider can be trusted before relying on it
+	if checkpointSyncUrl != "" {
+		trusted, err := checkCheckpointSources(hd, cfg)
+		if err != nil {
+			return fmt.Errorf("error verifying checkpoint sync provider: %w", err)
+		}
+		if !trusted {
+			return nil
+		}
+
+		// Show the last verified checkpoint so the resynced chain can be compared to it
+		checkpoint, err := hd.LoadVerifiedCheckpoint()
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil && checkpoint.Network == cfg.Hyperdrive.Network.Value {
+			fmt.Printf("The last checkpoint your sources agreed on (%s) is slot %d with block root %s. Once your Beacon Node has resynced, that block should be on its chain.\n", checkpoint.VerifiedAt.Format(time.RFC1123), checkpoint.Slot, checkpoint.BlockRoot)
+		}
+		fmt.Println()
+	}
+
 	// Prompt for confirmation
 	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("%sAre you SURE you want to delete and resync your main Beacon Node from scratch? This cannot be undone!%s", terminal.ColorRed, terminal.ColorReset))) {
 		fmt.Println("Cancelled.")
@@ -59,7 +81,7 @@ func resyncBeaconNode(c *cli.Context) error {
 	}
 
 	// Get the BN volume name
-	volume, err := hd.GetClientVolumeName(beaconContainerName, clientDataVolumeName)
+	volume, err := hd.GetClientVolumeName(beaconContainerName, client.ClientDataMountPath)
 	if err != nil {
 		return fmt.Errorf("Error getting Beacon Node volume name: %w", err)
 	}
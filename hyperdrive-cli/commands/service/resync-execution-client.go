@@ -51,7 +51,7 @@ func resyncExecutionClient(c *cli.Context) error {
 	}
 
 	// Get Execution volume name
-	volume, err := hd.GetClientVolumeName(executionContainerName, clientDataVolumeName)
+	volume, err := hd.GetClientVolumeName(executionContainerName, client.ClientDataMountPath)
 	if err != nil {
 		return fmt.Errorf("Error getting Execution client volume name: %w", err)
 	}
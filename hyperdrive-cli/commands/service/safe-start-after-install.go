@@ -12,6 +12,7 @@ import (
 	dmount "github.com/docker/docker/api/types/mount"
 	docker "github.com/docker/docker/client"
 	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/rocket-pool/node-manager-core/config"
 )
 
 const (
@@ -109,6 +110,16 @@ func safeStartAfterInstall(systemDir string) {
 		}
 		owner := userDirStat.Uid
 
+		// Restart it through its systemd unit if it has one
+		projectName := strings.TrimSuffix(containerName, "_"+string(config.ContainerID_Daemon))
+		reloaded, err := client.ReloadSystemdUnit(projectName)
+		if err != nil {
+			fmt.Printf("WARN: %s\n", err.Error())
+		}
+		if reloaded {
+			continue
+		}
+
 		// Start the service
 		success := client.StartServiceAsUser(owner, hyperdriveBinPath, userDir)
 		if !success {
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -82,6 +83,18 @@ func startService(c *cli.Context, startMode StartMode) error {
 		}
 	}
 
+	// Make sure the ports are free before they're written into the metrics and container configs
+	if c.Bool(ignorePortConflictsFlag.Name) {
+		fmt.Printf("%sIgnoring port conflicts.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	} else {
+		err := resolvePortConflicts(c, hd, cfg)
+		if errors.Is(err, errUnresolvedPortConflicts) {
+			return fmt.Errorf("%w.\nIf you're sure these ports are free, run `hyperdrive service start --%s`", err, ignorePortConflictsFlag.Name)
+		} else if err != nil {
+			fmt.Fprintf(os.Stderr, "%sWARNING: couldn't check for port conflicts: %s%s\n", terminal.ColorYellow, err.Error(), terminal.ColorReset)
+		}
+	}
+
 	// Update the Prometheus and Grafana config templates with the assigned ports
 	if cfg.Hyperdrive.Metrics.EnableMetrics.Value {
 		err := hd.DeployMetricsConfigurations(cfg)
@@ -107,6 +120,11 @@ func startService(c *cli.Context, startMode StartMode) error {
 		return nil
 	}
 
+	// Warn about resource limits that don't fit on this machine
+	for _, warning := range cfg.CheckResourceCapacity() {
+		fmt.Printf("%sWARNING: %s%s\n", terminal.ColorYellow, warning, terminal.ColorReset)
+	}
+
 	// Check if the user has any modules enabled
 	enabledModules := 0
 	if cfg.StakeWise.Enabled.Value {
@@ -129,8 +147,7 @@ func startService(c *cli.Context, startMode StartMode) error {
 				fmt.Println("**If you did NOT change clients, you can safely ignore this warning.**")
 				fmt.Println()
 				if c.Bool(cliutils.YesFlag.Name) {
-					fmt.Println("Aborting auto-start sequence due to non-interactive mode.")
-					return nil
+					return fmt.Errorf("aborting auto-start sequence due to non-interactive mode; please run `hyperdrive service start` manually once it's safe to start your Validator Clients")
 				}
 				if !cliutils.Confirm(fmt.Sprintf("Press y when you understand the above warning, have waited, and are ready to start Hyperdrive:%s", terminal.ColorReset)) {
 					fmt.Println("Cancelled.")
@@ -138,8 +155,7 @@ func startService(c *cli.Context, startMode StartMode) error {
 				}
 			} else if firstRun {
 				if c.Bool(cliutils.YesFlag.Name) {
-					fmt.Println("It looks like this is your first time starting a Validator Client, but auto-start is being aborted for safety due to non-interactive mode. Please run `hyperdrive service start` manually when you can.")
-					return nil
+					return fmt.Errorf("it looks like this is your first time starting a Validator Client, but auto-start is being aborted for safety due to non-interactive mode; please run `hyperdrive service start` manually when you can")
 				}
 				fmt.Println("It looks like this is your first time starting a Validator Client.")
 				existingNode := cliutils.Confirm("Just to be sure, do you have any existing, active validators attesting on the Beacon Chain that were created with your Hyperdrive node wallet (if you have one)?")
@@ -172,6 +188,11 @@ func startService(c *cli.Context, startMode StartMode) error {
 		}
 	}
 
+	// Make sure the images are locked if they should be pinned
+	if cfg.Cli.Images.PinDigests.Value {
+		warnUnlockedImages(hd, getComposeFiles(c))
+	}
+
 	// Start service
 	err = hd.StartService(getComposeFiles(c))
 	if err != nil {
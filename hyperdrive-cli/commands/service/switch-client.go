@@ -0,0 +1,563 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rocket-pool/node-manager-core/api/types"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// How often to check the new client's sync progress
+	switchClientSyncCheckInterval time.Duration = 30 * time.Second
+)
+
+var (
+	switchClientForceFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "force",
+		Usage: "Skip the free space check",
+	}
+	switchClientNoWaitFlag *cli.BoolFlag = &cli.BoolFlag{
+		Name:  "no-wait",
+		Usage: "Don't wait for the new client to sync; run the command again later to check on it and finish the switch",
+	}
+)
+
+// Switch the local Execution client or Beacon Node to a different client, keeping the old data until the new client has synced
+func switchClient(c *cli.Context, dataType client.ChainDataType, newClient string) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+	if !cfg.Hyperdrive.IsLocalMode() {
+		fmt.Println("You use externally-managed clients. Hyperdrive cannot switch them for you.")
+		return nil
+	}
+
+	// Resume an earlier switch if there is one
+	state, err := hd.LoadClientSwitchState()
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		if state.Type != dataType || state.NewClient != newClient {
+			return fmt.Errorf("a switch of your %s from %s to %s is already in progress; please finish it with `hyperdrive service switch-client %s %s` or undo it with `hyperdrive service switch-client abort` first", state.Type.ClientName(), state.OldClient, state.NewClient, state.Type, state.NewClient)
+		}
+		fmt.Printf("Resuming the switch of your %s from %s to %s that started on %s.\n\n", dataType.ClientName(), state.OldClient, state.NewClient, state.StartedAt.Format(time.RFC1123))
+	} else {
+		state, err = prepareClientSwitch(c, hd, cfg, dataType, newClient)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return nil
+		}
+	}
+
+	// Run the remaining stages
+	if state.Stage == client.ClientSwitchStage_Prepared {
+		err = startClientSwitch(c, hd, cfg, state)
+		if err != nil {
+			return err
+		}
+	}
+	if state.Stage == client.ClientSwitchStage_Syncing {
+		synced, err := waitForClientSwitchSync(c, hd, state)
+		if err != nil {
+			return err
+		}
+		if !synced {
+			return nil
+		}
+		state.Stage = client.ClientSwitchStage_CleaningUp
+		err = hd.SaveClientSwitchState(state)
+		if err != nil {
+			return err
+		}
+	}
+	return finishClientSwitch(c, hd, cfg, state)
+}
+
+// Check that the switch can be done, confirm it with the user, and save its initial state.
+// Returns nil if the switch was cancelled.
+func prepareClientSwitch(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig, dataType client.ChainDataType, newClient string) (*client.ClientSwitchState, error) {
+	clientName := dataType.ClientName()
+
+	// Get the current client and check the new one
+	var oldClient string
+	var validClients []string
+	switch dataType {
+	case client.ChainDataType_Execution:
+		oldClient = string(cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value)
+		for _, option := range cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Options {
+			validClients = append(validClients, string(option.Value))
+		}
+	case client.ChainDataType_Beacon:
+		oldClient = string(cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value)
+		for _, option := range cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Options {
+			validClients = append(validClients, string(option.Value))
+		}
+	}
+	isValid := false
+	for _, validClient := range validClients {
+		if validClient == newClient {
+			isValid = true
+			break
+		}
+	}
+	if !isValid {
+		return nil, fmt.Errorf("unknown %s [%s]; expected one of: %s", clientName, newClient, strings.Join(validClients, ", "))
+	}
+	if oldClient == newClient {
+		fmt.Printf("Your %s is already %s.\n", clientName, newClient)
+		return nil, nil
+	}
+
+	// The Beacon Node has to move to the new Execution client to sync it, which leaves the old one without a Beacon Node to follow the chain.
+	// The validators need fallback clients to keep working until the new one has synced.
+	hasValidators := cfg.StakeWise.Enabled.Value || cfg.Constellation.Enabled.Value
+	if dataType == client.ChainDataType_Execution && hasValidators && !cfg.Hyperdrive.Fallback.UseFallbackClients.Value {
+		fmt.Printf("%sYour Beacon Node can't follow the chain until %s has synced, so your validators need fallback clients to keep working during the switch. Please configure fallback clients with `hyperdrive service config` before switching your %s.%s\n", terminal.ColorRed, newClient, clientName, terminal.ColorReset)
+		return nil, nil
+	}
+
+	// Get the current container and volume
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(dataType.ContainerID()))
+	oldVolume, err := hd.GetClientVolumeName(containerName, client.ClientDataMountPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s volume name: %w", clientName, err)
+	}
+
+	// Make sure there's room for the new client's data alongside the old data
+	ethNetworkName := cfg.Hyperdrive.GetEthNetworkName()
+	requiredSpace := client.GetEstimatedClientDataSize(dataType, newClient, ethNetworkName)
+	volumePath, err := hd.GetClientVolumeSource(containerName, client.ClientDataMountPath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s volume path: %w", clientName, err)
+	}
+	freeSpace, err := client.GetPathFreeSpace(volumePath)
+	if err != nil {
+		return nil, fmt.Errorf("error getting free space of the %s volume: %w", clientName, err)
+	}
+	fmt.Printf("%s needs about %s on %s; your disk has %s available.\n", newClient, humanize.IBytes(requiredSpace), ethNetworkName, humanize.IBytes(freeSpace))
+	if freeSpace < requiredSpace {
+		if !c.Bool(switchClientForceFlag.Name) {
+			fmt.Printf("%sYour disk doesn't have enough space to sync %s while keeping your %s data. Please free up some space, or re-run this with `--%s` to skip this check.%s\n", terminal.ColorRed, newClient, oldClient, switchClientForceFlag.Name, terminal.ColorReset)
+			return nil, nil
+		}
+		fmt.Printf("%sIgnoring the free space check.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	}
+
+	// Pick a fresh volume for the new client
+	newVolumeSetting := fmt.Sprintf("%s_%s", defaultClientVolume(dataType), newClient)
+	newVolume := cfg.Hyperdrive.GetDockerArtifactName(newVolumeSetting)
+	if newVolume == oldVolume {
+		newVolumeSetting = fmt.Sprintf("%s_%d", newVolumeSetting, time.Now().Unix())
+		newVolume = cfg.Hyperdrive.GetDockerArtifactName(newVolumeSetting)
+	}
+	exists, err := hd.VolumeExists(newVolume)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan the fallback: an old Beacon Node keeps running under a new name and serves as the fallback until the new one has synced.
+	// An old Execution client is just stopped, since nothing would drive it; the fallback clients cover the validators instead.
+	state := &client.ClientSwitchState{
+		Type:             dataType,
+		OldClient:        oldClient,
+		NewClient:        newClient,
+		Network:          cfg.Hyperdrive.Network.Value,
+		OldVolume:        oldVolume,
+		NewVolumeSetting: newVolumeSetting,
+		PreviousFallback: client.ClientSwitchFallbackSettings{
+			UseFallbackClients: cfg.Hyperdrive.Fallback.UseFallbackClients.Value,
+			EcHttpUrl:          cfg.Hyperdrive.Fallback.EcHttpUrl.Value,
+			BnHttpUrl:          cfg.Hyperdrive.Fallback.BnHttpUrl.Value,
+			PrysmRpcUrl:        cfg.Hyperdrive.Fallback.PrysmRpcUrl.Value,
+		},
+		Stage:     client.ClientSwitchStage_Prepared,
+		StartedAt: time.Now(),
+	}
+	switch dataType {
+	case client.ChainDataType_Execution:
+		state.OldVolumeSetting = cfg.Cli.ClientVolumes.EcDataVolume.Value
+	case client.ChainDataType_Beacon:
+		state.OldVolumeSetting = cfg.Cli.ClientVolumes.BnDataVolume.Value
+		state.OldContainer = client.GetClientSwitchOldContainerName(cfg, dataType)
+		state.EnabledFallback = !cfg.Hyperdrive.Fallback.UseFallbackClients.Value && newClient != string(config.BeaconNode_Prysm)
+	}
+
+	// Describe the plan
+	fmt.Println()
+	fmt.Printf("Hyperdrive will switch your %s from %s to %s:\n", clientName, oldClient, newClient)
+	if exists {
+		fmt.Printf("\t- %s will reuse the data already in volume %s.\n", newClient, newVolume)
+	} else {
+		fmt.Printf("\t- %s will sync into a new volume, %s.\n", newClient, newVolume)
+	}
+	switch {
+	case state.OldContainer == "":
+		fmt.Printf("\t- %s will be stopped, and its data will be kept in volume %s until %s has synced.\n", oldClient, oldVolume, newClient)
+	case state.EnabledFallback:
+		fmt.Printf("\t- %s will keep running as %s and will be used as your fallback %s until %s has synced.\n", oldClient, state.OldContainer, clientName, newClient)
+	default:
+		fmt.Printf("\t- %s will keep running as %s until %s has synced.\n", oldClient, state.OldContainer, newClient)
+	}
+	if dataType == client.ChainDataType_Execution {
+		fmt.Printf("\t- Your Beacon Node will switch to %s right away, since it has to drive %s for it to sync.\n", newClient, newClient)
+		if hasValidators {
+			fmt.Printf("\t- Your validators will use your fallback clients until %s has synced.\n", newClient)
+		}
+	}
+	fmt.Printf("\t- Once %s has synced, %s and its volume %s will be deleted.\n", newClient, oldClient, oldVolume)
+	fmt.Printf("\t- Until then, you can undo the switch with `hyperdrive service switch-client abort`.\n")
+	fmt.Println()
+	if hasValidators {
+		if !state.EnabledFallback && !cfg.Hyperdrive.Fallback.UseFallbackClients.Value {
+			fmt.Printf("%sYou don't have fallback clients configured, so your validators will miss their duties until %s has synced.%s\n", terminal.ColorYellow, newClient, terminal.ColorReset)
+		} else if dataType == client.ChainDataType_Beacon && newClient == string(config.BeaconNode_Prysm) && !cfg.Hyperdrive.Fallback.UseFallbackClients.Value {
+			fmt.Printf("%sThe Prysm Validator Client can't use %s as a fallback, so your validators will miss their duties until %s has synced.%s\n", terminal.ColorYellow, oldClient, newClient, terminal.ColorReset)
+		}
+		if dataType == client.ChainDataType_Beacon {
+			fmt.Println("Your Validator Clients will change along with your Beacon Node, so Hyperdrive will wait 15 minutes before starting them to protect you from slashing.")
+		}
+		fmt.Println()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("Are you sure you want to switch your %s to %s?", clientName, newClient))) {
+		fmt.Println("Cancelled.")
+		return nil, nil
+	}
+
+	err = hd.SaveClientSwitchState(state)
+	if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Move the old client aside, point the config at the new client, and start it
+func startClientSwitch(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig, state *client.ClientSwitchState) error {
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(state.Type.ContainerID()))
+	exists, err := hd.ContainerExists(containerName)
+	if err != nil {
+		return err
+	}
+
+	// Move the old client out of the way; an old Beacon Node keeps running under its new name until the hand-off in finishClientSwitch
+	if exists {
+		fmt.Printf("Stopping %s...\n", containerName)
+		err = hd.StopContainer(containerName)
+		if err != nil {
+			fmt.Printf("%sWARNING: Stopping %s failed: %s%s\n", terminal.ColorYellow, containerName, err.Error(), terminal.ColorReset)
+		}
+
+		if state.OldContainer != "" {
+			oldExists, err := hd.ContainerExists(state.OldContainer)
+			if err != nil {
+				return err
+			}
+			if !oldExists {
+				fmt.Printf("Creating %s to keep %s running...\n", state.OldContainer, state.OldClient)
+				err = hd.CloneContainerWithoutPorts(containerName, state.OldContainer)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		fmt.Printf("Deleting %s...\n", containerName)
+		err = hd.RemoveContainer(containerName)
+		if err != nil {
+			return fmt.Errorf("error deleting %s container: %w", state.Type.ClientName(), err)
+		}
+	}
+	if state.OldContainer != "" {
+		fmt.Printf("Starting %s...\n", state.OldContainer)
+		err = hd.StartContainer(state.OldContainer)
+		if err != nil {
+			return fmt.Errorf("error starting %s: %w", state.OldContainer, err)
+		}
+	}
+
+	// Switch the config to the new client
+	switch state.Type {
+	case client.ChainDataType_Execution:
+		cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value = config.ExecutionClient(state.NewClient)
+		cfg.Cli.ClientVolumes.EcDataVolume.Value = state.NewVolumeSetting
+	case client.ChainDataType_Beacon:
+		cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value = config.BeaconNode(state.NewClient)
+		cfg.Cli.ClientVolumes.BnDataVolume.Value = state.NewVolumeSetting
+	}
+	if state.EnabledFallback {
+		cfg.Hyperdrive.Fallback.UseFallbackClients.Value = true
+		cfg.Hyperdrive.Fallback.EcHttpUrl.Value = cfg.Hyperdrive.GetEcHttpEndpoint()
+		cfg.Hyperdrive.Fallback.BnHttpUrl.Value = fmt.Sprintf("http://%s:%d", state.OldContainer, cfg.Hyperdrive.LocalBeaconClient.HttpPort.Value)
+	}
+	err = hd.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+
+	// Start the new client. The switch stays in the prepared stage until it's running, so running this again picks up from here.
+	fmt.Printf("Starting %s...\n", state.NewClient)
+	err = startService(c, StartMode_NoUpdate)
+	if err != nil {
+		return fmt.Errorf("error starting Hyperdrive: %w", err)
+	}
+	running, err := hd.GetRunningContainers(cfg.Hyperdrive.ProjectName.Value)
+	if err != nil {
+		return err
+	}
+	if !running[containerName] {
+		return fmt.Errorf("%s isn't running; please fix the problem above and run `hyperdrive service switch-client %s %s` again to resume the switch, or undo it with `hyperdrive service switch-client abort`", containerName, state.Type, state.NewClient)
+	}
+	state.Stage = client.ClientSwitchStage_Syncing
+	err = hd.SaveClientSwitchState(state)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// Wait for the new client to finish syncing. Returns false if it hasn't synced and the user didn't want to wait.
+func waitForClientSwitchSync(c *cli.Context, hd *client.HyperdriveClient, state *client.ClientSwitchState) (bool, error) {
+	noWait := c.Bool(switchClientNoWaitFlag.Name)
+	if !noWait {
+		fmt.Printf("Waiting for %s to sync. You can safely stop this with Ctrl+C and run `hyperdrive service switch-client %s %s` again later to pick up where it left off.\n", state.NewClient, state.Type, state.NewClient)
+	}
+	for {
+		status, err := getClientSwitchSyncStatus(hd, state.Type)
+		switch {
+		case err != nil:
+			fmt.Printf("%sCouldn't get the sync status of %s: %s%s\n", terminal.ColorYellow, state.NewClient, err.Error(), terminal.ColorReset)
+		case status.Error != "":
+			fmt.Printf("%s is unavailable (%s).\n", state.NewClient, status.Error)
+		case status.IsSynced:
+			fmt.Printf("%s%s is fully synced.%s\n", terminal.ColorGreen, state.NewClient, terminal.ColorReset)
+			return true, nil
+		default:
+			fmt.Printf("%s is still syncing (%0.2f%%).\n", state.NewClient, client.SyncRatioToPercent(status.SyncProgress))
+		}
+
+		if noWait {
+			fmt.Printf("Run `hyperdrive service switch-client %s %s` again once it has synced to finish the switch.\n", state.Type, state.NewClient)
+			return false, nil
+		}
+		time.Sleep(switchClientSyncCheckInterval)
+	}
+}
+
+// Get the sync status of the primary client being switched
+func getClientSwitchSyncStatus(hd *client.HyperdriveClient, dataType client.ChainDataType) (*types.ClientStatus, error) {
+	response, err := hd.Api.Service.ClientStatus()
+	if err != nil {
+		return nil, err
+	}
+	if dataType == client.ChainDataType_Beacon {
+		return &response.Data.BcManagerStatus.PrimaryClientStatus, nil
+	}
+	return &response.Data.EcManagerStatus.PrimaryClientStatus, nil
+}
+
+// Remove the old client and its data, and restore the fallback settings
+func finishClientSwitch(c *cli.Context, hd *client.HyperdriveClient, cfg *client.GlobalConfig, state *client.ClientSwitchState) error {
+	// Remove the old container
+	if state.OldContainer != "" {
+		exists, err := hd.ContainerExists(state.OldContainer)
+		if err != nil {
+			return err
+		}
+		if exists {
+			fmt.Printf("Stopping %s...\n", state.OldContainer)
+			err = hd.StopContainer(state.OldContainer)
+			if err != nil {
+				fmt.Printf("%sWARNING: Stopping %s failed: %s%s\n", terminal.ColorYellow, state.OldContainer, err.Error(), terminal.ColorReset)
+			}
+			fmt.Printf("Deleting %s...\n", state.OldContainer)
+			err = hd.RemoveContainer(state.OldContainer)
+			if err != nil {
+				return fmt.Errorf("error deleting %s: %w", state.OldContainer, err)
+			}
+		}
+	}
+
+	// Restore the fallback settings
+	if state.EnabledFallback {
+		fmt.Println("Restoring your fallback client settings...")
+		cfg.Hyperdrive.Fallback.UseFallbackClients.Value = state.PreviousFallback.UseFallbackClients
+		cfg.Hyperdrive.Fallback.EcHttpUrl.Value = state.PreviousFallback.EcHttpUrl
+		cfg.Hyperdrive.Fallback.BnHttpUrl.Value = state.PreviousFallback.BnHttpUrl
+		cfg.Hyperdrive.Fallback.PrysmRpcUrl.Value = state.PreviousFallback.PrysmRpcUrl
+		err := hd.SaveConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("error saving config: %w", err)
+		}
+		err = startService(c, StartMode_NoUpdate)
+		if err != nil {
+			return fmt.Errorf("error starting Hyperdrive: %w", err)
+		}
+	}
+
+	// Delete the old volume
+	exists, err := hd.VolumeExists(state.OldVolume)
+	if err != nil {
+		return err
+	}
+	if exists {
+		fmt.Printf("Deleting volume %s...\n", state.OldVolume)
+		err = hd.DeleteVolume(state.OldVolume)
+		if err != nil {
+			return fmt.Errorf("error deleting volume: %w", err)
+		}
+	}
+
+	err = hd.DeleteClientSwitchState()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nDone! Your %s is now %s.\n", state.Type.ClientName(), state.NewClient)
+	return nil
+}
+
+// Undo an in-progress client switch, going back to the old client and its data
+func abortClientSwitch(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+
+	// Get the switch
+	state, err := hd.LoadClientSwitchState()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		fmt.Println("There isn't a client switch in progress.")
+		return nil
+	}
+	clientName := state.Type.ClientName()
+	if state.Stage == client.ClientSwitchStage_CleaningUp {
+		return fmt.Errorf("%s has already synced and %s is being removed, so the switch can't be undone; please finish it with `hyperdrive service switch-client %s %s`", state.NewClient, state.OldClient, state.Type, state.NewClient)
+	}
+	oldExists, err := hd.VolumeExists(state.OldVolume)
+	if err != nil {
+		return err
+	}
+	if !oldExists {
+		return fmt.Errorf("the %s volume %s doesn't exist anymore, so the switch can't be undone", state.OldClient, state.OldVolume)
+	}
+	newVolume := cfg.Hyperdrive.GetDockerArtifactName(state.NewVolumeSetting)
+
+	fmt.Printf("This will undo the switch of your %s from %s to %s that started on %s:\n", clientName, state.OldClient, state.NewClient, state.StartedAt.Format(time.RFC1123))
+	fmt.Printf("\t- %s will be stopped and %s will run from volume %s again.\n", state.NewClient, state.OldClient, state.OldVolume)
+	if state.EnabledFallback {
+		fmt.Println("\t- Your fallback client settings will be restored.")
+	}
+	fmt.Printf("\t- The data %s has synced so far will be kept in volume %s.\n", state.NewClient, newVolume)
+	fmt.Println()
+	if !(c.Bool(utils.YesFlag.Name) || utils.Confirm(fmt.Sprintf("Are you sure you want to go back to %s?", state.OldClient))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Remove the new client's container and the one that kept the old client running, so the old data is only used by the restored client
+	containerName := cfg.Hyperdrive.GetDockerArtifactName(string(state.Type.ContainerID()))
+	for _, name := range []string{containerName, state.OldContainer} {
+		if name == "" {
+			continue
+		}
+		exists, err := hd.ContainerExists(name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		fmt.Printf("Stopping %s...\n", name)
+		err = hd.StopContainer(name)
+		if err != nil {
+			fmt.Printf("%sWARNING: Stopping %s failed: %s%s\n", terminal.ColorYellow, name, err.Error(), terminal.ColorReset)
+		}
+		fmt.Printf("Deleting %s...\n", name)
+		err = hd.RemoveContainer(name)
+		if err != nil {
+			return fmt.Errorf("error deleting %s: %w", name, err)
+		}
+	}
+
+	// Point the config back at the old client; older switches didn't record the volume setting, so get it from the volume name
+	oldVolumeSetting := state.OldVolumeSetting
+	if oldVolumeSetting == "" {
+		oldVolumeSetting = strings.TrimPrefix(state.OldVolume, cfg.Hyperdrive.ProjectName.Value+"_")
+	}
+	switch state.Type {
+	case client.ChainDataType_Execution:
+		cfg.Hyperdrive.LocalExecutionClient.ExecutionClient.Value = config.ExecutionClient(state.OldClient)
+		cfg.Cli.ClientVolumes.EcDataVolume.Value = oldVolumeSetting
+	case client.ChainDataType_Beacon:
+		cfg.Hyperdrive.LocalBeaconClient.BeaconNode.Value = config.BeaconNode(state.OldClient)
+		cfg.Cli.ClientVolumes.BnDataVolume.Value = oldVolumeSetting
+	}
+	if state.EnabledFallback {
+		cfg.Hyperdrive.Fallback.UseFallbackClients.Value = state.PreviousFallback.UseFallbackClients
+		cfg.Hyperdrive.Fallback.EcHttpUrl.Value = state.PreviousFallback.EcHttpUrl
+		cfg.Hyperdrive.Fallback.BnHttpUrl.Value = state.PreviousFallback.BnHttpUrl
+		cfg.Hyperdrive.Fallback.PrysmRpcUrl.Value = state.PreviousFallback.PrysmRpcUrl
+	}
+	err = hd.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	err = hd.DeleteClientSwitchState()
+	if err != nil {
+		return err
+	}
+
+	// Start the old client again
+	fmt.Printf("Starting %s...\n", state.OldClient)
+	err = startService(c, StartMode_NoUpdate)
+	if err != nil {
+		return fmt.Errorf("error starting Hyperdrive: %w", err)
+	}
+	fmt.Printf("\nDone! Your %s is %s again. You can delete the data %s synced with `hyperdrive service cleanup --include-volumes` once you don't need it.\n", clientName, state.OldClient, state.NewClient)
+	return nil
+}
+
+// Get the default volume name for a client's data, without the project prefix
+func defaultClientVolume(dataType client.ChainDataType) string {
+	if dataType == client.ChainDataType_Beacon {
+		return hdconfig.BeaconNodeDataVolume
+	}
+	return hdconfig.ExecutionClientDataVolume
+}
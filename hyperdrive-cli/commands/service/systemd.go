@@ -0,0 +1,172 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	cliutils "github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	systemdHealthCheckIntervalFlag *cli.DurationFlag = &cli.DurationFlag{
+		Name:  "health-check-interval",
+		Usage: "Also install a timer that checks for stuck or unhealthy containers on this interval (such as 15m); leave it out to skip the health check",
+	}
+)
+
+// Install the systemd units
+func installSystemdUnits(c *cli.Context) error {
+	hd, cfg, err := loadSystemdConfig(c)
+	if err != nil {
+		return err
+	}
+	units := client.GetSystemdUnits(cfg.Hyperdrive.ProjectName.Value)
+	interval := c.Duration(systemdHealthCheckIntervalFlag.Name)
+	if interval < 0 {
+		return fmt.Errorf("the health check interval can't be negative")
+	}
+
+	fmt.Printf("This will install the %s systemd unit, which starts Hyperdrive as your user when this machine boots and stops it cleanly when it shuts down.\n", units.Service)
+	fmt.Printf("It will also install %s, which prunes your Execution client once a day if its free space has dropped below the auto-prune threshold in `hyperdrive service config`.\n", units.PruneTimer)
+	if interval > 0 {
+		fmt.Printf("It will also install %s, which checks the containers every %s.\n", units.HealthTimer, interval)
+	}
+	fmt.Println("Installing it requires root privileges.")
+	fmt.Println()
+	if !(c.Bool(cliutils.YesFlag.Name) || cliutils.Confirm("Would you like to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = hd.InstallSystemdUnits(cfg, interval)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sInstalled %s successfully.%s\n", terminal.ColorGreen, units.Service, terminal.ColorReset)
+	fmt.Println("Hyperdrive will start automatically the next time this machine boots. Updates installed by your package manager will restart it through systemd as well.")
+	if cfg.Cli.ContainerRuntime.Runtime.Value.IsRootless() {
+		fmt.Printf("%sNOTE: %s runs under your own user, so it only starts on boot if lingering is enabled for your account. If you haven't already, enable it with `loginctl enable-linger`.%s\n", terminal.ColorYellow, cfg.Cli.ContainerRuntime.Runtime.Value, terminal.ColorReset)
+	}
+	return nil
+}
+
+// Uninstall the systemd units
+func uninstallSystemdUnits(c *cli.Context) error {
+	hd, cfg, err := loadSystemdConfig(c)
+	if err != nil {
+		return err
+	}
+	units := client.GetSystemdUnits(cfg.Hyperdrive.ProjectName.Value)
+	if !client.IsSystemdUnitInstalled(cfg.Hyperdrive.ProjectName.Value) {
+		fmt.Printf("%s isn't installed.\n", units.Service)
+		return nil
+	}
+
+	if !(c.Bool(cliutils.YesFlag.Name) || cliutils.Confirm(fmt.Sprintf("Are you sure you want to remove %s? Hyperdrive will keep running, but it won't be started and stopped with this machine anymore.", units.Service))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	err = hd.UninstallSystemdUnits(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sRemoved %s successfully.%s\n", terminal.ColorGreen, units.Service, terminal.ColorReset)
+	return nil
+}
+
+// Print the status of the systemd units
+func getSystemdStatus(c *cli.Context) error {
+	hd, cfg, err := loadSystemdConfig(c)
+	if err != nil {
+		return err
+	}
+	if !client.IsSystemdUnitInstalled(cfg.Hyperdrive.ProjectName.Value) {
+		fmt.Println("Hyperdrive's systemd unit isn't installed. You can install it with `hyperdrive service systemd install`.")
+		return nil
+	}
+	return hd.PrintSystemdStatus(cfg)
+}
+
+// Stop the service for systemd, stopping the Validator Clients before the clients they depend on
+func stopServiceForSystemd(c *cli.Context) error {
+	hd, cfg, err := loadSystemdConfig(c)
+	if err != nil {
+		return err
+	}
+
+	vcs, err := hd.GetValidatorContainers(cfg.Hyperdrive.ProjectName.Value + "_")
+	if err != nil {
+		return fmt.Errorf("error getting validator client containers: %w", err)
+	}
+	for _, vc := range vcs {
+		fmt.Printf("Stopping %s...\n", vc)
+		err = hd.StopContainer(vc)
+		if err != nil {
+			return fmt.Errorf("error stopping VC [%s]: %w", vc, err)
+		}
+	}
+	return hd.StopService(getComposeFiles(c))
+}
+
+// Check for containers that are stuck or unhealthy, for the systemd health check timer
+func checkHealthForSystemd(c *cli.Context) error {
+	hd, cfg, err := loadSystemdConfig(c)
+	if err != nil {
+		return err
+	}
+	projectName := cfg.Hyperdrive.ProjectName.Value
+	if !client.IsSystemdUnitActive(projectName) {
+		fmt.Println("Hyperdrive isn't running, skipping the health check.")
+		return nil
+	}
+
+	// Make sure the daemon is up
+	running, err := hd.GetRunningContainers(projectName)
+	if err != nil {
+		return err
+	}
+	problems := []string{}
+	daemonName := cfg.Hyperdrive.GetDockerArtifactName(string(config.ContainerID_Daemon))
+	if !running[daemonName] {
+		problems = append(problems, fmt.Sprintf("%s isn't running", daemonName))
+	}
+
+	// Check the rest of them
+	unhealthy, err := hd.GetUnhealthyContainers(projectName)
+	if err != nil {
+		return err
+	}
+	for name, status := range unhealthy {
+		problems = append(problems, fmt.Sprintf("%s is %s", name, status))
+	}
+	if len(problems) == 0 {
+		fmt.Println("All containers are healthy.")
+		return nil
+	}
+	sort.Strings(problems)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	return fmt.Errorf("%d container(s) need attention; check them with `hyperdrive service status` and `hyperdrive service logs`", len(problems))
+}
+
+// Load the Hyperdrive client and config for the systemd commands
+func loadSystemdConfig(c *cli.Context) (*client.HyperdriveClient, *client.GlobalConfig, error) {
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return nil, nil, fmt.Errorf("no configuration detected; please run `hyperdrive service config` to set up Hyperdrive first")
+	}
+	return hd, cfg, nil
+}
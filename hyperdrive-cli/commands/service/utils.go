@@ -10,7 +10,6 @@ import (
 
 // Settings
 const (
-	clientDataVolumeName string = "/ethclient"
 	dataFolderVolumeName string = "/.hyperdrive/data"
 
 	PruneFreeSpaceRequired uint64 = 50 * 1024 * 1024 * 1024
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/terminal"
+	"github.com/urfave/cli/v2"
+)
+
+// Cross-verify the checkpoint sync provider against the other configured sources
+func verifyCheckpoint(c *cli.Context) error {
+	// Get Hyperdrive client
+	hd, err := client.NewHyperdriveClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+
+	// Get the config
+	cfg, isNew, err := hd.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `hyperdrive service config` to set up Hyperdrive.")
+	}
+	if cfg.Hyperdrive.LocalBeaconClient.CheckpointSyncProvider.Value == "" {
+		fmt.Println("You do not have a checkpoint sync provider configured.")
+		return nil
+	}
+
+	_, err = checkCheckpointSources(hd, cfg)
+	return err
+}
+
+// Compare the finalized checkpoint across the configured sources, print the results, and save the checkpoint if it was verified.
+// Returns true if the checkpoint sync provider can be used according to the user's settings.
+func checkCheckpointSources(hd *client.HyperdriveClient, cfg *client.GlobalConfig) (bool, error) {
+	sources := client.GetCheckpointSources(cfg)
+	requireAgreement := cfg.Cli.CheckpointSync.RequireAgreement.Value
+	network := cfg.Hyperdrive.Network.Value
+	ctx := context.Background()
+
+	// Compare the sources
+	fmt.Printf("Checking the finalized checkpoint with %d source(s)...\n", len(sources))
+	verification := client.VerifyCheckpoint(ctx, sources)
+	for _, result := range verification.Results {
+		if result.Error != nil {
+			fmt.Printf("\t%s (%s): %sERROR: %s%s\n", result.Source.Name, result.Source.Url, terminal.ColorYellow, result.Error.Error(), terminal.ColorReset)
+			continue
+		}
+		fmt.Printf("\t%s (%s): slot %d, block root %s, state root %s\n", result.Source.Name, result.Source.Url, result.Header.Slot, result.Header.BlockRoot, result.Header.StateRoot)
+	}
+	fmt.Println()
+
+	// Make sure the providers still agree with the last checkpoint that was verified; a single source can't be trusted on its own
+	trusted := verification.IsVerified()
+	previous, err := hd.LoadVerifiedCheckpoint()
+	if err != nil {
+		return false, err
+	}
+	if previous != nil && previous.Network == network && len(sources) > 0 {
+		err = client.CheckSourceHasCheckpoint(ctx, sources[0], previous)
+		if err != nil {
+			fmt.Printf("%sYour checkpoint sync provider doesn't match the checkpoint verified on %s: %s%s\n", terminal.ColorRed, previous.VerifiedAt.Format(time.RFC1123), err.Error(), terminal.ColorReset)
+			trusted = false
+		}
+	}
+
+	// Report the results
+	switch {
+	case verification.Responded == 0:
+		fmt.Printf("%sNone of your checkpoint sources responded.%s\n", terminal.ColorRed, terminal.ColorReset)
+		trusted = false
+	case !verification.PrimaryResponded():
+		fmt.Printf("%sYour checkpoint sync provider didn't respond, so its checkpoint could not be verified.%s\n", terminal.ColorRed, terminal.ColorReset)
+	case !verification.Agreed:
+		fmt.Printf("%sYour checkpoint sources DISAGREE on the finalized checkpoint at slot %d. One of them may be malicious or on the wrong chain.%s\n", terminal.ColorRed, verification.Slot, terminal.ColorReset)
+	case verification.Responded == 1:
+		fmt.Printf("%sOnly one checkpoint source responded, so the checkpoint could not be cross-verified. Add more providers with `hyperdrive service config` to enable verification.%s\n", terminal.ColorYellow, terminal.ColorReset)
+	}
+
+	// Save the checkpoint for later checks
+	if trusted {
+		header := verification.GetAgreedHeader()
+		checkpoint := &client.VerifiedCheckpoint{
+			Network:    network,
+			Slot:       header.Slot,
+			BlockRoot:  header.BlockRoot,
+			StateRoot:  header.StateRoot,
+			VerifiedAt: time.Now(),
+		}
+		for _, result := range verification.Results {
+			if result.Error == nil {
+				checkpoint.Sources = append(checkpoint.Sources, result.Source.Url)
+			}
+		}
+		err = hd.SaveVerifiedCheckpoint(checkpoint)
+		if err != nil {
+			return false, err
+		}
+		fmt.Printf("%d sources agree on the finalized checkpoint at slot %d (block root %s).\n", verification.Responded, header.Slot, header.BlockRoot)
+	}
+
+	if !trusted {
+		if requireAgreement {
+			fmt.Printf("%sHyperdrive will not use this checkpoint. Please check your checkpoint providers, or disable \"%s\" in `hyperdrive service config` to ignore this.%s\n", terminal.ColorRed, cfg.Cli.CheckpointSync.RequireAgreement.Name, terminal.ColorReset)
+			return false, nil
+		}
+		fmt.Printf("%sWARNING: Continuing anyway because \"%s\" is disabled.%s\n", terminal.ColorYellow, cfg.Cli.CheckpointSync.RequireAgreement.Name, terminal.ColorReset)
+	}
+	return true, nil
+}
@@ -1,12 +1,15 @@
 package context
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"math/big"
 	"net/url"
 	"os"
 	"path/filepath"
 
+	"github.com/mitchellh/go-homedir"
 	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
 	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
 	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
@@ -15,6 +18,9 @@ import (
 
 const (
 	contextMetadataName string = "hd-context"
+
+	// The folder in the user directory that holds user-defined network settings, laid out like the system networks folder
+	CustomNetworksDir string = "networks"
 )
 
 // Context for global settings
@@ -88,6 +94,48 @@ func (c *HyperdriveContext) LoadNetworkSettings() error {
 	if err != nil {
 		return fmt.Errorf("error loading constellation network settings from path [%s]: %s", csNetSettingsDir, err.Error())
 	}
+	return c.loadCustomNetworkSettings()
+}
+
+// Get the path of the folder holding user-defined network settings
+func (c *HyperdriveContext) GetCustomNetworksDir() (string, error) {
+	userDir, err := homedir.Expand(c.UserDirPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding user directory path: %w", err)
+	}
+	return filepath.Join(userDir, CustomNetworksDir), nil
+}
+
+// Add any user-defined networks to the built-in ones
+func (c *HyperdriveContext) loadCustomNetworkSettings() error {
+	customDir, err := c.GetCustomNetworksDir()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(customDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	hdSettings, err := hdconfig.LoadSettingsFiles(customDir)
+	if err != nil {
+		return fmt.Errorf("error loading custom hyperdrive network settings from path [%s]: %s", customDir, err.Error())
+	}
+	c.HyperdriveNetworkSettings = append(c.HyperdriveNetworkSettings, hdSettings...)
+
+	swNetSettingsDir := filepath.Join(customDir, hdconfig.ModulesName, swconfig.ModuleName)
+	swSettings, err := swconfig.LoadSettingsFiles(swNetSettingsDir)
+	if err != nil {
+		return fmt.Errorf("error loading custom stakewise network settings from path [%s]: %s", swNetSettingsDir, err.Error())
+	}
+	c.StakeWiseNetworkSettings = append(c.StakeWiseNetworkSettings, swSettings...)
+
+	csNetSettingsDir := filepath.Join(customDir, hdconfig.ModulesName, csconfig.ModuleName)
+	csSettings, err := csconfig.LoadSettingsFiles(csNetSettingsDir)
+	if err != nil {
+		return fmt.Errorf("error loading custom constellation network settings from path [%s]: %s", csNetSettingsDir, err.Error())
+	}
+	c.ConstellationNetworkSettings = append(c.ConstellationNetworkSettings, csSettings...)
 	return nil
 }
 
@@ -13,6 +13,15 @@ import (
 	"golang.org/x/term"
 )
 
+var (
+	// The scanner used to read user input.
+	// It's shared between prompts so input that's piped in isn't lost when one prompt's scanner reads ahead of its own line.
+	inputScanner *bufio.Scanner
+
+	// The file the input scanner reads from
+	inputSource *os.File
+)
+
 // Prompt for user input
 func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt string) string {
 
@@ -20,7 +29,7 @@ func Prompt(initialPrompt string, expectedFormat string, incorrectFormatPrompt s
 	fmt.Println(initialPrompt)
 
 	// Get valid user input
-	scanner := bufio.NewScanner(os.Stdin)
+	scanner := getInputScanner()
 	for scanner.Scan(); !regexp.MustCompile(expectedFormat).MatchString(scanner.Text()); scanner.Scan() {
 		fmt.Println("")
 		fmt.Println(incorrectFormatPrompt)
@@ -112,3 +121,12 @@ func PromptPassword(initialPrompt string, expectedFormat string, incorrectFormat
 	fmt.Println("")
 	return input
 }
+
+// Get the scanner for reading user input from stdin, making a new one if stdin has been replaced
+func getInputScanner() *bufio.Scanner {
+	if inputScanner == nil || inputSource != os.Stdin {
+		inputScanner = bufio.NewScanner(os.Stdin)
+		inputSource = os.Stdin
+	}
+	return inputScanner
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"testing"
+
+	"github.com/nodeset-org/hyperdrive/internal/tests/harness"
+	"github.com/rocket-pool/node-manager-core/config"
+	"github.com/rocket-pool/node-manager-core/log"
+)
+
+// Various singleton variables used for testing
+var (
+	testHarness *harness.CliHarness = nil
+	logger      *slog.Logger        = nil
+)
+
+// Initialize a common harness used by all tests
+func TestMain(m *testing.M) {
+	var err error
+	logger = slog.Default()
+
+	// Create the harness
+	testHarness, err = harness.NewCliHarness(logger, "../../../install/deploy", config.Network_Hoodi)
+	if err != nil {
+		fail("error creating CLI harness: %v", err)
+	}
+
+	// Run tests
+	code := m.Run()
+
+	// Clean up and exit
+	mainCleanup()
+	os.Exit(code)
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format, args...)
+	mainCleanup()
+	os.Exit(1)
+}
+
+func mainCleanup() {
+	if testHarness == nil {
+		return
+	}
+	err := testHarness.Close()
+	if err != nil {
+		logger.Error("Error closing CLI harness", log.Err(err))
+	}
+	testHarness = nil
+}
+
+func handle_panics() {
+	// Handle panics
+	r := recover()
+	if r != nil {
+		debug.PrintStack()
+		fail("Recovered from panic: %v", r)
+	}
+}
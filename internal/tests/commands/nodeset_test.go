@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/nodeset-org/hyperdrive-daemon/shared/types/api"
+	"github.com/nodeset-org/hyperdrive/internal/tests/harness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistrationStatus_DeclineRegistration(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	setUnregisteredNode(t)
+
+	result, err := testHarness.Run([]string{"n"}, "nodeset", "registration-status")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "nodeset-registration-status-decline", result)
+
+	// Declining shouldn't register the node
+	for _, request := range testHarness.Hyperdrive.GetRequests() {
+		require.NotEqual(t, "nodeset/register-node", request.Path)
+	}
+}
+
+func TestRegisterNode_InvalidThenValidEmail(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	setUnregisteredNode(t)
+	err = testHarness.Hyperdrive.SetResponse("nodeset/register-node", api.NodeSetRegisterNodeData{
+		Success: true,
+	})
+	require.NoError(t, err)
+
+	result, err := testHarness.Run([]string{"not an email", "operator@example.com"}, "nodeset", "register-node")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "nodeset-register-node", result)
+
+	// Make sure the valid email was the one sent to the daemon
+	var registered bool
+	for _, request := range testHarness.Hyperdrive.GetRequests() {
+		if request.Path == "nodeset/register-node" {
+			require.Equal(t, "operator@example.com", request.Query.Get("email"))
+			registered = true
+		}
+	}
+	require.True(t, registered)
+}
+
+func TestRegisterNode_NotWhitelisted(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	setUnregisteredNode(t)
+	err = testHarness.Hyperdrive.SetResponse("nodeset/register-node", api.NodeSetRegisterNodeData{
+		NotWhitelisted: true,
+	})
+	require.NoError(t, err)
+
+	result, err := testHarness.Run(nil, "nodeset", "register-node", "--email", "operator@example.com")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "nodeset-register-node-not-whitelisted", result)
+}
+
+// Set up the mock daemon for a node with a ready wallet that hasn't registered with NodeSet
+func setUnregisteredNode(t *testing.T) {
+	err := testHarness.Hyperdrive.SetResponse("wallet/status", api.WalletStatusData{
+		WalletStatus: getReadyWalletStatus(),
+	})
+	require.NoError(t, err)
+	err = testHarness.Hyperdrive.SetResponse("nodeset/get-registration-status", api.NodeSetGetRegistrationStatusData{
+		Status: api.NodeSetRegistrationStatus_Unregistered,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/internal/tests/harness"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkList(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "network", "list")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "service-network-list", result)
+}
+
+func TestNetworkRemove_BuiltIn(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "network", "remove", "--yes", "mainnet")
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+	harness.RequireGolden(t, "service-network-remove-built-in", result)
+}
+
+func TestConfigWizardAnswers_InvalidChoice(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	answersPath := filepath.Join(testHarness.GetUserDir(), "answers.yml")
+	answers := "version: v1.2.2\nanswers:\n  - step: step-welcome\n    choice: Next\n  - step: step-network\n    choice: Sepolia\n"
+	err = os.WriteFile(answersPath, []byte(answers), 0600)
+	require.NoError(t, err)
+
+	result, err := testHarness.Run(nil, "service", "config", "--wizard-answers", answersPath)
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+	harness.RequireGolden(t, "service-config-wizard-answers-invalid", result)
+}
+
+func TestConfigSchema(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "config", "schema", "--format", "json-schema")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+
+	schema := map[string]any{}
+	err = json.Unmarshal([]byte(result.Output), &schema)
+	require.NoError(t, err)
+	require.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+
+	// Check a choice parameter and the module sections
+	properties := schema["properties"].(map[string]any)
+	hyperdrive := properties["hyperdrive"].(map[string]any)["properties"].(map[string]any)
+	network := hyperdrive["network"].(map[string]any)
+	require.Equal(t, "string", network["type"])
+	require.NotEmpty(t, network["enum"])
+	modules := properties["modules"].(map[string]any)["properties"].(map[string]any)
+	require.Contains(t, modules, "stakewise")
+	require.Contains(t, modules, "constellation")
+}
+
+func TestConfigSchema_UnsupportedFormat(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "config", "schema", "--format", "xml")
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+}
+
+func TestConfigExport(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	exportPath := filepath.Join(testHarness.GetUserDir(), "export.yml")
+	result, err := testHarness.Run(nil, "service", "config", "export", exportPath)
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "service-config-export", result)
+
+	// Machine-specific settings should be left out
+	export, err := client.LoadConfigExport(exportPath)
+	require.NoError(t, err)
+	hyperdrive := export.Settings["hyperdrive"].(map[string]any)
+	require.NotContains(t, hyperdrive, "apiPort")
+	require.NotContains(t, hyperdrive, "hdUserDataDir")
+	require.NotContains(t, hyperdrive, "additionalDockerNetworks")
+	require.Contains(t, hyperdrive, "clientTimeout")
+	cli := export.Settings["modules"].(map[string]any)["cli"].(map[string]any)
+	require.NotContains(t, cli, "clientVolumes")
+	require.NotEmpty(t, export.LocalSettings)
+}
+
+func TestFirewallPrint(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "firewall", "print", "--backend", "iptables")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+
+	// P2P ports should be opened, and the daemon API port shouldn't
+	require.Contains(t, result.Output, "$ipt -A HYPERDRIVE -p tcp --dport 30303 ")
+	require.Contains(t, result.Output, "$ipt -A HYPERDRIVE -p udp --dport 9001 ")
+	require.Contains(t, result.Output, "$ipt -C INPUT -j HYPERDRIVE")
+	for _, line := range strings.Split(result.Output, "\n") {
+		if strings.Contains(line, "-j ACCEPT") {
+			require.NotContains(t, line, "hyperdrive.apiPort")
+		}
+	}
+}
+
+func TestFirewallPrint_UnknownBackend(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	result, err := testHarness.Run(nil, "service", "firewall", "print", "--backend", "pf")
+	require.NoError(t, err)
+	require.Error(t, result.Err)
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nodeset-org/hyperdrive-daemon/shared/types/api"
+	"github.com/nodeset-org/hyperdrive/internal/tests/harness"
+	"github.com/rocket-pool/node-manager-core/eth"
+	"github.com/rocket-pool/node-manager-core/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nodeAddress common.Address = common.HexToAddress("0x90F79bf6EB2c4f870365E785982E1f101E93b906")
+)
+
+func TestWalletStatus_Ready(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	err = testHarness.Hyperdrive.SetResponse("wallet/status", api.WalletStatusData{
+		WalletStatus: getReadyWalletStatus(),
+	})
+	require.NoError(t, err)
+	err = testHarness.Hyperdrive.SetResponse("wallet/balance", api.WalletBalanceData{
+		Balance: eth.EthToWei(1.5),
+	})
+	require.NoError(t, err)
+
+	result, err := testHarness.Run(nil, "wallet", "status")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "wallet-status-ready", result)
+}
+
+func TestWalletStatus_NoWallet(t *testing.T) {
+	err := testHarness.Reset()
+	require.NoError(t, err)
+	defer handle_panics()
+
+	err = testHarness.Hyperdrive.SetResponse("wallet/status", api.WalletStatusData{})
+	require.NoError(t, err)
+
+	result, err := testHarness.Run(nil, "wallet", "status")
+	require.NoError(t, err)
+	require.NoError(t, result.Err)
+	harness.RequireGolden(t, "wallet-status-no-wallet", result)
+
+	// The balance shouldn't be requested without a wallet
+	for _, request := range testHarness.Hyperdrive.GetRequests() {
+		require.NotEqual(t, "wallet/balance", request.Path)
+	}
+}
+
+// Get the status of a wallet that's loaded and ready to transact
+func getReadyWalletStatus() wallet.WalletStatus {
+	status := wallet.WalletStatus{}
+	status.Address.HasAddress = true
+	status.Address.NodeAddress = nodeAddress
+	status.Wallet.IsLoaded = true
+	status.Wallet.IsOnDisk = true
+	status.Wallet.WalletAddress = nodeAddress
+	status.Password.IsPasswordSaved = true
+	return status
+}
@@ -0,0 +1,43 @@
+package harness
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// The folder golden files are stored in, relative to the test package
+	goldenDir string = "testdata"
+
+	// The extension for golden files
+	goldenExtension string = ".golden"
+)
+
+var (
+	// Set with `go test -update` to rewrite the golden files with the actual output instead of comparing against them
+	updateGolden *bool = flag.Bool("update", false, "Update the golden files with the actual command output")
+)
+
+// Compare a command's result against the golden file with the provided name, or rewrite the golden file if -update is set
+func RequireGolden(t *testing.T, name string, result *CommandResult) {
+	t.Helper()
+	path := filepath.Join(goldenDir, name+goldenExtension)
+	actual := result.String()
+
+	if *updateGolden {
+		err := os.MkdirAll(goldenDir, 0755)
+		require.NoError(t, err)
+		err = os.WriteFile(path, []byte(actual), 0644)
+		require.NoError(t, err)
+		t.Logf("Updated golden file [%s]", path)
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "error reading golden file [%s]; run the test with -update to create it", path)
+	require.Equal(t, string(expected), actual, "output doesn't match golden file [%s]; run the test with -update if the change is intended", path)
+}
@@ -0,0 +1,244 @@
+package harness
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	csconfig "github.com/nodeset-org/hyperdrive-constellation/shared/config"
+	hdconfig "github.com/nodeset-org/hyperdrive-daemon/shared/config"
+	swconfig "github.com/nodeset-org/hyperdrive-stakewise/shared/config"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/app"
+	hdclient "github.com/nodeset-org/hyperdrive/hyperdrive-cli/client"
+	"github.com/nodeset-org/hyperdrive/hyperdrive-cli/utils/context"
+	"github.com/nodeset-org/osha/filesystem"
+	"github.com/rocket-pool/node-manager-core/config"
+)
+
+const (
+	// The name of the snapshot of the user directory taken after the harness sets it up
+	baselineSnapshotName string = "cli-baseline"
+
+	// How long a command can run before the harness gives up on it
+	DefaultCommandTimeout time.Duration = 30 * time.Second
+)
+
+var (
+	// Matches the ANSI escape codes used for terminal colors
+	ansiRegex *regexp.Regexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+)
+
+// The result of running a CLI command
+type CommandResult struct {
+	// Everything the command printed to stdout and stderr, normalized so it can be compared between runs
+	Output string
+
+	// The error the command returned, if any
+	Err error
+
+	// The error's message, normalized like the output
+	errMessage string
+}
+
+// Get the output and error of a command, in the form stored in golden files
+func (r *CommandResult) String() string {
+	if r.Err == nil {
+		return r.Output
+	}
+	return fmt.Sprintf("%s\n[error] %s\n", r.Output, r.errMessage)
+}
+
+// Runs CLI commands through the real application against mock API servers for Hyperdrive and its modules
+type CliHarness struct {
+	// The mock Hyperdrive daemon
+	Hyperdrive *MockApiServer
+
+	// The mock StakeWise daemon
+	StakeWise *MockApiServer
+
+	// The mock Constellation daemon
+	Constellation *MockApiServer
+
+	// How long a command can run before the harness gives up on it
+	CommandTimeout time.Duration
+
+	logger    *slog.Logger
+	fsManager *filesystem.FilesystemManager
+}
+
+// Create a new CLI harness.
+// systemDir is the folder with the network settings and templates, usually the repo's install/deploy folder.
+// The harness creates a user directory with a new configuration for the provided network that points at the mock servers.
+func NewCliHarness(logger *slog.Logger, systemDir string, network config.Network) (*CliHarness, error) {
+	err := os.Setenv(context.TestSystemDirEnvVar, systemDir)
+	if err != nil {
+		return nil, fmt.Errorf("error setting system dir: %w", err)
+	}
+	fsManager, err := filesystem.NewFilesystemManager(logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating filesystem manager: %w", err)
+	}
+
+	h := &CliHarness{
+		Hyperdrive:     NewMockApiServer(hdconfig.HyperdriveApiClientRoute),
+		StakeWise:      NewMockApiServer(swconfig.ApiClientRoute),
+		Constellation:  NewMockApiServer(csconfig.ApiClientRoute),
+		CommandTimeout: DefaultCommandTimeout,
+		logger:         logger,
+		fsManager:      fsManager,
+	}
+	err = h.createConfig(network)
+	if err != nil {
+		_ = h.Close()
+		return nil, err
+	}
+	err = fsManager.TakeSnapshot(baselineSnapshotName)
+	if err != nil {
+		_ = h.Close()
+		return nil, fmt.Errorf("error taking baseline snapshot: %w", err)
+	}
+	return h, nil
+}
+
+// Get the user directory the commands run against
+func (h *CliHarness) GetUserDir() string {
+	return h.fsManager.GetTestDir()
+}
+
+// Restore the user directory to its original state and clear the mock servers
+func (h *CliHarness) Reset() error {
+	err := h.fsManager.RevertToSnapshot(baselineSnapshotName)
+	if err != nil {
+		return fmt.Errorf("error reverting to baseline snapshot: %w", err)
+	}
+	h.Hyperdrive.Reset()
+	h.StakeWise.Reset()
+	h.Constellation.Reset()
+	return nil
+}
+
+// Run a command through the CLI application, feeding it the provided lines of input when it prompts for them.
+// args are the arguments after the global flags, such as "wallet", "status".
+func (h *CliHarness) Run(input []string, args ...string) (*CommandResult, error) {
+	fullArgs := []string{
+		"hyperdrive",
+		"--allow-root",
+		"--config-path", h.GetUserDir(),
+		"--api-address", h.Hyperdrive.GetUrl(),
+	}
+	fullArgs = append(fullArgs, args...)
+
+	// Swap out the standard streams
+	stdinReader, stdinWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("error creating stdin pipe: %w", err)
+	}
+	stdoutReader, stdoutWriter, err := os.Pipe()
+	if err != nil {
+		_ = stdinReader.Close()
+		_ = stdinWriter.Close()
+		return nil, fmt.Errorf("error creating stdout pipe: %w", err)
+	}
+	oldStdin, oldStdout, oldStderr := os.Stdin, os.Stdout, os.Stderr
+	os.Stdin, os.Stdout, os.Stderr = stdinReader, stdoutWriter, stdoutWriter
+
+	// Collect the output
+	outputChannel := make(chan []byte)
+	go func() {
+		output, _ := io.ReadAll(stdoutReader)
+		outputChannel <- output
+	}()
+
+	// Feed in the input; the pipe stays open so a command asking for more input than was scripted blocks instead of spinning on EOF
+	go func() {
+		for _, line := range input {
+			_, _ = stdinWriter.WriteString(line + "\n")
+		}
+	}()
+
+	// Run the command
+	errChannel := make(chan error, 1)
+	go func() {
+		errChannel <- app.NewApp().Run(fullArgs)
+	}()
+	var runErr error
+	timedOut := false
+	select {
+	case runErr = <-errChannel:
+	case <-time.After(h.CommandTimeout):
+		timedOut = true
+	}
+
+	// Restore the standard streams and get the output
+	os.Stdin, os.Stdout, os.Stderr = oldStdin, oldStdout, oldStderr
+	_ = stdoutWriter.Close()
+	output := <-outputChannel
+	_ = stdoutReader.Close()
+	if !timedOut {
+		_ = stdinWriter.Close()
+		_ = stdinReader.Close()
+	}
+
+	result := &CommandResult{
+		Output: h.normalize(string(output)),
+		Err:    runErr,
+	}
+	if runErr != nil {
+		result.errMessage = h.normalize(runErr.Error())
+	}
+	if timedOut {
+		return result, fmt.Errorf("command [%s] didn't finish within %s; it may be waiting for more input than was provided. Output so far:\n%s", strings.Join(args, " "), h.CommandTimeout, result.Output)
+	}
+	return result, nil
+}
+
+// Stop the mock servers and delete the user directory
+func (h *CliHarness) Close() error {
+	h.Hyperdrive.Close()
+	h.StakeWise.Close()
+	h.Constellation.Close()
+	return h.fsManager.Close()
+}
+
+// Create a new config in the user directory that points the modules at the mock servers
+func (h *CliHarness) createConfig(network config.Network) error {
+	hdCtx := context.NewHyperdriveContext(h.GetUserDir(), nil)
+	err := hdCtx.LoadNetworkSettings()
+	if err != nil {
+		return fmt.Errorf("error loading network settings: %w", err)
+	}
+	hdClient, err := hdclient.NewHyperdriveClientFromHyperdriveCtx(hdCtx)
+	if err != nil {
+		return fmt.Errorf("error creating Hyperdrive client: %w", err)
+	}
+	cfg, _, err := hdClient.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error creating config: %w", err)
+	}
+
+	cfg.ChangeNetwork(network)
+	cfg.Hyperdrive.ApiPort.Value = h.Hyperdrive.GetPort()
+	cfg.StakeWise.ApiPort.Value = h.StakeWise.GetPort()
+	cfg.Constellation.ApiPort.Value = h.Constellation.GetPort()
+	err = hdClient.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	return nil
+}
+
+// Remove the parts of a command's output that change between runs, such as colors, paths, and ports
+func (h *CliHarness) normalize(output string) string {
+	output = ansiRegex.ReplaceAllString(output, "")
+	replacer := strings.NewReplacer(
+		h.GetUserDir(), "<USER_DIR>",
+		h.Hyperdrive.GetUrl(), "<HYPERDRIVE_URL>",
+		h.StakeWise.GetUrl(), "<STAKEWISE_URL>",
+		h.Constellation.GetUrl(), "<CONSTELLATION_URL>",
+	)
+	return replacer.Replace(output)
+}
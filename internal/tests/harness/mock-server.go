@@ -0,0 +1,157 @@
+package harness
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/rocket-pool/node-manager-core/api/types"
+)
+
+// A request received by a mock API server
+type MockRequest struct {
+	// The HTTP method of the request
+	Method string
+
+	// The path of the request, relative to the server's API route
+	Path string
+
+	// The query parameters of the request
+	Query url.Values
+
+	// The body of the request
+	Body []byte
+}
+
+// A canned response for a route on a mock API server
+type mockResponse struct {
+	status int
+	body   []byte
+}
+
+// An in-memory stand-in for a daemon's API server that returns canned responses for the routes a test registers,
+// and records every request it receives
+type MockApiServer struct {
+	route     string
+	server    *httptest.Server
+	responses map[string]mockResponse
+	requests  []MockRequest
+	lock      *sync.Mutex
+}
+
+// Create and start a new mock API server that serves the provided API route (such as "hyperdrive/api/v1")
+func NewMockApiServer(route string) *MockApiServer {
+	s := &MockApiServer{
+		route:     strings.Trim(route, "/"),
+		responses: map[string]mockResponse{},
+		requests:  []MockRequest{},
+		lock:      &sync.Mutex{},
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handleRequest))
+	return s
+}
+
+// Get the base URL of the server, without the API route
+func (s *MockApiServer) GetUrl() string {
+	return s.server.URL
+}
+
+// Get the port the server is listening on
+func (s *MockApiServer) GetPort() uint16 {
+	return uint16(s.server.Listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Set the data to return for a route, such as "wallet/status"
+func (s *MockApiServer) SetResponse(path string, data any) error {
+	body, err := json.Marshal(types.ApiResponse[any]{
+		Data: &data,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing response for [%s]: %w", path, err)
+	}
+	s.setResponse(path, http.StatusOK, body)
+	return nil
+}
+
+// Set an error to return for a route, such as "wallet/status"
+func (s *MockApiServer) SetErrorResponse(path string, status int, message string) error {
+	body, err := json.Marshal(types.ApiResponse[any]{
+		Error: message,
+	})
+	if err != nil {
+		return fmt.Errorf("error serializing error response for [%s]: %w", path, err)
+	}
+	s.setResponse(path, status, body)
+	return nil
+}
+
+// Get the requests the server has received since it was last reset
+func (s *MockApiServer) GetRequests() []MockRequest {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	requests := make([]MockRequest, len(s.requests))
+	copy(requests, s.requests)
+	return requests
+}
+
+// Clear the registered responses and the recorded requests
+func (s *MockApiServer) Reset() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.responses = map[string]mockResponse{}
+	s.requests = []MockRequest{}
+}
+
+// Stop the server
+func (s *MockApiServer) Close() {
+	s.server.Close()
+}
+
+// Register a response for a route
+func (s *MockApiServer) setResponse(path string, status int, body []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.responses[strings.Trim(path, "/")] = mockResponse{
+		status: status,
+		body:   body,
+	}
+}
+
+// Record a request and send the response registered for its route
+func (s *MockApiServer) handleRequest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading request body: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	path := strings.TrimPrefix(strings.Trim(r.URL.Path, "/"), s.route)
+	path = strings.Trim(path, "/")
+
+	s.lock.Lock()
+	s.requests = append(s.requests, MockRequest{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	response, exists := s.responses[path]
+	s.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if !exists {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintf(w, `{"error":"no mock response registered for [%s]"}`, path)
+		return
+	}
+	w.WriteHeader(response.status)
+	_, _ = w.Write(response.body)
+}